@@ -0,0 +1,387 @@
+// Package client calls the Go News API over HTTP. It is what cmd/newsctl
+// is built on, and other Go programs can use it the same way.
+package client
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// CLIENT - Typed access to the news API
+// =============================================================================
+
+// apiPrefix is the version of the API the client speaks; paths below are
+// relative to it.
+const apiPrefix = "/v1"
+
+// Client calls one news API server. It is safe for concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	apiKey     string
+	httpClient *http.Client
+	userAgent  string
+	retry      RetryPolicy
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithAPIKey sends key as a bearer token with every request, for servers
+// behind an authenticating proxy or gateway.
+func WithAPIKey(key string) Option {
+	return func(c *Client) { c.apiKey = key }
+}
+
+// WithHTTPClient makes requests with hc instead of a client with a 30
+// second timeout. Streams use a copy of it without the timeout.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithUserAgent sets the User-Agent header of requests.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) { c.userAgent = ua }
+}
+
+// New creates a client for the server at baseURL, such as
+// "http://localhost:8080".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid server URL %q (use a URL such as http://localhost:8080)", baseURL)
+	}
+	c := &Client{
+		baseURL:    u,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		userAgent:  "go-news-client/1.0",
+		retry:      DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// =============================================================================
+// TYPES - Response bodies
+// =============================================================================
+
+// Article is one stored article.
+type Article struct {
+	ID                 string     `json:"id"` // Derived from the link; stable across restarts
+	Title              string     `json:"title"`
+	Description        string     `json:"description"`
+	Link               string     `json:"link"`
+	Published          *time.Time `json:"published,omitempty"`
+	PublishedEstimated bool       `json:"published_estimated,omitempty"` // Published is when it was first seen
+	FeedTitle          string     `json:"feed_title"`
+	Tags               []string   `json:"tags,omitempty"`
+	Language           string     `json:"language,omitempty"`
+	Starred            bool       `json:"starred"`
+	Read               bool       `json:"read"`
+}
+
+// FeedStatus is a subscribed feed and its fetch health.
+type FeedStatus struct {
+	ID                  string     `json:"id"`
+	URL                 string     `json:"url"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastAttempt         *time.Time `json:"last_attempt,omitempty"`
+	LastSuccess         *time.Time `json:"last_success,omitempty"`
+	NextAttempt         *time.Time `json:"next_attempt,omitempty"`
+	UnparsedDates       int        `json:"unparsed_dates,omitempty"`        // Articles dated when first seen instead
+	UnparsedDateExample string     `json:"unparsed_date_example,omitempty"` // A date the feed sent that couldn't be parsed
+}
+
+// Feed states in FeedStatus.
+const (
+	FeedStatePending = "pending" // Not fetched yet
+	FeedStateOK      = "ok"
+	FeedStateFailing = "failing"     // Last fetch failed; still polled normally
+	FeedStateBackoff = "backing_off" // Fetches paused after repeated failures
+	FeedStateDead    = "dead"        // The feed is gone and no longer polled
+)
+
+// Candidate is a feed found at a web page.
+type Candidate struct {
+	URL    string `json:"url"`
+	Title  string `json:"title,omitempty"`
+	Type   string `json:"type"`   // Media type, e.g. application/atom+xml
+	Source string `json:"source"` // How it was found: direct, link or probe
+}
+
+// Subscription is a feed the server polls.
+type Subscription struct {
+	ID           string    `json:"id"`
+	URL          string    `json:"url"`
+	Title        string    `json:"title,omitempty"`
+	Source       string    `json:"source"`
+	RequestedURL string    `json:"requested_url,omitempty"` // What was asked for, if discovery chose another URL
+	MovedFrom    string    `json:"moved_from,omitempty"`    // The URL subscribed to, if the feed has moved since
+	Dead         bool      `json:"dead,omitempty"`          // The feed is gone and no longer polled
+	CreatedAt    time.Time `json:"created_at"`
+
+	// Candidates are every feed found at the URL, the first of which was
+	// subscribed to. Only Subscribe sets them.
+	Candidates []Candidate `json:"candidates,omitempty"`
+}
+
+// Summary is a news report about recent articles.
+type Summary struct {
+	ArticleCount int      `json:"article_count"`
+	Summary      string   `json:"summary"`
+	Trends       []string `json:"trends,omitempty"`
+}
+
+// =============================================================================
+// ENDPOINTS
+// =============================================================================
+
+// ArticleQuery selects articles. The zero value asks for the server's
+// default number of the most recent ones.
+type ArticleQuery struct {
+	Count    int      // At most this many; 0 for the server default
+	Feeds    []string // Only from feeds with these titles
+	Keyword  string   // Only those mentioning this in title or description
+	Collapse bool     // One article per story
+	Cursor   string   // Start after the page that returned it; see ArticlePage
+}
+
+func (q ArticleQuery) values() url.Values {
+	v := url.Values{}
+	if q.Count > 0 {
+		v.Set("count", strconv.Itoa(q.Count))
+	}
+	for _, f := range q.Feeds {
+		v.Add("feed", f)
+	}
+	if q.Keyword != "" {
+		v.Set("q", q.Keyword)
+	}
+	if q.Collapse {
+		v.Set("collapse", "true")
+	}
+	if q.Cursor != "" {
+		v.Set("cursor", q.Cursor)
+	}
+	return v
+}
+
+// Articles returns recent articles, newest first.
+func (c *Client) Articles(ctx context.Context, q ArticleQuery) ([]Article, error) {
+	page, err := c.ArticlePage(ctx, q)
+	return page.Articles, err
+}
+
+// ArticlePage is one page of articles, newest first.
+type ArticlePage struct {
+	Articles []Article
+	Next     string // Cursor of the following page; empty on the last
+}
+
+// ArticlePage returns the page of articles q selects and the cursor of
+// the next one. Pages hold q.Count articles and run back through the
+// server's search window of recent articles.
+func (c *Client) ArticlePage(ctx context.Context, q ArticleQuery) (ArticlePage, error) {
+	var body struct {
+		Articles   []Article `json:"articles"`
+		NextCursor string    `json:"next_cursor"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/articles", q.values(), nil, &body); err != nil {
+		return ArticlePage{}, err
+	}
+	return ArticlePage{Articles: body.Articles, Next: body.NextCursor}, nil
+}
+
+// Feeds returns the subscribed feeds with their fetch health.
+func (c *Client) Feeds(ctx context.Context) ([]FeedStatus, error) {
+	var feeds []FeedStatus
+	err := c.do(ctx, http.MethodGet, "/feeds", nil, nil, &feeds)
+	return feeds, err
+}
+
+// FeedStatus returns the fetch health of one subscribed feed.
+func (c *Client) FeedStatus(ctx context.Context, id string) (FeedStatus, error) {
+	var status FeedStatus
+	err := c.do(ctx, http.MethodGet, "/feeds/"+url.PathEscape(id)+"/status", nil, nil, &status)
+	return status, err
+}
+
+// Discover returns the feeds found at a web page without subscribing.
+func (c *Client) Discover(ctx context.Context, pageURL string) ([]Candidate, error) {
+	var candidates []Candidate
+	err := c.do(ctx, http.MethodGet, "/discover", url.Values{"url": {pageURL}}, nil, &candidates)
+	return candidates, err
+}
+
+// Subscribe adds a feed, or the feed a web page links to.
+func (c *Client) Subscribe(ctx context.Context, feedURL string) (Subscription, error) {
+	var sub Subscription
+	err := c.do(ctx, http.MethodPost, "/feeds", nil, map[string]string{"url": feedURL}, &sub)
+	return sub, err
+}
+
+// Unsubscribe removes a feed added through the API by its ID.
+func (c *Client) Unsubscribe(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/feeds/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// SummaryQuery chooses what a summary covers.
+type SummaryQuery struct {
+	Count  int  // Articles to cover; 0 for the server default
+	Trends bool // Cover the articles behind trending terms rather than the latest
+}
+
+// Summary asks the server for a news report. It can take as long as the
+// server's summarizer does.
+func (c *Client) Summary(ctx context.Context, q SummaryQuery) (Summary, error) {
+	v := countValues(q.Count)
+	if q.Trends {
+		v.Set("focus", "trends")
+	}
+	var summary Summary
+	err := c.do(ctx, http.MethodGet, "/summary", v, nil, &summary)
+	return summary, err
+}
+
+// =============================================================================
+// TRANSPORT
+// =============================================================================
+
+// newRequest builds a request for path on the server.
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, body io.Reader) (*http.Request, error) {
+	u := *c.baseURL
+	u.Path += apiPrefix + path
+	u.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	return req, nil
+}
+
+// do sends a request with in, if not nil, as its JSON body and decodes
+// the JSON response into out, if not nil, retrying as c's policy allows.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	var data []byte
+	if in != nil {
+		var err error
+		if data, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.send(ctx, method, path, query, data, out)
+		if err == nil {
+			return nil
+		}
+		delay, retry := c.retry.delay(attempt, method, err)
+		if !retry {
+			return err
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// send makes one attempt at a request with data, if not nil, as its JSON
+// body.
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, data []byte, out any) error {
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+	req, err := c.newRequest(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return &decodeError{path: path, err: err}
+		}
+	}
+	return nil
+}
+
+// decodeError is a response body that isn't what the endpoint returns,
+// which a retry won't fix.
+type decodeError struct {
+	path string
+	err  error
+}
+
+func (e *decodeError) Error() string {
+	return fmt.Sprintf("failed to decode %s response: %v", e.path, e.err)
+}
+
+func (e *decodeError) Unwrap() error { return e.err }
+
+// checkResponse turns an error status into an *APIError.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode < 400 {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+	apiErr := &APIError{StatusCode: resp.StatusCode, RequestID: resp.Header.Get("X-Request-ID")}
+	mediaType, _, _ := strings.Cut(resp.Header.Get("Content-Type"), ";")
+	var problem struct {
+		Type      string `json:"type"`
+		Title     string `json:"title"`
+		Detail    string `json:"detail"`
+		RequestID string `json:"request_id"`
+	}
+	if mediaType == "application/problem+json" && json.Unmarshal(body, &problem) == nil {
+		apiErr.Type, apiErr.Message = problem.Type, cmp.Or(problem.Detail, problem.Title)
+		apiErr.RequestID = cmp.Or(problem.RequestID, apiErr.RequestID)
+	} else {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return apiErr
+}
+
+// Why a client package next to the server:
+//
+// - newsctl and other Go tools share one implementation of the wire
+//   format, so a change to an endpoint is fixed in one place.
+// - The package lives in the api module but outside internal/, so other
+//   modules can import it; its types are its own rather than the
+//   server's internal ones, which can then change freely.
+// - Tests run it against the real handlers, so it can't silently drift
+//   from what the server sends.
+// - Errors are *APIError values that match sentinels such as ErrNotFound,
+//   so callers branch on errors.Is rather than on status codes.
@@ -0,0 +1,448 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/client"
+	"github.com/YOUR_USERNAME/go-news/api/internal/events"
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/handlers"
+	"github.com/YOUR_USERNAME/go-news/api/internal/rules"
+	"github.com/YOUR_USERNAME/go-news/api/internal/store"
+	"github.com/YOUR_USERNAME/go-news/api/internal/subscription"
+	"github.com/YOUR_USERNAME/go-news/api/internal/webhook"
+	"github.com/YOUR_USERNAME/go-news/newsroom"
+)
+
+// fakeReader discovers every URL as a feed of its own.
+type fakeReader struct{}
+
+func (fakeReader) Discover(ctx context.Context, url string, settings feed.FetchSettings) ([]feed.Candidate, error) {
+	return []feed.Candidate{{URL: url}}, nil
+}
+func (fakeReader) Configure(url string, settings feed.FetchSettings) error { return nil }
+func (fakeReader) Forget(url string)                                       {}
+
+// fakePoller polls nothing.
+type fakePoller struct{}
+
+func (fakePoller) Sync(urls []string) (added, removed []string) { return nil, nil }
+func (fakePoller) Feeds() []string                              { return nil }
+func (fakePoller) NextPoll(url string) (time.Time, bool)        { return time.Time{}, false }
+
+// fakeStatuses reports no fetches.
+type fakeStatuses struct{}
+
+func (fakeStatuses) FeedStatuses() []feed.FetchStatus { return nil }
+
+// testServer is the API's handlers over an in-memory store, served by
+// httptest, and a client for it.
+type testServer struct {
+	url      string
+	client   *client.Client
+	articles *store.ArticleStore
+	broker   *events.Broker
+	subs     *subscription.Service
+	added    int // Articles added so far; each is published a minute before the last
+}
+
+// newTestServer serves the handlers, wrapped in middleware if given.
+func newTestServer(t *testing.T, middleware ...func(http.Handler) http.Handler) *testServer {
+	t.Helper()
+	articles := store.NewArticleStore()
+	broker := events.NewBroker(100)
+	articles.Subscribe(broker.Publish)
+	subStore, err := subscription.NewStore("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subs := subscription.NewService(subStore, fakeReader{}, fakePoller{})
+	ruleEngine, err := rules.NewEngine("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dispatcher, err := webhook.NewDispatcher(webhook.Options{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	handlers.RegisterV1(mux, slices.Concat(
+		handlers.New(articles).Routes(),
+		handlers.NewSummaryHandlers(articles, newsroom.NewStubSummarizer()).Routes(),
+		handlers.NewStreamHandlers(broker, time.Second).Routes(),
+		handlers.NewFeedHandlers(fakeStatuses{}, fakePoller{}, subs).Routes(),
+		handlers.NewSubscriptionHandlers(subs).Routes(),
+		handlers.NewStoryHandlers(articles).Routes(),
+		handlers.NewTrendHandlers(articles).Routes(),
+		handlers.NewRuleHandlers(ruleEngine, articles).Routes(),
+		handlers.NewWebhookHandlers(dispatcher).Routes(),
+	)...)
+	handler := handlers.RequestID(handlers.WithProblems(mux))
+	for _, m := range middleware {
+		handler = m(handler)
+	}
+	srv := httptest.NewServer(handler)
+	t.Cleanup(func() {
+		broker.Close()
+		srv.Close()
+	})
+
+	c, err := client.New(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testServer{url: srv.URL, client: c, articles: articles, broker: broker, subs: subs}
+}
+
+// add stores articles titled titles, each older than those added before.
+func (s *testServer) add(t *testing.T, feedTitle string, titles ...string) {
+	t.Helper()
+	var batch []*feed.Article
+	for _, title := range titles {
+		s.added++
+		published := time.Now().Add(-time.Duration(s.added) * time.Minute)
+		batch = append(batch, &feed.Article{
+			Title:     title,
+			Link:      "https://example.com/" + strings.ReplaceAll(title, " ", "-"),
+			FeedTitle: feedTitle,
+			Published: &published,
+		})
+	}
+	if err := s.articles.AddArticles(batch); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func titles(articles []client.Article) []string {
+	var result []string
+	for _, a := range articles {
+		result = append(result, a.Title)
+	}
+	return result
+}
+
+func TestNewRejectsInvalidURLs(t *testing.T) {
+	for _, raw := range []string{"", "localhost:8080", "ftp://example.com", "http://"} {
+		if _, err := client.New(raw); err == nil {
+			t.Errorf("New(%q) succeeded", raw)
+		}
+	}
+}
+
+func TestArticles(t *testing.T) {
+	s := newTestServer(t)
+	s.add(t, "Go Blog", "Go 1.23 released", "Range over func")
+	s.add(t, "golang", "Weekly questions thread")
+
+	tests := []struct {
+		name  string
+		query client.ArticleQuery
+		want  []string
+	}{
+		{"all", client.ArticleQuery{}, []string{"Go 1.23 released", "Range over func", "Weekly questions thread"}},
+		{"count", client.ArticleQuery{Count: 1}, []string{"Go 1.23 released"}},
+		{"feed", client.ArticleQuery{Feeds: []string{"golang"}}, []string{"Weekly questions thread"}},
+		{"keyword", client.ArticleQuery{Keyword: "func"}, []string{"Range over func"}},
+		{"no match", client.ArticleQuery{Keyword: "rust"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			articles, err := s.client.Articles(context.Background(), tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := titles(articles); !slices.Equal(got, tt.want) {
+				t.Errorf("titles = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArticlePages(t *testing.T) {
+	s := newTestServer(t)
+	s.add(t, "Go Blog", "Go 1.23 released", "Range over func", "Toolchains", "Telemetry", "Swiss tables")
+	ctx := context.Background()
+
+	page, err := s.client.ArticlePage(ctx, client.ArticleQuery{Count: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := titles(page.Articles); !slices.Equal(got, []string{"Go 1.23 released", "Range over func"}) || page.Next == "" {
+		t.Fatalf("first page = %q, next %q", got, page.Next)
+	}
+
+	// Articles arriving meanwhile don't shift the pages that follow
+	time.Sleep(time.Millisecond)
+	s.articles.AddArticles([]*feed.Article{{Title: "Breaking", Link: "https://example.com/breaking", FeedTitle: "Go Blog"}})
+	page, err = s.client.ArticlePage(ctx, client.ArticleQuery{Count: 2, Cursor: page.Next})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := titles(page.Articles); !slices.Equal(got, []string{"Toolchains", "Telemetry"}) {
+		t.Errorf("second page = %q", got)
+	}
+
+	if _, err := s.client.ArticlePage(ctx, client.ArticleQuery{Cursor: "bogus"}); !errors.Is(err, client.ErrInvalid) {
+		t.Errorf("bogus cursor = %v, want ErrInvalid", err)
+	}
+}
+
+func TestIterateArticles(t *testing.T) {
+	s := newTestServer(t)
+	s.add(t, "Go Blog", "Go 1.23 released", "Range over func", "Toolchains")
+	s.add(t, "golang", "Weekly questions thread")
+	s.add(t, "Go Blog", "Telemetry", "Swiss tables")
+
+	tests := []struct {
+		name  string
+		query client.ArticleQuery
+		want  []string
+	}{
+		{"pages of two", client.ArticleQuery{Count: 2}, []string{"Go 1.23 released", "Range over func", "Toolchains", "Weekly questions thread", "Telemetry", "Swiss tables"}},
+		{"one page", client.ArticleQuery{}, []string{"Go 1.23 released", "Range over func", "Toolchains", "Weekly questions thread", "Telemetry", "Swiss tables"}},
+		{"filtered", client.ArticleQuery{Count: 1, Feeds: []string{"Go Blog"}, Keyword: "t"}, []string{"Toolchains", "Telemetry", "Swiss tables"}},
+		{"empty", client.ArticleQuery{Keyword: "rust"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := s.client.IterateArticles(tt.query)
+			var got []client.Article
+			for it.Next(context.Background()) {
+				got = append(got, it.Article())
+			}
+			if err := it.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if titles := titles(got); !slices.Equal(titles, tt.want) {
+				t.Errorf("iterated %q, want %q", titles, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptions(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+
+	sub, err := s.client.Subscribe(ctx, "https://example.com/feed.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub.ID != feed.ID("https://example.com/feed.xml") || sub.Source != subscription.SourceAPI {
+		t.Errorf("subscription = %+v", sub)
+	}
+
+	if len(sub.Candidates) != 1 || sub.Candidates[0].URL != sub.URL {
+		t.Errorf("candidates = %+v, want the subscribed feed", sub.Candidates)
+	}
+
+	_, err = s.client.Subscribe(ctx, "https://example.com/feed.xml")
+	var apiErr *client.APIError
+	if !errors.Is(err, client.ErrConflict) || !errors.As(err, &apiErr) || !strings.Contains(apiErr.Message, "already subscribed") {
+		t.Errorf("second subscribe = %v, want ErrConflict", err)
+	} else if apiErr.Type != "/problems/conflict" || apiErr.RequestID == "" {
+		t.Errorf("APIError = %+v, want the conflict problem and its request ID", apiErr)
+	}
+
+	if err := s.client.Unsubscribe(ctx, sub.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.client.Unsubscribe(ctx, sub.ID); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("second unsubscribe = %v, want ErrNotFound", err)
+	}
+
+	candidates, err := s.client.Discover(ctx, "https://example.com/blog")
+	if err != nil || len(candidates) != 1 || candidates[0].URL != "https://example.com/blog" {
+		t.Errorf("Discover = %+v, %v", candidates, err)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+
+	if _, err := s.client.Summary(ctx, client.SummaryQuery{}); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("summary of nothing = %v, want ErrNotFound", err)
+	}
+
+	s.add(t, "Go Blog", "Go 1.23 released", "Range over func")
+	summary, err := s.client.Summary(ctx, client.SummaryQuery{Count: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.ArticleCount != 1 || !strings.Contains(summary.Summary, "Go 1.23 released") {
+		t.Errorf("summary = %+v", summary)
+	}
+}
+
+func TestStoriesAndTrends(t *testing.T) {
+	s := newTestServer(t)
+	s.add(t, "HN", "Go 1.23 is released")
+	s.add(t, "Reddit", "Go 1.23 is released!")
+	s.add(t, "Lobsters", "Kubernetes 1.31 is out")
+	ctx := context.Background()
+
+	stories, err := s.client.Stories(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stories) != 2 || len(stories[0].Siblings) != 1 || !slices.Equal(stories[0].Feeds, []string{"HN", "Reddit"}) {
+		t.Errorf("stories = %+v", stories)
+	}
+
+	report, err := s.client.Trends(ctx, client.TrendQuery{Window: time.Hour, Count: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Window != "1h0m0s" || report.Articles != 3 || len(report.Trends) != 1 || len(report.Trends[0].Supporting) != 2 {
+		t.Errorf("report = %+v", report)
+	}
+
+	if _, err := s.client.Trends(ctx, client.TrendQuery{Window: 365 * 24 * time.Hour}); !errors.Is(err, client.ErrInvalid) {
+		t.Errorf("year-long window = %v, want ErrInvalid", err)
+	}
+}
+
+func TestRules(t *testing.T) {
+	s := newTestServer(t)
+	s.add(t, "Go Blog", "Go 1.23 released", "Range over func")
+	ctx := context.Background()
+
+	rule := client.Rule{
+		Name:    "releases",
+		Match:   client.RuleMatch{Title: "released"},
+		Actions: client.Actions{Tags: []string{"release"}, Star: true},
+	}
+	result, err := s.client.DryRunRule(ctx, rule, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Scanned != 2 || result.Matched != 1 || result.Articles[0].Article.Title != "Go 1.23 released" ||
+		!slices.Equal(result.Articles[0].AddTags, []string{"release"}) {
+		t.Errorf("dry run = %+v", result)
+	}
+
+	created, err := s.client.CreateRule(ctx, rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.ID == "" || created.CreatedAt.IsZero() {
+		t.Errorf("created = %+v", created)
+	}
+
+	created.Disabled = true
+	if _, err := s.client.UpdateRule(ctx, created); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.client.Rule(ctx, created.ID)
+	if err != nil || !got.Disabled {
+		t.Errorf("Rule = %+v, %v; want it disabled", got, err)
+	}
+	if list, err := s.client.Rules(ctx); err != nil || len(list) != 1 {
+		t.Errorf("Rules = %+v, %v", list, err)
+	}
+
+	if _, err := s.client.CreateRule(ctx, client.Rule{Name: "empty"}); !errors.Is(err, client.ErrInvalid) {
+		t.Errorf("rule without criteria = %v, want ErrInvalid", err)
+	}
+	if err := s.client.DeleteRule(ctx, created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.client.Rule(ctx, created.ID); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("deleted rule = %v, want ErrNotFound", err)
+	}
+}
+
+func TestWebhooks(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+
+	hook, err := s.client.CreateWebhook(ctx, client.Webhook{
+		URL:    "https://hooks.example/news",
+		Filter: client.WebhookFilter{Feeds: []string{"Go Blog"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hook.ID == "" || hook.Secret == "" || hook.Secret == "REDACTED" {
+		t.Errorf("created = %+v, want an ID and the generated secret", hook)
+	}
+
+	hooks, err := s.client.Webhooks(ctx)
+	if err != nil || len(hooks) != 1 || hooks[0].Secret != "REDACTED" || !slices.Equal(hooks[0].Filter.Feeds, []string{"Go Blog"}) {
+		t.Errorf("Webhooks = %+v, %v", hooks, err)
+	}
+	if attempts, err := s.client.WebhookDeliveries(ctx, hook.ID); err != nil || len(attempts) != 0 {
+		t.Errorf("deliveries = %+v, %v", attempts, err)
+	}
+
+	if err := s.client.DeleteWebhook(ctx, hook.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.client.WebhookDeliveries(ctx, hook.ID); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("deliveries of a deleted webhook = %v, want ErrNotFound", err)
+	}
+}
+
+func TestTail(t *testing.T) {
+	s := newTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Publish once the stream is connected; the filter drops the golang article
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		s.add(t, "golang", "Weekly questions thread")
+		s.add(t, "Go Blog", "Go 1.23 released", "Range over func")
+	}()
+
+	var got []string
+	stop := errors.New("enough")
+	err := s.client.Tail(ctx, client.TailQuery{Feeds: []string{"Go Blog"}}, func(e client.Event) error {
+		if e.ID == 0 {
+			t.Errorf("event without an ID: %+v", e)
+		}
+		got = append(got, e.Article.Title)
+		if len(got) == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("Tail = %v, want the callback's error", err)
+	}
+	if want := []string{"Go 1.23 released", "Range over func"}; !slices.Equal(got, want) {
+		t.Errorf("tailed %q, want %q", got, want)
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	var auth, path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, path = r.Header.Get("Authorization"), r.URL.Path
+		w.Write([]byte(`{"articles":[]}`))
+	}))
+	defer srv.Close()
+
+	c, err := client.New(srv.URL, client.WithAPIKey("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Articles(context.Background(), client.ArticleQuery{}); err != nil {
+		t.Fatal(err)
+	}
+	if auth != "Bearer secret" {
+		t.Errorf("Authorization = %q", auth)
+	}
+	if path != "/v1/articles" {
+		t.Errorf("path = %q, want the versioned route", path)
+	}
+}
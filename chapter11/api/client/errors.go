@@ -0,0 +1,58 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// =============================================================================
+// ERRORS - Typed failures for callers to branch on
+// =============================================================================
+
+// Errors matched by errors.Is against an *APIError, by status class.
+var (
+	ErrInvalid      = errors.New("invalid request")    // 400 or 422: the server rejected the input
+	ErrUnauthorized = errors.New("unauthorized")       // 401 or 403, from an authenticating proxy
+	ErrNotFound     = errors.New("not found")          // 404
+	ErrConflict     = errors.New("conflict")           // 409, such as subscribing twice
+	ErrRateLimited  = errors.New("rate limited")       // 429; RetryAfter says when to try again
+	ErrUnavailable  = errors.New("server unavailable") // 5xx, including a failing summarizer upstream
+)
+
+// APIError is a response with an error status. The API answers with an
+// application/problem+json body, whose type, detail and request ID it
+// carries; any other body, say from a proxy, becomes Message as it is.
+type APIError struct {
+	StatusCode int
+	Type       string        // The problem type, such as "/problems/invalid-request"; "" for a body that wasn't a problem
+	Message    string        // The problem's detail, or its title when it has none
+	RequestID  string        // From the body or the X-Request-ID header; quote it when reporting a failure
+	RetryAfter time.Duration // From the Retry-After header; 0 if there was none
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("server answered %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("server answered %d: %s", e.StatusCode, e.Message)
+}
+
+// Is matches the sentinel error for e's status code, so callers can write
+// errors.Is(err, client.ErrNotFound).
+func (e *APIError) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return target == ErrInvalid
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return target == ErrUnauthorized
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusConflict:
+		return target == ErrConflict
+	case http.StatusTooManyRequests:
+		return target == ErrRateLimited
+	}
+	return e.StatusCode >= 500 && target == ErrUnavailable
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// =============================================================================
+// INSIGHTS - Stories and trending terms across feeds
+// =============================================================================
+
+// Story is the articles several feeds published about the same news.
+type Story struct {
+	ID       string     `json:"id"`
+	Article  Article    `json:"article"`           // The representative: the first to be published
+	Siblings []Article  `json:"siblings"`          // The other articles, newest first
+	Feeds    []string   `json:"feeds"`             // Titles of the feeds covering the story, sorted
+	Updated  *time.Time `json:"updated,omitempty"` // When the newest article was published
+}
+
+// Stories returns up to count of the most recent stories; 0 for the
+// server default.
+func (c *Client) Stories(ctx context.Context, count int) ([]Story, error) {
+	var stories []Story
+	err := c.do(ctx, http.MethodGet, "/stories", countValues(count), nil, &stories)
+	return stories, err
+}
+
+// TrendReport is the terms trending in one window.
+type TrendReport struct {
+	Window           string    `json:"window"`
+	From             time.Time `json:"from"`
+	To               time.Time `json:"to"`
+	Articles         int       `json:"articles"`          // Articles published in the window
+	BaselineArticles int       `json:"baseline_articles"` // Articles published in the baseline before it
+	Trends           []Trend   `json:"trends"`
+}
+
+// Trend is a term mentioned markedly more in the window than before.
+type Trend struct {
+	Term             string    `json:"term"`
+	Articles         int       `json:"articles"`          // Articles in the window mentioning it
+	BaselineArticles int       `json:"baseline_articles"` // Articles in the baseline mentioning it
+	Burst            float64   `json:"burst"`             // How many times its baseline share of articles it has now
+	Score            float64   `json:"score"`             // Trends are ordered by it
+	Supporting       []Article `json:"supporting"`        // Newest first
+}
+
+// TrendQuery chooses the window trends are computed over.
+type TrendQuery struct {
+	Window time.Duration // Up to 30 days; 0 for the server default of 24 hours
+	Count  int           // At most this many terms; 0 for the server default
+}
+
+// Trends returns the terms rising in the window ending now.
+func (c *Client) Trends(ctx context.Context, q TrendQuery) (TrendReport, error) {
+	v := countValues(q.Count)
+	if q.Window > 0 {
+		v.Set("window", q.Window.String())
+	}
+	var report TrendReport
+	err := c.do(ctx, http.MethodGet, "/trends", v, nil, &report)
+	return report, err
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// =============================================================================
+// OPML - Reading subscription lists exported by other feed readers
+// =============================================================================
+
+// opml is the part of an OPML document that lists feeds.
+type opml struct {
+	Outlines []outline `xml:"body>outline"`
+}
+
+// outline is a feed, or a folder of outlines.
+type outline struct {
+	Text     string    `xml:"text,attr"`
+	Title    string    `xml:"title,attr"`
+	XMLURL   string    `xml:"xmlUrl,attr"`
+	Outlines []outline `xml:"outline"`
+}
+
+// OPMLFeed is one feed listed in an OPML file.
+type OPMLFeed struct {
+	Title string
+	URL   string
+}
+
+// ParseOPML returns the feeds an OPML subscription list contains, in
+// document order, including those inside folders. Outlines without a
+// feed URL are skipped.
+func ParseOPML(r io.Reader) ([]OPMLFeed, error) {
+	var doc opml
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid OPML: %w", err)
+	}
+
+	var feeds []OPMLFeed
+	var walk func([]outline)
+	walk = func(outlines []outline) {
+		for _, o := range outlines {
+			if o.XMLURL != "" {
+				title := o.Title
+				if title == "" {
+					title = o.Text
+				}
+				feeds = append(feeds, OPMLFeed{Title: title, URL: o.XMLURL})
+			}
+			walk(o.Outlines)
+		}
+	}
+	walk(doc.Outlines)
+	return feeds, nil
+}
@@ -0,0 +1,39 @@
+package client_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/YOUR_USERNAME/go-news/api/client"
+)
+
+func TestParseOPML(t *testing.T) {
+	const doc = `<?xml version="1.0"?>
+<opml version="2.0">
+  <head><title>Subscriptions</title></head>
+  <body>
+    <outline text="The Go Blog" type="rss" xmlUrl="https://go.dev/blog/feed.atom"/>
+    <outline text="Reddit">
+      <outline text="r/golang" title="golang" type="rss" xmlUrl="https://www.reddit.com/r/golang/.rss"/>
+      <outline text="No feed here"/>
+    </outline>
+  </body>
+</opml>`
+
+	feeds, err := client.ParseOPML(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []client.OPMLFeed{
+		{Title: "The Go Blog", URL: "https://go.dev/blog/feed.atom"},
+		{Title: "golang", URL: "https://www.reddit.com/r/golang/.rss"},
+	}
+	if !slices.Equal(feeds, want) {
+		t.Errorf("feeds = %+v, want %+v", feeds, want)
+	}
+
+	if _, err := client.ParseOPML(strings.NewReader("<opml><body>")); err == nil {
+		t.Error("ParseOPML accepted truncated XML")
+	}
+}
@@ -0,0 +1,88 @@
+package client
+
+import "context"
+
+// =============================================================================
+// PAGES - Iterating over articles one page at a time
+// =============================================================================
+
+// defaultPageSize is how many articles an ArticleIterator fetches per
+// request unless its query's Count says otherwise.
+const defaultPageSize = 100
+
+// ArticleIterator walks articles newest first, fetching a page at a time
+// as it goes. The server only pages through its newest 5000 articles, or
+// 500 with Collapse, so an iterator stops there even if older ones are
+// stored:
+//
+//	it := c.IterateArticles(client.ArticleQuery{Feeds: []string{"Go Blog"}})
+//	for it.Next(ctx) {
+//		fmt.Println(it.Article().Title)
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type ArticleIterator struct {
+	client  *Client
+	query   ArticleQuery
+	page    []Article
+	article Article
+	done    bool // The last page has been fetched
+	err     error
+}
+
+// IterateArticles returns an iterator over every article q selects. q's
+// Count is the page size, and its Cursor where to start.
+func (c *Client) IterateArticles(q ArticleQuery) *ArticleIterator {
+	if q.Count <= 0 {
+		q.Count = defaultPageSize
+	}
+	return &ArticleIterator{client: c, query: q}
+}
+
+// Next advances to the next article, fetching the next page if needed. It
+// returns false when there are no more articles or a request failed,
+// which Err then reports.
+func (it *ArticleIterator) Next(ctx context.Context) bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		page, err := it.client.ArticlePage(ctx, it.query)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page = page.Articles
+		it.query.Cursor = page.Next
+		it.done = page.Next == ""
+	}
+	it.article, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Article returns the article Next advanced to.
+func (it *ArticleIterator) Article() Article {
+	return it.article
+}
+
+// Cursor returns where the page after the current one starts, so a later
+// iterator can resume there; it is empty once the last page is fetched.
+func (it *ArticleIterator) Cursor() string {
+	return it.query.Cursor
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *ArticleIterator) Err() error {
+	return it.err
+}
+
+// Why an iterator with Next and Err:
+//
+// - It reads like bufio.Scanner and sql.Rows, which Go programmers know,
+//   and works on the Go version this module targets, which predates
+//   range-over-func iterators.
+// - Pages are only fetched as the caller gets to them, so stopping early
+//   costs no extra requests.
+// - A failed page stops the iteration without losing what came before;
+//   Cursor lets the caller resume from it.
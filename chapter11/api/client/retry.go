@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// =============================================================================
+// RETRIES - Backing off from transient failures
+// =============================================================================
+
+// RetryPolicy says how often and how patiently failed requests are
+// retried. GET, PUT and DELETE requests are retried after network errors,
+// 429 and 502 to 504; POST requests, which may have taken effect, only
+// after 429, which the server answers before doing anything.
+type RetryPolicy struct {
+	MaxAttempts int           // Including the first; 1 disables retries
+	BaseDelay   time.Duration // Before the first retry, doubling for each after it
+	MaxDelay    time.Duration // Longest wait; a longer Retry-After fails instead
+}
+
+// DefaultRetryPolicy is used unless WithRetryPolicy says otherwise.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   250 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// WithRetryPolicy retries failed requests according to p.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) { c.retry = p }
+}
+
+// delay returns how long to wait before attempt number attempt+1 after
+// err, or false if the request shouldn't be retried.
+func (p RetryPolicy) delay(attempt int, method string, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return 0, false
+	}
+
+	var apiErr *APIError
+	switch {
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests:
+		if apiErr.RetryAfter > 0 {
+			return apiErr.RetryAfter, apiErr.RetryAfter <= p.MaxDelay
+		}
+	case method == http.MethodPost:
+		return 0, false
+	case errors.As(err, &apiErr):
+		switch apiErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		default:
+			return 0, false
+		}
+	case errors.As(err, new(*decodeError)):
+		return 0, false
+	}
+
+	// Exponential backoff with jitter, so clients failing together don't
+	// retry together
+	backoff := min(p.BaseDelay<<(attempt-1), p.MaxDelay)
+	return backoff/2 + rand.N(backoff/2+1), true
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Why retries live in the client:
+//
+// - The server sheds load with 429 and Retry-After, and readiness
+//   failures surface as 503 behind a proxy; every caller would otherwise
+//   write the same loop around every call.
+// - Only requests that can't have taken effect are repeated, so a retry
+//   never subscribes to a feed or creates a webhook twice.
+// - A Retry-After longer than MaxDelay ends the call with ErrRateLimited
+//   rather than blocking it, leaving the caller to decide.
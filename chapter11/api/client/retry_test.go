@@ -0,0 +1,145 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/client"
+)
+
+// flaky answers the first failures requests with status and header
+// instead of passing them on, counting every request it sees.
+type flaky struct {
+	failures   int32
+	status     int
+	retryAfter string
+	requests   atomic.Int32
+}
+
+func (f *flaky) wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if f.requests.Add(1) <= f.failures {
+			if f.retryAfter != "" {
+				w.Header().Set("Retry-After", f.retryAfter)
+			}
+			http.Error(w, http.StatusText(f.status), f.status)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// fastRetries keeps tests quick while still backing off.
+var fastRetries = client.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		flaky        *flaky
+		call         func(context.Context, *client.Client) error
+		wantErr      error
+		wantRequests int32
+	}{
+		{
+			name:         "get recovers",
+			flaky:        &flaky{failures: 2, status: http.StatusServiceUnavailable},
+			call:         getArticles,
+			wantRequests: 3,
+		},
+		{
+			name:         "get gives up",
+			flaky:        &flaky{failures: 5, status: http.StatusBadGateway},
+			call:         getArticles,
+			wantErr:      client.ErrUnavailable,
+			wantRequests: 3,
+		},
+		{
+			name:         "internal errors aren't retried",
+			flaky:        &flaky{failures: 1, status: http.StatusInternalServerError},
+			call:         getArticles,
+			wantErr:      client.ErrUnavailable,
+			wantRequests: 1,
+		},
+		{
+			name:         "post isn't retried after a gateway error",
+			flaky:        &flaky{failures: 1, status: http.StatusBadGateway},
+			call:         subscribe,
+			wantErr:      client.ErrUnavailable,
+			wantRequests: 1,
+		},
+		{
+			name:         "post is retried when rate limited",
+			flaky:        &flaky{failures: 1, status: http.StatusTooManyRequests},
+			call:         subscribe,
+			wantRequests: 2,
+		},
+		{
+			name:         "long Retry-After fails at once",
+			flaky:        &flaky{failures: 1, status: http.StatusTooManyRequests, retryAfter: "60"},
+			call:         getArticles,
+			wantErr:      client.ErrRateLimited,
+			wantRequests: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.flaky
+			s := newTestServer(t, f.wrap)
+			c, err := client.New(s.url, client.WithRetryPolicy(fastRetries))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = tt.call(context.Background(), c)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got := f.requests.Load(); got != tt.wantRequests {
+				t.Errorf("%d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestRetryAfterIsReported(t *testing.T) {
+	f := &flaky{failures: 1, status: http.StatusTooManyRequests, retryAfter: "60"}
+	s := newTestServer(t, f.wrap)
+
+	_, err := s.client.Articles(context.Background(), client.ArticleQuery{})
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != time.Minute {
+		t.Errorf("error = %#v, want an APIError with RetryAfter 1m", err)
+	}
+}
+
+func TestRetryStopsWithContext(t *testing.T) {
+	f := &flaky{failures: 5, status: http.StatusServiceUnavailable}
+	s := newTestServer(t, f.wrap)
+	c, err := client.New(s.url, client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := getArticles(ctx, c); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want the context's", err)
+	}
+	if got := f.requests.Load(); got != 1 {
+		t.Errorf("%d requests, want 1", got)
+	}
+}
+
+func getArticles(ctx context.Context, c *client.Client) error {
+	_, err := c.Articles(ctx, client.ArticleQuery{})
+	return err
+}
+
+func subscribe(ctx context.Context, c *client.Client) error {
+	_, err := c.Subscribe(ctx, "https://example.com/feed.xml")
+	return err
+}
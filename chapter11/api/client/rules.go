@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// =============================================================================
+// RULES - Tagging, starring and dropping articles as they arrive
+// =============================================================================
+
+// Rule applies its actions to every incoming article it matches.
+type Rule struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Match     RuleMatch `json:"match"`
+	Actions   Actions   `json:"actions"`
+	Disabled  bool      `json:"disabled,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RuleMatch selects articles. Each non-empty criterion must match; text
+// comparisons ignore case. At least one criterion is required.
+type RuleMatch struct {
+	Title       string `json:"title,omitempty"`       // Substring of the title
+	Description string `json:"description,omitempty"` // Substring of the description
+	Feed        string `json:"feed,omitempty"`        // Feed title, or the URL the feed is fetched from
+	Host        string `json:"host,omitempty"`        // Host of the article link, or a parent domain of it
+	Regex       string `json:"regex,omitempty"`       // Go regular expression over title and description
+}
+
+// Actions are applied to matching articles. Drop discards the article
+// before it is stored, so the other actions only matter without it.
+type Actions struct {
+	Drop     bool     `json:"drop,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Star     bool     `json:"star,omitempty"`
+	MarkRead bool     `json:"mark_read,omitempty"`
+}
+
+// DryRunResult lists the stored articles a rule would have affected.
+type DryRunResult struct {
+	Scanned  int      `json:"scanned"`
+	Matched  int      `json:"matched"`
+	Articles []Effect `json:"articles"`
+}
+
+// Effect is what a rule would have done to one stored article.
+type Effect struct {
+	Article  Article  `json:"article"`
+	Drop     bool     `json:"drop,omitempty"`
+	AddTags  []string `json:"add_tags,omitempty"` // Tags the article doesn't already have
+	Star     bool     `json:"star,omitempty"`
+	MarkRead bool     `json:"mark_read,omitempty"`
+}
+
+// ruleBody is the part of a rule clients set; the server assigns the rest.
+type ruleBody struct {
+	Name     string    `json:"name"`
+	Match    RuleMatch `json:"match"`
+	Actions  Actions   `json:"actions"`
+	Disabled bool      `json:"disabled"`
+}
+
+func bodyOf(r Rule) ruleBody {
+	return ruleBody{Name: r.Name, Match: r.Match, Actions: r.Actions, Disabled: r.Disabled}
+}
+
+// Rules returns every rule in the order they are applied.
+func (c *Client) Rules(ctx context.Context) ([]Rule, error) {
+	var rules []Rule
+	err := c.do(ctx, http.MethodGet, "/rules", nil, nil, &rules)
+	return rules, err
+}
+
+// Rule returns one rule.
+func (c *Client) Rule(ctx context.Context, id string) (Rule, error) {
+	var rule Rule
+	err := c.do(ctx, http.MethodGet, "/rules/"+url.PathEscape(id), nil, nil, &rule)
+	return rule, err
+}
+
+// CreateRule adds a rule, which applies to articles ingested from then
+// on. Its ID and CreatedAt are ignored and set by the server.
+func (c *Client) CreateRule(ctx context.Context, rule Rule) (Rule, error) {
+	var created Rule
+	err := c.do(ctx, http.MethodPost, "/rules", nil, bodyOf(rule), &created)
+	return created, err
+}
+
+// UpdateRule replaces the rule with rule.ID, keeping its place in the
+// order.
+func (c *Client) UpdateRule(ctx context.Context, rule Rule) (Rule, error) {
+	var updated Rule
+	err := c.do(ctx, http.MethodPut, "/rules/"+url.PathEscape(rule.ID), nil, bodyOf(rule), &updated)
+	return updated, err
+}
+
+// DeleteRule removes a rule.
+func (c *Client) DeleteRule(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/rules/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// DryRunRule reports which of the last scan stored articles rule would
+// have affected, without saving it. scan 0 uses the server default.
+func (c *Client) DryRunRule(ctx context.Context, rule Rule, scan int) (DryRunResult, error) {
+	var result DryRunResult
+	err := c.do(ctx, http.MethodPost, "/rules/dry-run", countValues(scan), bodyOf(rule), &result)
+	return result, err
+}
+
+// countValues returns ?count=n, or no parameters for n 0.
+func countValues(n int) url.Values {
+	v := url.Values{}
+	if n > 0 {
+		v.Set("count", strconv.Itoa(n))
+	}
+	return v
+}
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// STREAM - Following new articles as they arrive
+// =============================================================================
+
+// defaultRetry is how long Tail waits before reconnecting when the server
+// hasn't said.
+const defaultRetry = 3 * time.Second
+
+// Event is one article from the stream.
+type Event struct {
+	ID      uint64
+	Article Article
+}
+
+// TailQuery filters the stream. The zero value follows every article.
+type TailQuery struct {
+	Feeds   []string // Only from feeds with these titles
+	Keyword string   // Only those mentioning this in title or description
+	After   uint64   // Resume after this event ID; 0 for only new articles
+}
+
+// Tail calls fn with each new article the server ingests until ctx is
+// done or fn returns an error, which Tail then returns. Dropped
+// connections are resumed after the last event seen, so none are missed
+// unless the server's replay buffer has moved on.
+func (c *Client) Tail(ctx context.Context, q TailQuery, fn func(Event) error) error {
+	hc := *c.httpClient
+	hc.Timeout = 0
+
+	lastID, retry := q.After, defaultRetry
+	for {
+		err := c.tailOnce(ctx, &hc, q, &lastID, &retry, fn)
+		var stop callbackError
+		var apiErr *APIError
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case errors.As(err, &stop):
+			return stop.err
+		case errors.As(err, &apiErr) && apiErr.StatusCode < 500 && apiErr.StatusCode != http.StatusTooManyRequests:
+			return err
+		}
+
+		// The connection ended or failed; resume after the last event,
+		// waiting longer if the server asked
+		wait := retry
+		if apiErr != nil {
+			wait = max(wait, apiErr.RetryAfter)
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// callbackError carries an error from the caller's fn, which ends Tail.
+type callbackError struct{ err error }
+
+func (e callbackError) Error() string { return e.err.Error() }
+
+// tailOnce reads one connection's events, updating lastID and retry as
+// they arrive.
+func (c *Client) tailOnce(ctx context.Context, hc *http.Client, q TailQuery, lastID *uint64, retry *time.Duration, fn func(Event) error) error {
+	v := url.Values{}
+	for _, f := range q.Feeds {
+		v.Add("feed", f)
+	}
+	if q.Keyword != "" {
+		v.Set("q", q.Keyword)
+	}
+	req, err := c.newRequest(ctx, http.MethodGet, "/articles/stream", v, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if *lastID > 0 {
+		req.Header.Set("Last-Event-ID", strconv.FormatUint(*lastID, 10))
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+
+	// Server-Sent Events: fields until a blank line ends the event
+	var id, event, data string
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if event == "article" && data != "" {
+				var e Event
+				e.ID, _ = strconv.ParseUint(id, 10, 64)
+				if err := json.Unmarshal([]byte(data), &e.Article); err != nil {
+					return fmt.Errorf("failed to decode stream event %s: %w", id, err)
+				}
+				*lastID = e.ID
+				if err := fn(e); err != nil {
+					return callbackError{err}
+				}
+			}
+			id, event, data = "", "", ""
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			id = value
+		case "event":
+			event = value
+		case "data":
+			data += value
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms > 0 {
+				*retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// =============================================================================
+// WEBHOOKS - Receivers notified of new articles
+// =============================================================================
+
+// Webhook is a receiver the server posts signed batches of new articles
+// to.
+type Webhook struct {
+	ID        string        `json:"id"`
+	URL       string        `json:"url"`
+	Secret    string        `json:"secret"` // Only returned by CreateWebhook; "REDACTED" otherwise
+	Filter    WebhookFilter `json:"filter"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// WebhookFilter limits the articles sent to a webhook. Each non-empty
+// list must have a match; the zero value sends every article.
+type WebhookFilter struct {
+	Feeds    []string `json:"feeds,omitempty"`    // Feed titles, case-insensitive
+	Keywords []string `json:"keywords,omitempty"` // Substrings of title or description
+	Tags     []string `json:"tags,omitempty"`     // Article tags
+}
+
+// DeliveryAttempt is one attempt to deliver a batch to a webhook.
+type DeliveryAttempt struct {
+	DeliveryID  string        `json:"delivery_id"`
+	Attempt     int           `json:"attempt"`
+	Time        time.Time     `json:"time"`
+	StatusCode  int           `json:"status_code,omitempty"`
+	Error       string        `json:"error,omitempty"`
+	Duration    time.Duration `json:"duration_ns"`
+	Outcome     string        `json:"outcome"`
+	NextAttempt *time.Time    `json:"next_attempt,omitempty"`
+}
+
+// webhookBody is the part of a webhook clients set.
+type webhookBody struct {
+	URL    string        `json:"url"`
+	Secret string        `json:"secret,omitempty"`
+	Filter WebhookFilter `json:"filter"`
+}
+
+// Webhooks returns every webhook, oldest first, with secrets redacted.
+func (c *Client) Webhooks(ctx context.Context) ([]Webhook, error) {
+	var hooks []Webhook
+	err := c.do(ctx, http.MethodGet, "/webhooks", nil, nil, &hooks)
+	return hooks, err
+}
+
+// CreateWebhook registers a receiver. hook.Secret may be empty for the
+// server to generate one; the returned webhook is the only place it is
+// shown.
+func (c *Client) CreateWebhook(ctx context.Context, hook Webhook) (Webhook, error) {
+	var created Webhook
+	body := webhookBody{URL: hook.URL, Secret: hook.Secret, Filter: hook.Filter}
+	err := c.do(ctx, http.MethodPost, "/webhooks", nil, body, &created)
+	return created, err
+}
+
+// DeleteWebhook removes a webhook and its pending deliveries.
+func (c *Client) DeleteWebhook(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/webhooks/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// WebhookDeliveries returns a webhook's delivery log, newest first.
+func (c *Client) WebhookDeliveries(ctx context.Context, id string) ([]DeliveryAttempt, error) {
+	var attempts []DeliveryAttempt
+	err := c.do(ctx, http.MethodGet, "/webhooks/"+url.PathEscape(id)+"/deliveries", nil, nil, &attempts)
+	return attempts, err
+}
@@ -2,18 +2,32 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 	"time"
 
-	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/config"
+	"github.com/YOUR_USERNAME/go-news/api/internal/events"
 	"github.com/YOUR_USERNAME/go-news/api/internal/handlers"
+	"github.com/YOUR_USERNAME/go-news/api/internal/ingest"
+	"github.com/YOUR_USERNAME/go-news/api/internal/openapi"
 	"github.com/YOUR_USERNAME/go-news/api/internal/reader"
+	"github.com/YOUR_USERNAME/go-news/api/internal/rules"
+	"github.com/YOUR_USERNAME/go-news/api/internal/scheduler"
 	"github.com/YOUR_USERNAME/go-news/api/internal/store"
+	"github.com/YOUR_USERNAME/go-news/api/internal/story"
+	"github.com/YOUR_USERNAME/go-news/api/internal/subscription"
+	"github.com/YOUR_USERNAME/go-news/api/internal/web"
+	"github.com/YOUR_USERNAME/go-news/api/internal/webhook"
+	"github.com/YOUR_USERNAME/go-news/api/internal/websub"
 	"github.com/YOUR_USERNAME/go-news/newsroom"
 )
 
@@ -22,6 +36,34 @@ import (
 // =============================================================================
 
 func main() {
+	// Load configuration: defaults, then config file, environment and flags
+	loader, err := config.NewLoader(os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if loader.PrintConfig() {
+		if err := config.Print(os.Stdout, cfg); err != nil {
+			log.Fatalf("Failed to print config: %v", err)
+		}
+		return
+	}
+
+	// Structured logging at the configured level
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(cfg.Log.SlogLevel())
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel})))
+
 	fmt.Println("Starting Go News API...")
 
 	// Create core components with dependency injection
@@ -30,104 +72,263 @@ func main() {
 	// 1. Create storage - the single source of truth for articles
 	articleStore := store.NewArticleStore()
 
-	// 2. Create RSS reader with storage dependency
-	rssReader := reader.NewRSSReader(articleStore)
+	// 2. Create RSS reader with storage dependency; failing feeds are
+	// retried and then backed off by a per-feed circuit breaker. Articles
+	// are cleaned, deduplicated and annotated on their way to the store,
+	// then user-defined rules drop, tag, star or mark them read.
+	ruleEngine, err := rules.NewEngine(cfg.Rules.StateFile)
+	if err != nil {
+		log.Fatalf("Failed to load rules: %v", err)
+	}
+	ingestPipeline := ingest.New(
+		ingest.Sanitize(),
+		ingest.Dedupe(10000),
+		ingest.DetectLanguage(),
+		ingest.Tag(ingest.DefaultTags),
+		ruleEngine,
+		story.Fingerprinter(),
+	)
+	readerOptions := append(cfg.Fetch.ReaderOptions(), reader.WithProcessor(ingestPipeline))
+	rssReader := reader.NewRSSReader(articleStore, readerOptions...)
+	switch fixtures := cfg.Fetch.Fixtures; {
+	case fixtures.Offline():
+		fmt.Printf("Offline: replaying feeds from fixtures in %s.\n", fixtures.Dir)
+	case fixtures.Mode != "":
+		fmt.Printf("Recording fetched feeds to %s.\n", fixtures.Dir)
+	}
+
+	// Subscribed feeds: those in the config plus those added through the API
+	secretKey, _ := cfg.Subscriptions.Key() // Checked by Validate
+	subscriptions, err := subscription.NewStore(cfg.Subscriptions.StateFile, secretKey)
+	if err != nil {
+		log.Fatalf("Failed to load subscriptions: %v", err)
+	}
+	subscriptions.SetConfigured(cfg.Feeds)
 
-	// 3. Create article handlers with read-only storage dependency
+	// 3. Create article handlers with read-only storage dependency;
+	// stories group near-duplicate articles from different feeds, and
+	// trends find the terms rising among them
 	articleHandlers := handlers.New(articleStore)
+	storyHandlers := handlers.NewStoryHandlers(articleStore)
+	trendHandlers := handlers.NewTrendHandlers(articleStore)
 
 	// 4. Create AI summarizer with configuration
-	config := newsroom.DefaultConfig()
-	summarizer, err := newsroom.NewArticleSummarizer(config)
-	if err != nil {
-		// If Ollama isn't available, use stub implementation
+	var summarizer handlers.Summarizer = newsroom.NewStubSummarizer()
+	if cfg.Summarizer.Stub {
+		fmt.Println("Using stub summarizer (summarizer.stub is set).")
+	} else if aiSummarizer, err := newsroom.NewArticleSummarizer(cfg.Summarizer.Newsroom()); err != nil {
+		// If Ollama isn't available, keep the stub implementation
 		fmt.Printf("Warning: Failed to create AI summarizer: %v\n", err)
 		fmt.Println("Using stub implementation. Install Ollama for real AI summaries.")
-		summarizer = newsroom.NewStubSummarizer()
+	} else {
+		summarizer = aiSummarizer
 	}
 
 	// 5. Create summary handlers with both dependencies
 	summaryHandlers := handlers.NewSummaryHandlers(articleStore, summarizer)
 
+	// 6. Fan out newly stored articles to live stream subscribers
+	broker := events.NewBroker(256)
+	articleStore.Subscribe(broker.Publish)
+	streamHandlers := handlers.NewStreamHandlers(broker, 15*time.Second)
+
+	// 7. Deliver matching new articles to registered webhooks
+	dispatcher, err := webhook.NewDispatcher(cfg.Webhooks.Options(), nil)
+	if err != nil {
+		log.Fatalf("Failed to start webhook dispatcher: %v", err)
+	}
+	articleStore.Subscribe(dispatcher.Notify)
+	webhookHandlers := handlers.NewWebhookHandlers(dispatcher)
+	ruleHandlers := handlers.NewRuleHandlers(ruleEngine, articleStore)
+
+	// 8. Subscribing by website URL discovers the feed and starts polling
+	// it; subscriptions follow feeds that move permanently or disappear
+	feedScheduler := scheduler.New(rssReader, time.Duration(cfg.Fetch.Interval))
+	subscriptionService := subscription.NewService(subscriptions, rssReader, feedScheduler)
+	rssReader.Watch(subscriptionService)
+	subscriptionService.LoadSettings()
+	subscriptionHandlers := handlers.NewSubscriptionHandlers(subscriptionService)
+
+	// Report per-feed health from the reader and the polling schedule,
+	// under the subscriptions' IDs
+	feedHandlers := handlers.NewFeedHandlers(rssReader, feedScheduler, subscriptionService)
+
+	// A browser UI over the same articles, summaries and subscriptions
+	webUI, err := web.New(articleStore, summarizer, subscriptionService)
+	if err != nil {
+		log.Fatalf("Failed to load web UI: %v", err)
+	}
+
+	// Feeds that advertise a WebSub hub also get pushed updates, which
+	// are stored like fetched ones. Offline, there is no hub to reach.
+	pushSubscriber := websub.NewSubscriber(cfg.WebSub.Options(cfg.Fetch.MaxBodyBytes), rssReader, nil)
+	if cfg.WebSub.Enabled() && !cfg.Fetch.Fixtures.Offline() {
+		rssReader.WatchHubs(pushSubscriber)
+	}
+	webSubHandlers := handlers.NewWebSubHandlers(pushSubscriber)
+
 	// Setup HTTP router
 	mux := http.NewServeMux()
 
-	// Let handlers register their own routes
+	// Let handlers register their own routes: each is served under /v1
+	// and, deprecated, at the unversioned path it had before
 	articleHandlers.RegisterRoutes(mux)
+	storyHandlers.RegisterRoutes(mux)
+	trendHandlers.RegisterRoutes(mux)
 	summaryHandlers.RegisterRoutes(mux)
+	streamHandlers.RegisterRoutes(mux)
+	webhookHandlers.RegisterRoutes(mux)
+	ruleHandlers.RegisterRoutes(mux)
+	feedHandlers.RegisterRoutes(mux)
+	subscriptionHandlers.RegisterRoutes(mux)
+	webSubHandlers.RegisterRoutes(mux)
 
-	// Add a root handler for documentation
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path != "/" {
-			http.NotFound(w, r)
-			return
-		}
+	// The UI gets its own mux so its errors are pages, not problems
+	uiMux := http.NewServeMux()
+	webUI.RegisterRoutes(uiMux)
+
+	routes := slices.Concat(
+		articleHandlers.Routes(),
+		storyHandlers.Routes(),
+		trendHandlers.Routes(),
+		summaryHandlers.Routes(),
+		streamHandlers.Routes(),
+		webhookHandlers.Routes(),
+		ruleHandlers.Routes(),
+		feedHandlers.Routes(),
+		subscriptionHandlers.Routes(),
+		webSubHandlers.Routes(),
+	)
+	handlers.RegisterV1(mux, routes...)
 
-		w.Header().Set("Content-Type", "application/json")
-		fmt.Fprintf(w, `{
-  "service": "Go News API",
-  "version": "1.0.0",
-  "endpoints": {
-    "GET /articles": "Fetch recent articles (supports ?count=N)",
-    "GET /summary": "Generate AI news report (supports ?count=N)",
-    "GET /": "This documentation"
-  }
-}`)
+	// The OpenAPI document is generated from the same routes
+	apiDoc := openapi.Build(openapi.Info{
+		Title:       "Go News API",
+		Version:     "1.0.0",
+		Description: "Aggregated news articles, stories, trends and AI summaries.",
+	}, handlers.V1Endpoints(routes...))
+	mux.HandleFunc("GET /openapi.json", handlers.OpenAPIHandler(apiDoc))
+
+	// 9. Create health handlers that probe storage, feeds and summarizer.
+	// A feed is stale once it has missed a few scheduled polls.
+	healthHandlers := handlers.NewHealthHandlers(handlers.HealthConfig{
+		Storage:    articleStore,
+		Feeds:      rssReader,
+		Summarizer: summarizer,
+		StaleAfter: 3 * time.Duration(cfg.Fetch.Interval),
 	})
 
+	// 10. Rate limit API routes per client; probes stay outside the limiter.
+	// API errors, unmatched routes included, are problem+json bodies; the
+	// UI answers with its error page. Every response carries a request ID.
+	limiter := handlers.NewRateLimiter(cfg.Server.RateLimit.RequestsPerSecond, cfg.Server.RateLimit.Burst)
+	root := http.NewServeMux()
+	healthHandlers.RegisterRoutes(root)
+	webSubHandlers.RegisterCallbacks(root)
+	uiHandler := limiter.Middleware(webUI.WithErrorPages(uiMux))
+	root.Handle(web.Prefix, uiHandler)
+	root.Handle("/ui", uiHandler)
+	root.Handle("/", limiter.Middleware(handlers.WithProblems(mux)))
+
+	// Add a root handler for documentation
+	mux.HandleFunc("GET /{$}", handlers.IndexHandler(apiDoc, map[string]string{
+		"GET /openapi.json":          "OpenAPI 3 description of the /v1 routes",
+		"GET /healthz":               "Liveness probe",
+		"GET /readyz":                "Readiness probe with dependency checks",
+		"GET /ui/":                   "Browse articles, read the summary and manage feeds in a browser",
+		"GET /websub/callback/{id}":  "WebSub hub verification callback",
+		"POST /websub/callback/{id}": "WebSub content distribution callback",
+		"GET /":                      "This documentation",
+	}))
+
+	// 11. Keep polling feeds and delivering webhooks in the background.
+	// Polling starts before the initial fetch so feeds that move or
+	// disappear during it are picked up by the scheduler.
+	ctx, stopPolling := context.WithCancel(context.Background())
+	defer stopPolling()
+	feedScheduler.Start(ctx, subscriptions.URLs())
+	go dispatcher.Run(ctx)
+	if cfg.WebSub.Enabled() {
+		go pushSubscriber.Run(ctx, subscriptions.URLs)
+	}
+
 	// Fetch initial feeds
 	fmt.Println("Fetching initial feeds...")
-	feeds := []string{
-		"https://www.reddit.com/r/golang.rss",
-		"https://go.dev/blog/feed.atom",
+	startupCtx, cancelStartup := context.WithTimeout(ctx, time.Duration(cfg.Fetch.StartupDeadline))
+	report := rssReader.FetchAll(startupCtx, subscriptions.URLs(), cfg.Fetch.Limits())
+	cancelStartup()
+	for _, result := range report.Failed() {
+		fmt.Printf("Warning: Failed to fetch %s: %v\n", result.URL, result.Err)
 	}
+	fmt.Printf("Fetched %d of %d feeds in %s\n", report.Succeeded(), len(report.Results), report.Duration.Round(time.Millisecond))
 
-	ctx := context.Background()
-	for _, feedURL := range feeds {
-		if _, err := rssReader.FetchFeed(ctx, feedURL); err != nil {
-			fmt.Printf("Warning: Failed to fetch %s: %v\n", feedURL, err)
-		}
+	// Logging, feeds and limits can be reloaded from config on SIGHUP
+	configReloader := &reloader{
+		loader:    loader,
+		current:   cfg,
+		feeds:     subscriptionService,
+		scheduler: feedScheduler,
+		limiter:   limiter,
+		logLevel:  logLevel,
 	}
 
 	// Start HTTP server with graceful shutdown
 	srv := &http.Server{
-		Addr:         ":8080",
-		Handler:      mux,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		Addr:         cfg.Server.Addr(),
+		Handler:      handlers.RequestID(root),
+		ReadTimeout:  time.Duration(cfg.Server.ReadTimeout),
+		WriteTimeout: time.Duration(cfg.Server.WriteTimeout),
+		IdleTimeout:  time.Duration(cfg.Server.IdleTimeout),
 	}
 
-	// Channel to listen for interrupt signals
-	done := make(chan os.Signal, 1)
-	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
+	// Close open streams on shutdown so they don't block it
+	srv.RegisterOnShutdown(broker.Close)
+
+	// Channel to listen for interrupt, terminate and reload signals
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
 
 	// Start server in background
 	go func() {
-		fmt.Println("API server listening on http://localhost:8080")
+		base := fmt.Sprintf("http://localhost:%d", cfg.Server.Port)
+		fmt.Printf("API server listening on %s\n", srv.Addr)
 		fmt.Println("\nTry these endpoints:")
-		fmt.Println("  curl http://localhost:8080/")
-		fmt.Println("  curl http://localhost:8080/articles?count=5")
-		fmt.Println("  curl http://localhost:8080/summary?count=3")
-		fmt.Println("\nPress Ctrl+C to stop")
+		fmt.Printf("  curl %s/\n", base)
+		fmt.Printf("  curl %s/articles?count=5\n", base)
+		fmt.Printf("  curl %s/summary?count=3\n", base)
+		fmt.Printf("  curl %s/readyz\n", base)
+		fmt.Printf("  curl -N %s/articles/stream\n", base)
+		fmt.Printf("\nSend SIGHUP (kill -HUP %d) to reload config\n", os.Getpid())
+		fmt.Println("Press Ctrl+C to stop")
 
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Server failed to start: %v", err)
 		}
 	}()
 
-	// Block until signal received
-	<-done
+	// Block until a shutdown signal, reloading config on each SIGHUP
+	for sig := range signals {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		fmt.Println("\nReceived SIGHUP, reloading configuration...")
+		if err := configReloader.reload(); err != nil {
+			fmt.Printf("Reload rejected, keeping current configuration: %v\n", err)
+		}
+	}
 	fmt.Println("\nShutting down gracefully...")
 
 	// Create shutdown context with timeout
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	shutdownTimeout := time.Duration(configReloader.current.Server.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
-	// Gracefully shutdown server
+	// Gracefully shutdown server, then stop background polling
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
+	stopPolling()
+	feedScheduler.Stop()
 
 	fmt.Println("Server stopped")
 }
@@ -159,12 +360,18 @@ func main() {
 //    - Timeout prevents hanging
 //    - Clean resource cleanup
 //
+// 6. Layered Configuration: One typed config, validated before startup
+//    - Defaults < config file < environment < flags
+//    - Invalid settings stop the server with every problem listed
+//    - -print-config shows the effective config with secrets redacted
+//
+// 7. Hot Reload: SIGHUP re-reads the config without dropping connections
+//    - New feeds start polling, removed feeds stop
+//    - Log level, polling interval and rate limits change in place
+//    - An invalid config is rejected and the old one stays in effect
+//
 // In production, you'd also add:
-// - Configuration management (environment variables, config files)
-// - Structured logging (zerolog, zap)
 // - Metrics and observability (Prometheus, OpenTelemetry)
-// - Health check endpoints
-// - Background workers for periodic feed updates
-// - Rate limiting and authentication
+// - Authentication
 // - Database migrations
 // - Feature flags
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/config"
+	"github.com/YOUR_USERNAME/go-news/api/internal/handlers"
+	"github.com/YOUR_USERNAME/go-news/api/internal/scheduler"
+	"github.com/YOUR_USERNAME/go-news/api/internal/subscription"
+)
+
+// =============================================================================
+// RELOAD - Applying a new configuration to the running server on SIGHUP
+// =============================================================================
+
+// reloader re-reads configuration and applies what can change live:
+// subscribed feeds, the polling interval, rate limits and the log level.
+// Other changes are reported as requiring a restart.
+type reloader struct {
+	loader    *config.Loader
+	current   config.Config // What the server is running with
+	feeds     *subscription.Service
+	scheduler *scheduler.Scheduler
+	limiter   *handlers.RateLimiter
+	logLevel  *slog.LevelVar
+}
+
+// reload loads the config again and applies it. An invalid config is
+// rejected and the current one stays in effect.
+func (r *reloader) reload() error {
+	next, err := r.loader.Load()
+	if err != nil {
+		return err
+	}
+
+	changes := config.Diff(r.current, next)
+	if len(changes) == 0 {
+		fmt.Println("Configuration reloaded: no changes")
+		return nil
+	}
+
+	// Feeds added through the API keep polling across reloads
+	r.feeds.SetConfigured(next.Feeds)
+	r.scheduler.SetInterval(time.Duration(next.Fetch.Interval))
+	r.limiter.SetLimit(next.Server.RateLimit.RequestsPerSecond, next.Server.RateLimit.Burst)
+	r.logLevel.Set(next.Log.SlogLevel())
+	r.current = r.current.Reloaded(next) // Restart-only settings stay as started
+
+	fmt.Printf("Configuration reloaded: %d change(s)\n", len(changes))
+	for _, change := range changes {
+		fmt.Printf("  %s\n", change)
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/YOUR_USERNAME/go-news/api/client"
+)
+
+// =============================================================================
+// COMMANDS
+// =============================================================================
+
+// env is what every command works with.
+type env struct {
+	client *client.Client
+	out    *printer
+}
+
+// command runs one newsctl command with the arguments after its name.
+type command func(ctx context.Context, e *env, name string, args []string) error
+
+var commands = map[string]command{
+	"articles": articlesCmd,
+	"search":   searchCmd,
+	"tail":     tailCmd,
+	"open":     openCmd,
+	"feeds":    feedsCmd,
+	"add":      addCmd,
+	"remove":   removeCmd,
+	"import":   importCmd,
+	"summary":  summaryCmd,
+}
+
+// parseFlags parses a command's flags, requiring between min and max
+// positional arguments (max < 0 for no limit).
+func parseFlags(fs *flag.FlagSet, args []string, min, max int) error {
+	fs.SetOutput(io.Discard)
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %s: %v", errUsage, fs.Name(), err)
+	}
+	if n := fs.NArg(); n < min || (max >= 0 && n > max) {
+		return fmt.Errorf("%w: %s: wrong number of arguments (see newsctl -h)", errUsage, fs.Name())
+	}
+	return nil
+}
+
+func articlesCmd(ctx context.Context, e *env, name string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	count := fs.Int("n", 10, "number of articles")
+	var feeds stringList
+	fs.Var(&feeds, "feed", "only this feed (repeatable)")
+	collapse := fs.Bool("collapse", false, "one article per story")
+	if err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	articles, err := e.client.Articles(ctx, client.ArticleQuery{Count: *count, Feeds: feeds, Collapse: *collapse})
+	if err != nil {
+		return err
+	}
+	return e.out.articles(articles)
+}
+
+func searchCmd(ctx context.Context, e *env, name string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	count := fs.Int("n", 20, "number of articles")
+	var feeds stringList
+	fs.Var(&feeds, "feed", "only this feed (repeatable)")
+	if err := parseFlags(fs, args, 1, -1); err != nil {
+		return err
+	}
+
+	query := client.ArticleQuery{Count: *count, Feeds: feeds, Keyword: strings.Join(fs.Args(), " ")}
+	articles, err := e.client.Articles(ctx, query)
+	if err != nil {
+		return err
+	}
+	return e.out.articles(articles)
+}
+
+func tailCmd(ctx context.Context, e *env, name string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	var feeds stringList
+	fs.Var(&feeds, "feed", "only this feed (repeatable)")
+	keyword := fs.String("q", "", "only articles mentioning this")
+	if err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	return e.client.Tail(ctx, client.TailQuery{Feeds: feeds, Keyword: *keyword}, func(event client.Event) error {
+		return e.out.streamed(event.Article)
+	})
+}
+
+// openCmd opens an article in the browser: the Nth of the list the same
+// filters give to articles or search, or a URL.
+func openCmd(ctx context.Context, e *env, name string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	var feeds stringList
+	fs.Var(&feeds, "feed", "count only this feed's articles (repeatable)")
+	keyword := fs.String("q", "", "count only articles mentioning this")
+	if err := parseFlags(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	target := fs.Arg(0)
+	if n, err := strconv.Atoi(target); err == nil {
+		if n < 1 {
+			return fmt.Errorf("%w: open: article numbers start at 1", errUsage)
+		}
+		articles, err := e.client.Articles(ctx, client.ArticleQuery{Count: n, Feeds: feeds, Keyword: *keyword})
+		if err != nil {
+			return err
+		}
+		if len(articles) < n {
+			return fmt.Errorf("there are only %d articles", len(articles))
+		}
+		if target = articles[n-1].Link; target == "" {
+			return fmt.Errorf("article %d has no link", n)
+		}
+	} else if !strings.HasPrefix(target, "http://") && !strings.HasPrefix(target, "https://") {
+		return fmt.Errorf("%w: open: %q is neither an article number nor an http(s) URL", errUsage, target)
+	}
+	return openBrowser(target)
+}
+
+// openBrowser opens url with $BROWSER or the platform's opener.
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch browser := os.Getenv("BROWSER"); {
+	case browser != "":
+		cmd = exec.Command(browser, url)
+	case runtime.GOOS == "darwin":
+		cmd = exec.Command("open", url)
+	case runtime.GOOS == "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open %s: %w", url, err)
+	}
+	return cmd.Process.Release()
+}
+
+func feedsCmd(ctx context.Context, e *env, name string, args []string) error {
+	if err := parseFlags(flag.NewFlagSet(name, flag.ContinueOnError), args, 0, 0); err != nil {
+		return err
+	}
+	feeds, err := e.client.Feeds(ctx)
+	if err != nil {
+		return err
+	}
+	return e.out.feeds(feeds)
+}
+
+func addCmd(ctx context.Context, e *env, name string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	if err := parseFlags(fs, args, 1, -1); err != nil {
+		return err
+	}
+	var subs []client.Subscription
+	for _, url := range fs.Args() {
+		sub, err := e.client.Subscribe(ctx, url)
+		if err != nil {
+			return fmt.Errorf("%s: %w", url, err)
+		}
+		subs = append(subs, sub)
+	}
+	return e.out.subscriptions(subs)
+}
+
+func removeCmd(ctx context.Context, e *env, name string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	if err := parseFlags(fs, args, 1, -1); err != nil {
+		return err
+	}
+	for _, id := range fs.Args() {
+		if err := e.client.Unsubscribe(ctx, id); err != nil {
+			return fmt.Errorf("%s: %w", id, err)
+		}
+		e.out.status("Removed %s", id)
+	}
+	return nil
+}
+
+// importCmd subscribes to every feed in an OPML file, carrying on past
+// feeds that fail. Feeds already subscribed to aren't failures.
+func importCmd(ctx context.Context, e *env, name string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	if err := parseFlags(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if path := fs.Arg(0); path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	feeds, err := client.ParseOPML(r)
+	if err != nil {
+		return err
+	}
+
+	results := make([]importResult, len(feeds))
+	failed := 0
+	for i, f := range feeds {
+		results[i] = importResult{Title: f.Title, URL: f.URL, Result: "added"}
+		_, err := e.client.Subscribe(ctx, f.URL)
+		switch {
+		case err == nil:
+		case errors.Is(err, client.ErrConflict):
+			results[i].Result = "exists"
+		default:
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			results[i].Result, results[i].Error = "failed", err.Error()
+			failed++
+		}
+	}
+	if err := e.out.imported(results); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d feeds couldn't be added", failed, len(feeds))
+	}
+	return nil
+}
+
+// importResult is what happened to one feed of an OPML file.
+type importResult struct {
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Result string `json:"result"` // added, exists or failed
+	Error  string `json:"error,omitempty"`
+}
+
+func summaryCmd(ctx context.Context, e *env, name string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	count := fs.Int("n", 5, "number of articles to cover")
+	trends := fs.Bool("trends", false, "cover what is trending rather than the latest")
+	if err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	summary, err := e.client.Summary(ctx, client.SummaryQuery{Count: *count, Trends: *trends})
+	if err != nil {
+		return err
+	}
+	return e.out.summary(summary)
+}
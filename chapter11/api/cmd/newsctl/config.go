@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// =============================================================================
+// CONFIG - Server URL, API key and output format
+// =============================================================================
+
+// config is newsctl's settings. Each comes from, in increasing priority,
+// the default, the config file, the environment and the flags.
+type config struct {
+	Server string `yaml:"server"`
+	APIKey string `yaml:"api_key"`
+	Output string `yaml:"output"`
+}
+
+// defaultConfigPath is config.yaml in newsctl's directory under the user
+// config directory, such as ~/.config/newsctl/config.yaml.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "newsctl", "config.yaml")
+}
+
+// loadConfig reads the config file at path, if it exists, and applies
+// NEWSCTL_SERVER, NEWSCTL_API_KEY and NEWSCTL_OUTPUT from lookup.
+func loadConfig(path string, lookup func(string) (string, bool)) (config, error) {
+	cfg := config{Server: "http://localhost:8080", Output: "table"}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return config{}, fmt.Errorf("failed to read config: %w", err)
+		default:
+			dec := yaml.NewDecoder(bytes.NewReader(data))
+			dec.KnownFields(true)
+			if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+				return config{}, fmt.Errorf("invalid config %s: %w", path, err)
+			}
+		}
+	}
+
+	for name, dst := range map[string]*string{
+		"NEWSCTL_SERVER":  &cfg.Server,
+		"NEWSCTL_API_KEY": &cfg.APIKey,
+		"NEWSCTL_OUTPUT":  &cfg.Output,
+	} {
+		if value, ok := lookup(name); ok && value != "" {
+			*dst = value
+		}
+	}
+	return cfg, nil
+}
+
+// override applies the non-empty flag values.
+func (c *config) override(server, apiKey, output string) {
+	if server != "" {
+		c.Server = server
+	}
+	if apiKey != "" {
+		c.APIKey = apiKey
+	}
+	if output != "" {
+		c.Output = output
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/YOUR_USERNAME/go-news/api/client"
+)
+
+// =============================================================================
+// NEWSCTL - Terminal client for the news API
+// =============================================================================
+
+// usage is printed for -h and for unknown commands.
+const usage = `Usage: newsctl [flags] <command> [command flags] [args]
+
+Commands:
+  articles [-n N] [-feed NAME]... [-collapse]   List recent articles
+  search [-n N] [-feed NAME]... QUERY           Find recent articles mentioning QUERY
+  tail [-feed NAME]... [-q KEYWORD]             Print new articles as they arrive
+  open [-feed NAME]... [-q KEYWORD] N|URL       Open the Nth listed article, or a URL, in a browser
+  feeds                                         List subscribed feeds and their health
+  add URL...                                    Subscribe to feeds or websites
+  remove ID...                                  Unsubscribe from feeds added through the API
+  import FILE                                   Subscribe to every feed in an OPML file (- for stdin)
+  summary [-n N] [-trends]                      Request a news report
+
+Flags:
+`
+
+// errUsage marks errors in how newsctl was invoked.
+var errUsage = errors.New("usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(os.Stderr, "newsctl: %v\n", err)
+		os.Exit(2)
+	case errors.Is(err, context.Canceled):
+		// Interrupted, usually out of tail
+	default:
+		fmt.Fprintf(os.Stderr, "newsctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the global flags and config and runs one command.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("newsctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+	configPath := fs.String("config", defaultConfigPath(), "config file with server, api_key and output")
+	server := fs.String("server", "", "API server URL (default from config, NEWSCTL_SERVER or http://localhost:8080)")
+	apiKey := fs.String("api-key", "", "API key sent as a bearer token (default from config or NEWSCTL_API_KEY)")
+	output := fs.String("o", "", "output format: table or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(*configPath, os.LookupEnv)
+	if err != nil {
+		return err
+	}
+	cfg.override(*server, *apiKey, *output)
+	if cfg.Output != "table" && cfg.Output != "json" {
+		return fmt.Errorf("%w: output must be table or json, not %q", errUsage, cfg.Output)
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("%w: no command given", errUsage)
+	}
+	name, cmdArgs := fs.Arg(0), fs.Args()[1:]
+	cmd, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("%w: unknown command %q (see newsctl -h)", errUsage, name)
+	}
+
+	var opts []client.Option
+	if cfg.APIKey != "" {
+		opts = append(opts, client.WithAPIKey(cfg.APIKey))
+	}
+	opts = append(opts, client.WithUserAgent("newsctl/1.0"))
+	c, err := client.New(cfg.Server, opts...)
+	if err != nil {
+		return err
+	}
+
+	return cmd(ctx, &env{client: c, out: newPrinter(stdout, cfg.Output)}, name, cmdArgs)
+}
+
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// writeConfig writes a newsctl config file and returns its path.
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string // "" for no config file
+		env     map[string]string
+		want    config
+		wantErr bool
+	}{
+		{"defaults", "", nil, config{Server: "http://localhost:8080", Output: "table"}, false},
+		{"file", "server: http://news.example\napi_key: file-key\n", nil, config{Server: "http://news.example", APIKey: "file-key", Output: "table"}, false},
+		{
+			"environment over file",
+			"server: http://news.example\napi_key: file-key\noutput: json\n",
+			map[string]string{"NEWSCTL_API_KEY": "env-key", "NEWSCTL_OUTPUT": "table"},
+			config{Server: "http://news.example", APIKey: "env-key", Output: "table"},
+			false,
+		},
+		{"empty environment ignored", "api_key: file-key\n", map[string]string{"NEWSCTL_API_KEY": ""}, config{Server: "http://localhost:8080", APIKey: "file-key", Output: "table"}, false},
+		{"comments only", "# server: http://news.example\n", nil, config{Server: "http://localhost:8080", Output: "table"}, false},
+		{"unknown field", "sever: http://news.example\n", nil, config{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "missing.yaml")
+			if tt.file != "" {
+				path = writeConfig(t, tt.file)
+			}
+			lookup := func(name string) (string, bool) {
+				value, ok := tt.env[name]
+				return value, ok
+			}
+
+			got, err := loadConfig(path, lookup)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadConfig error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("loadConfig = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// apiServer answers /v1/articles with one article and records the
+// Authorization header of each request.
+type apiServer struct {
+	mu    sync.Mutex
+	auths []string
+}
+
+func (s *apiServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.auths = append(s.auths, r.Header.Get("Authorization"))
+	s.mu.Unlock()
+
+	if r.URL.Path != "/v1/articles" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(`{"articles":[{"id":"a1","title":"Go 1.23 is released","link":"https://go.dev/blog/go1.23",` +
+		`"published":"2024-08-13T16:00:00Z","published_estimated":false,"feed_title":"The Go Blog","tags":[],"starred":false,"read":false}]}`))
+}
+
+func TestRun(t *testing.T) {
+	api := &apiServer{}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	fileConfig := writeConfig(t, "server: "+srv.URL+"\napi_key: file-key\n")
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		args     []string
+		wantErr  error
+		wantAuth string // The Authorization header sent; ignored when no request is made
+		wantOut  string
+	}{
+		{"config file", nil, []string{"-config", fileConfig, "articles"}, nil, "Bearer file-key", "Go 1.23 is released"},
+		{"environment over file", map[string]string{"NEWSCTL_API_KEY": "env-key"}, []string{"-config", fileConfig, "articles"}, nil, "Bearer env-key", "The Go Blog"},
+		{"flag over environment", map[string]string{"NEWSCTL_API_KEY": "env-key"}, []string{"-config", fileConfig, "-api-key", "flag-key", "articles"}, nil, "Bearer flag-key", "PUBLISHED"},
+		{"server from environment", map[string]string{"NEWSCTL_SERVER": srv.URL}, []string{"-config", "", "articles"}, nil, "", "Go 1.23"},
+		{"server flag over file", map[string]string{"NEWSCTL_SERVER": "http://unused.invalid"}, []string{"-config", fileConfig, "-server", srv.URL, "articles"}, nil, "Bearer file-key", "Go 1.23"},
+		{"json output", map[string]string{"NEWSCTL_OUTPUT": "json"}, []string{"-config", fileConfig, "articles"}, nil, "Bearer file-key", `"title": "Go 1.23 is released"`},
+		{"invalid output flag", nil, []string{"-config", fileConfig, "-o", "yaml", "articles"}, errUsage, "", ""},
+		{"invalid output in environment", map[string]string{"NEWSCTL_OUTPUT": "csv"}, []string{"-config", fileConfig, "articles"}, errUsage, "", ""},
+		{"unknown command", nil, []string{"-config", fileConfig, "frobnicate"}, errUsage, "", ""},
+		{"no command", nil, []string{"-config", fileConfig}, errUsage, "", ""},
+		{"bad command flag", nil, []string{"-config", fileConfig, "articles", "-x"}, errUsage, "", ""},
+		{"help", nil, []string{"-h"}, flag.ErrHelp, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"NEWSCTL_SERVER", "NEWSCTL_API_KEY", "NEWSCTL_OUTPUT"} {
+				t.Setenv(name, tt.env[name]) // Empty values are ignored, as if unset
+			}
+			api.mu.Lock()
+			api.auths = nil
+			api.mu.Unlock()
+
+			var stdout, stderr bytes.Buffer
+			err := run(context.Background(), tt.args, &stdout, &stderr)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("run error = %v, want %v", err, tt.wantErr)
+				}
+				if len(api.auths) != 0 {
+					t.Errorf("%d requests made, want none", len(api.auths))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("run error = %v\n%s", err, stderr.String())
+			}
+			if len(api.auths) != 1 || api.auths[0] != tt.wantAuth {
+				t.Errorf("Authorization headers = %q, want [%q]", api.auths, tt.wantAuth)
+			}
+			if !strings.Contains(stdout.String(), tt.wantOut) {
+				t.Errorf("output = %q, want it to contain %q", stdout.String(), tt.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/client"
+)
+
+// =============================================================================
+// OUTPUT - Tables for people, JSON for scripts
+// =============================================================================
+
+// maxTitle is how much of a title a table row shows.
+const maxTitle = 80
+
+// printer writes command results as tables or JSON.
+type printer struct {
+	w      io.Writer
+	format string // table or json
+}
+
+func newPrinter(w io.Writer, format string) *printer {
+	return &printer{w: w, format: format}
+}
+
+// json writes v as indented JSON.
+func (p *printer) json(v any) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// table writes tab-separated rows as aligned columns.
+func (p *printer) table(header string, rows []string) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, header)
+	for _, row := range rows {
+		fmt.Fprintln(tw, row)
+	}
+	return tw.Flush()
+}
+
+func (p *printer) articles(articles []client.Article) error {
+	if p.format == "json" {
+		return p.json(articles)
+	}
+	rows := make([]string, len(articles))
+	for i, a := range articles {
+		rows[i] = fmt.Sprintf("%d\t%s\t%s\t%s", i+1, date(a.Published), a.FeedTitle, truncate(a.Title))
+	}
+	return p.table("#\tPUBLISHED\tFEED\tTITLE", rows)
+}
+
+// streamed writes one article as it arrives: a line of JSON, or a line
+// of text with its link.
+func (p *printer) streamed(a client.Article) error {
+	if p.format == "json" {
+		return json.NewEncoder(p.w).Encode(a)
+	}
+	_, err := fmt.Fprintf(p.w, "%s  [%s] %s\n    %s\n", date(a.Published), a.FeedTitle, a.Title, a.Link)
+	return err
+}
+
+func (p *printer) feeds(feeds []client.FeedStatus) error {
+	if p.format == "json" {
+		return p.json(feeds)
+	}
+	rows := make([]string, len(feeds))
+	for i, f := range feeds {
+		rows[i] = fmt.Sprintf("%s\t%s\t%s\t%s", f.ID, f.State, date(f.LastSuccess), f.URL)
+	}
+	return p.table("ID\tSTATE\tLAST SUCCESS\tURL", rows)
+}
+
+func (p *printer) subscriptions(subs []client.Subscription) error {
+	if p.format == "json" {
+		return p.json(subs)
+	}
+	rows := make([]string, len(subs))
+	for i, s := range subs {
+		rows[i] = fmt.Sprintf("%s\t%s\t%s", s.ID, s.URL, s.Title)
+	}
+	return p.table("ID\tURL\tTITLE", rows)
+}
+
+func (p *printer) imported(results []importResult) error {
+	if p.format == "json" {
+		return p.json(results)
+	}
+	rows := make([]string, len(results))
+	for i, r := range results {
+		rows[i] = fmt.Sprintf("%s\t%s\t%s", r.Result, r.URL, r.Error)
+	}
+	return p.table("RESULT\tURL\tERROR", rows)
+}
+
+func (p *printer) summary(s client.Summary) error {
+	if p.format == "json" {
+		return p.json(s)
+	}
+	if len(s.Trends) > 0 {
+		fmt.Fprintf(p.w, "Trending: %s\n\n", strings.Join(s.Trends, ", "))
+	}
+	_, err := fmt.Fprintf(p.w, "%s\n\n(%d articles)\n", s.Summary, s.ArticleCount)
+	return err
+}
+
+// status reports a command's progress; JSON output stays silent so it
+// remains parseable.
+func (p *printer) status(format string, args ...any) {
+	if p.format != "json" {
+		fmt.Fprintf(p.w, format+"\n", args...)
+	}
+}
+
+// date formats a time in the local zone, or "-" for none.
+func date(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// truncate shortens a title to maxTitle runes.
+func truncate(s string) string {
+	if r := []rune(s); len(r) > maxTitle {
+		return string(r[:maxTitle-1]) + "…"
+	}
+	return s
+}
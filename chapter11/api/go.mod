@@ -2,4 +2,7 @@ module github.com/YOUR_USERNAME/go-news/api
 
 go 1.22
 
-require github.com/YOUR_USERNAME/go-news/newsroom v0.0.0
+require (
+	github.com/YOUR_USERNAME/go-news/newsroom v0.0.0
+	gopkg.in/yaml.v3 v3.0.1
+)
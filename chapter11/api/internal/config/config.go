@@ -0,0 +1,652 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/fixture"
+	"github.com/YOUR_USERNAME/go-news/api/internal/reader"
+	"github.com/YOUR_USERNAME/go-news/api/internal/webhook"
+	"github.com/YOUR_USERNAME/go-news/api/internal/websub"
+	"github.com/YOUR_USERNAME/go-news/newsroom"
+)
+
+// =============================================================================
+// CONFIGURATION - Typed settings for the API server
+// =============================================================================
+
+// Config is the complete, typed configuration for cmd/api.
+// Values are layered in this order, each overriding the previous one:
+// built-in defaults, the config file, environment variables, and flags.
+type Config struct {
+	Server        ServerConfig        `json:"server" yaml:"server"`
+	Feeds         []string            `json:"feeds" yaml:"feeds"`
+	Subscriptions SubscriptionsConfig `json:"subscriptions" yaml:"subscriptions"`
+	Fetch         FetchConfig         `json:"fetch" yaml:"fetch"`
+	Summarizer    SummarizerConfig    `json:"summarizer" yaml:"summarizer"`
+	Webhooks      WebhooksConfig      `json:"webhooks" yaml:"webhooks"`
+	Rules         RulesConfig         `json:"rules" yaml:"rules"`
+	WebSub        WebSubConfig        `json:"websub" yaml:"websub"`
+	Log           LogConfig           `json:"log" yaml:"log"`
+}
+
+// ServerConfig holds HTTP server settings.
+type ServerConfig struct {
+	Host            string          `json:"host" yaml:"host"`
+	Port            int             `json:"port" yaml:"port"`
+	ReadTimeout     Duration        `json:"read_timeout" yaml:"read_timeout"`
+	WriteTimeout    Duration        `json:"write_timeout" yaml:"write_timeout"`
+	IdleTimeout     Duration        `json:"idle_timeout" yaml:"idle_timeout"`
+	ShutdownTimeout Duration        `json:"shutdown_timeout" yaml:"shutdown_timeout"`
+	RateLimit       RateLimitConfig `json:"rate_limit" yaml:"rate_limit"`
+}
+
+// RateLimitConfig holds per-client request limits.
+type RateLimitConfig struct {
+	RequestsPerSecond float64 `json:"requests_per_second" yaml:"requests_per_second"` // 0 disables limiting
+	Burst             int     `json:"burst" yaml:"burst"`
+}
+
+// Addr returns the listen address in host:port form.
+func (s ServerConfig) Addr() string {
+	return net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+}
+
+// FetchConfig holds feed fetching settings.
+type FetchConfig struct {
+	Timeout      Duration `json:"timeout" yaml:"timeout"`
+	Interval     Duration `json:"interval" yaml:"interval"`             // Time between polls of each feed
+	MaxBodyBytes int      `json:"max_body_bytes" yaml:"max_body_bytes"` // Largest feed accepted, after decompression
+
+	// Startup fetches run concurrently within these limits
+	Workers         int      `json:"workers" yaml:"workers"`                   // Feeds fetched at once
+	PerHost         int      `json:"per_host" yaml:"per_host"`                 // Feeds fetched at once from one host
+	StartupDeadline Duration `json:"startup_deadline" yaml:"startup_deadline"` // Time allowed for the initial fetch of all feeds
+
+	// Failing feeds are retried, then backed off by a circuit breaker
+	MaxAttempts      int      `json:"max_attempts" yaml:"max_attempts"`           // Attempts per fetch for timeouts, 5xx and 429
+	BreakerThreshold int      `json:"breaker_threshold" yaml:"breaker_threshold"` // Consecutive failed fetches before backing off; 0 disables
+	BreakerCooldown  Duration `json:"breaker_cooldown" yaml:"breaker_cooldown"`   // First backoff period; doubles up to 6h or the cooldown if longer
+
+	// Requests, whether feed polls or page fetches, stay polite to hosts
+	RespectRobots   bool     `json:"respect_robots" yaml:"respect_robots"`       // Honour robots.txt rules and Crawl-delay
+	MinHostInterval Duration `json:"min_host_interval" yaml:"min_host_interval"` // Minimum time between requests to one host; 0 disables
+
+	Fixtures FixturesConfig `json:"fixtures" yaml:"fixtures"`
+}
+
+// FixturesConfig records fetched responses to a directory, or replays
+// them from one instead of using the network.
+type FixturesConfig struct {
+	Mode string `json:"mode" yaml:"mode"` // "record", "replay", or empty to fetch normally
+	Dir  string `json:"dir" yaml:"dir"`   // Fixture directory, laid out by host and path
+}
+
+// Offline reports whether feeds are replayed from fixtures.
+func (f FixturesConfig) Offline() bool {
+	return f.Mode == string(fixture.ModeReplay)
+}
+
+// ReaderOptions converts the fetch settings to reader options.
+func (f FetchConfig) ReaderOptions() []reader.Option {
+	cooldown := time.Duration(f.BreakerCooldown)
+	opts := []reader.Option{
+		reader.WithTimeout(time.Duration(f.Timeout)),
+		reader.WithMaxBodySize(int64(f.MaxBodyBytes)),
+		reader.WithRetry(reader.RetryPolicy{
+			MaxAttempts: f.MaxAttempts,
+			BaseDelay:   time.Second,
+			MaxDelay:    30 * time.Second,
+		}),
+		reader.WithCircuitBreaker(reader.BreakerPolicy{
+			Threshold:   f.BreakerThreshold,
+			Cooldown:    cooldown,
+			MaxCooldown: max(6*time.Hour, cooldown),
+		}),
+	}
+
+	politeness := reader.PolitenessPolicy{
+		RespectRobots: f.RespectRobots,
+		MinInterval:   time.Duration(f.MinHostInterval),
+	}
+	if f.Fixtures.Mode != "" {
+		opts = append(opts, reader.WithTransport(fixture.NewTransport(f.Fixtures.Dir, fixture.Mode(f.Fixtures.Mode), nil)))
+	}
+	if f.Fixtures.Offline() {
+		politeness.MinInterval = 0 // No real host to be polite to
+	}
+	return append(opts, reader.WithPoliteness(politeness))
+}
+
+// Limits converts the concurrency settings to reader.Limits.
+func (f FetchConfig) Limits() reader.Limits {
+	return reader.Limits{Workers: f.Workers, PerHost: f.PerHost}
+}
+
+// SummarizerConfig holds AI summarizer settings.
+type SummarizerConfig struct {
+	Stub      bool   `json:"stub" yaml:"stub"` // Always use the stub summarizer
+	OllamaURL string `json:"ollama_url" yaml:"ollama_url"`
+	Model     string `json:"model" yaml:"model"`
+	MaxTokens int    `json:"max_tokens" yaml:"max_tokens"`
+}
+
+// Newsroom converts the settings to the newsroom module's own Config type.
+func (s SummarizerConfig) Newsroom() newsroom.Config {
+	return newsroom.Config{
+		OllamaURL: s.OllamaURL,
+		Model:     s.Model,
+		MaxTokens: s.MaxTokens,
+	}
+}
+
+// SubscriptionsConfig holds settings for feeds added through the API.
+// Feeds listed in the config file are always subscribed as well.
+type SubscriptionsConfig struct {
+	StateFile string `json:"state_file" yaml:"state_file"` // Persists API subscriptions; empty keeps them in memory
+	SecretKey string `json:"secret_key" yaml:"secret_key"` // Base64 AES key encrypting feed credentials in the state file
+}
+
+// Key decodes the secret key, returning nil if none is set.
+func (s SubscriptionsConfig) Key() ([]byte, error) {
+	if s.SecretKey == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(s.SecretKey)
+	if err != nil {
+		return nil, errors.New("must be base64")
+	}
+	if n := len(key); n != 16 && n != 24 && n != 32 {
+		return nil, fmt.Errorf("must decode to 16, 24 or 32 bytes (got %d)", n)
+	}
+	return key, nil
+}
+
+// WebhooksConfig holds outbound webhook delivery settings.
+type WebhooksConfig struct {
+	StateFile      string   `json:"state_file" yaml:"state_file"` // Persists hooks and the retry queue; empty keeps them in memory
+	MaxAttempts    int      `json:"max_attempts" yaml:"max_attempts"`
+	InitialBackoff Duration `json:"initial_backoff" yaml:"initial_backoff"`
+	MaxBackoff     Duration `json:"max_backoff" yaml:"max_backoff"`
+	Timeout        Duration `json:"timeout" yaml:"timeout"`
+}
+
+// Options converts the settings to webhook.Options.
+func (w WebhooksConfig) Options() webhook.Options {
+	opts := webhook.DefaultOptions()
+	opts.StateFile = w.StateFile
+	opts.MaxAttempts = w.MaxAttempts
+	opts.InitialBackoff = time.Duration(w.InitialBackoff)
+	opts.MaxBackoff = time.Duration(w.MaxBackoff)
+	opts.Timeout = time.Duration(w.Timeout)
+	return opts
+}
+
+// RulesConfig holds settings for the filter and routing rules applied to
+// incoming articles.
+type RulesConfig struct {
+	StateFile string `json:"state_file" yaml:"state_file"` // Persists rules created through the API; empty keeps them in memory
+}
+
+// WebSubConfig holds settings for push subscriptions to feeds that
+// advertise a WebSub hub. Those feeds are still polled as a fallback.
+type WebSubConfig struct {
+	CallbackURL string   `json:"callback_url" yaml:"callback_url"` // Public URL of /websub/callback on this server; empty disables WebSub
+	Lease       Duration `json:"lease" yaml:"lease"`               // Subscription lifetime requested from hubs; renewed before it ends
+}
+
+// Enabled reports whether push subscriptions are configured.
+func (w WebSubConfig) Enabled() bool {
+	return w.CallbackURL != ""
+}
+
+// Options converts the settings to websub.Options. Pushed content is
+// bounded like fetched feeds.
+func (w WebSubConfig) Options(maxBodyBytes int) websub.Options {
+	opts := websub.DefaultOptions()
+	opts.CallbackURL = w.CallbackURL
+	opts.Lease = time.Duration(w.Lease)
+	opts.MaxBodySize = int64(maxBodyBytes)
+	return opts
+}
+
+// LogConfig holds logging settings.
+type LogConfig struct {
+	Level string `json:"level" yaml:"level"` // debug, info, warn or error
+}
+
+// SlogLevel converts the configured level name to a slog.Level.
+// Validate guarantees the name is recognised.
+func (l LogConfig) SlogLevel() slog.Level {
+	var level slog.Level
+	level.UnmarshalText([]byte(l.Level))
+	return level
+}
+
+// Default returns the built-in configuration.
+// Summarizer defaults come from newsroom.DefaultConfig so both modules agree.
+func Default() Config {
+	summarizer := newsroom.DefaultConfig()
+
+	return Config{
+		Server: ServerConfig{
+			Port:            8080,
+			ReadTimeout:     Duration(15 * time.Second),
+			WriteTimeout:    Duration(15 * time.Second),
+			IdleTimeout:     Duration(60 * time.Second),
+			ShutdownTimeout: Duration(10 * time.Second),
+			RateLimit: RateLimitConfig{
+				RequestsPerSecond: 10,
+				Burst:             20,
+			},
+		},
+		Feeds: []string{
+			"https://www.reddit.com/r/golang.rss",
+			"https://go.dev/blog/feed.atom",
+		},
+		Fetch: FetchConfig{
+			Timeout:      Duration(30 * time.Second),
+			Interval:     Duration(15 * time.Minute),
+			MaxBodyBytes: reader.DefaultMaxBodySize,
+
+			Workers:         8,
+			PerHost:         2,
+			StartupDeadline: Duration(time.Minute),
+
+			MaxAttempts:      3,
+			BreakerThreshold: 3,
+			BreakerCooldown:  Duration(5 * time.Minute),
+
+			RespectRobots:   true,
+			MinHostInterval: Duration(time.Second),
+		},
+		Summarizer: SummarizerConfig{
+			OllamaURL: summarizer.OllamaURL,
+			Model:     summarizer.Model,
+			MaxTokens: summarizer.MaxTokens,
+		},
+		Webhooks: WebhooksConfig{
+			MaxAttempts:    8,
+			InitialBackoff: Duration(30 * time.Second),
+			MaxBackoff:     Duration(time.Hour),
+			Timeout:        Duration(10 * time.Second),
+		},
+		WebSub: WebSubConfig{
+			Lease: Duration(24 * time.Hour),
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+	}
+}
+
+// =============================================================================
+// FILE LOADING
+// =============================================================================
+
+// LoadFile decodes a JSON or YAML config file on top of cfg.
+// The format is chosen by extension: .json, or .yaml/.yml.
+// Unknown keys are rejected so typos don't silently fall back to defaults.
+func LoadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(cfg); err != nil {
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("unsupported config file extension %q (use .json, .yaml or .yml)", ext)
+	}
+
+	return nil
+}
+
+// =============================================================================
+// ENVIRONMENT OVERRIDES
+// =============================================================================
+
+// envOverrides maps environment variables to setters on Config.
+// OLLAMA_URL and OLLAMA_MODEL keep the names newsroom already uses.
+var envOverrides = []struct {
+	name  string
+	apply func(cfg *Config, value string) error
+}{
+	{"NEWS_HOST", func(c *Config, v string) error { c.Server.Host = v; return nil }},
+	{"NEWS_PORT", func(c *Config, v string) error { return setInt(&c.Server.Port, v) }},
+	{"NEWS_READ_TIMEOUT", func(c *Config, v string) error { return c.Server.ReadTimeout.Set(v) }},
+	{"NEWS_WRITE_TIMEOUT", func(c *Config, v string) error { return c.Server.WriteTimeout.Set(v) }},
+	{"NEWS_IDLE_TIMEOUT", func(c *Config, v string) error { return c.Server.IdleTimeout.Set(v) }},
+	{"NEWS_SHUTDOWN_TIMEOUT", func(c *Config, v string) error { return c.Server.ShutdownTimeout.Set(v) }},
+	{"NEWS_RATE_LIMIT", func(c *Config, v string) error { return setFloat(&c.Server.RateLimit.RequestsPerSecond, v) }},
+	{"NEWS_RATE_BURST", func(c *Config, v string) error { return setInt(&c.Server.RateLimit.Burst, v) }},
+	{"NEWS_FEEDS", func(c *Config, v string) error { c.Feeds = splitList(v); return nil }},
+	{"NEWS_FETCH_TIMEOUT", func(c *Config, v string) error { return c.Fetch.Timeout.Set(v) }},
+	{"NEWS_FETCH_INTERVAL", func(c *Config, v string) error { return c.Fetch.Interval.Set(v) }},
+	{"NEWS_FETCH_MAX_BODY_BYTES", func(c *Config, v string) error { return setInt(&c.Fetch.MaxBodyBytes, v) }},
+	{"NEWS_FETCH_WORKERS", func(c *Config, v string) error { return setInt(&c.Fetch.Workers, v) }},
+	{"NEWS_FETCH_PER_HOST", func(c *Config, v string) error { return setInt(&c.Fetch.PerHost, v) }},
+	{"NEWS_FETCH_STARTUP_DEADLINE", func(c *Config, v string) error { return c.Fetch.StartupDeadline.Set(v) }},
+	{"NEWS_FETCH_MAX_ATTEMPTS", func(c *Config, v string) error { return setInt(&c.Fetch.MaxAttempts, v) }},
+	{"NEWS_FETCH_BREAKER_THRESHOLD", func(c *Config, v string) error { return setInt(&c.Fetch.BreakerThreshold, v) }},
+	{"NEWS_FETCH_BREAKER_COOLDOWN", func(c *Config, v string) error { return c.Fetch.BreakerCooldown.Set(v) }},
+	{"NEWS_FETCH_RESPECT_ROBOTS", func(c *Config, v string) error { return setBool(&c.Fetch.RespectRobots, v) }},
+	{"NEWS_FETCH_MIN_HOST_INTERVAL", func(c *Config, v string) error { return c.Fetch.MinHostInterval.Set(v) }},
+	{"NEWS_FETCH_FIXTURES_MODE", func(c *Config, v string) error { c.Fetch.Fixtures.Mode = v; return nil }},
+	{"NEWS_FETCH_FIXTURES_DIR", func(c *Config, v string) error { c.Fetch.Fixtures.Dir = v; return nil }},
+	{"NEWS_SUMMARIZER_STUB", func(c *Config, v string) error { return setBool(&c.Summarizer.Stub, v) }},
+	{"OLLAMA_URL", func(c *Config, v string) error { c.Summarizer.OllamaURL = v; return nil }},
+	{"OLLAMA_MODEL", func(c *Config, v string) error { c.Summarizer.Model = v; return nil }},
+	{"NEWS_SUMMARIZER_MAX_TOKENS", func(c *Config, v string) error { return setInt(&c.Summarizer.MaxTokens, v) }},
+	{"NEWS_SUBSCRIPTIONS_STATE_FILE", func(c *Config, v string) error { c.Subscriptions.StateFile = v; return nil }},
+	{"NEWS_SUBSCRIPTIONS_SECRET_KEY", func(c *Config, v string) error { c.Subscriptions.SecretKey = v; return nil }},
+	{"NEWS_WEBHOOK_STATE_FILE", func(c *Config, v string) error { c.Webhooks.StateFile = v; return nil }},
+	{"NEWS_RULES_STATE_FILE", func(c *Config, v string) error { c.Rules.StateFile = v; return nil }},
+	{"NEWS_WEBSUB_CALLBACK_URL", func(c *Config, v string) error { c.WebSub.CallbackURL = v; return nil }},
+	{"NEWS_WEBSUB_LEASE", func(c *Config, v string) error { return c.WebSub.Lease.Set(v) }},
+	{"NEWS_LOG_LEVEL", func(c *Config, v string) error { c.Log.Level = v; return nil }},
+}
+
+// ApplyEnv overrides cfg with any environment variables that are set.
+// lookup is usually os.LookupEnv; tests pass a map-backed function.
+func ApplyEnv(cfg *Config, lookup func(string) (string, bool)) error {
+	var errs []error
+	for _, override := range envOverrides {
+		value, ok := lookup(override.name)
+		if !ok || value == "" {
+			continue
+		}
+		if err := override.apply(cfg, value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", override.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func setInt(dst *int, value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid integer %q", value)
+	}
+	*dst = n
+	return nil
+}
+
+func setFloat(dst *float64, value string) error {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %q", value)
+	}
+	*dst = f
+	return nil
+}
+
+func setBool(dst *bool, value string) error {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid boolean %q", value)
+	}
+	*dst = b
+	return nil
+}
+
+// splitList splits a comma-separated list, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// =============================================================================
+// VALIDATION
+// =============================================================================
+
+// Validate checks every setting and reports all problems at once,
+// each prefixed with the config key it refers to.
+func (c Config) Validate() error {
+	var errs []error
+	fail := func(key, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		fail("server.port", "must be between 1 and 65535 (got %d)", c.Server.Port)
+	}
+	for _, d := range []struct {
+		key   string
+		value Duration
+	}{
+		{"server.read_timeout", c.Server.ReadTimeout},
+		{"server.write_timeout", c.Server.WriteTimeout},
+		{"server.idle_timeout", c.Server.IdleTimeout},
+		{"server.shutdown_timeout", c.Server.ShutdownTimeout},
+		{"fetch.timeout", c.Fetch.Timeout},
+		{"fetch.interval", c.Fetch.Interval},
+		{"fetch.startup_deadline", c.Fetch.StartupDeadline},
+		{"fetch.breaker_cooldown", c.Fetch.BreakerCooldown},
+		{"webhooks.initial_backoff", c.Webhooks.InitialBackoff},
+		{"webhooks.max_backoff", c.Webhooks.MaxBackoff},
+		{"webhooks.timeout", c.Webhooks.Timeout},
+	} {
+		if d.value <= 0 {
+			fail(d.key, "must be a positive duration (got %s)", d.value)
+		}
+	}
+
+	if c.Fetch.Interval > 0 && c.Fetch.Interval < Duration(10*time.Second) {
+		fail("fetch.interval", "must be at least 10s to avoid hammering publishers (got %s)", c.Fetch.Interval)
+	}
+
+	if c.Fetch.MaxBodyBytes < 1024 {
+		fail("fetch.max_body_bytes", "must be at least 1024 (got %d)", c.Fetch.MaxBodyBytes)
+	}
+	if c.Fetch.Workers < 1 {
+		fail("fetch.workers", "must be at least 1 (got %d)", c.Fetch.Workers)
+	}
+	if c.Fetch.PerHost < 1 {
+		fail("fetch.per_host", "must be at least 1 (got %d)", c.Fetch.PerHost)
+	}
+	if c.Fetch.MaxAttempts < 1 {
+		fail("fetch.max_attempts", "must be at least 1 (got %d)", c.Fetch.MaxAttempts)
+	}
+	if c.Fetch.BreakerThreshold < 0 {
+		fail("fetch.breaker_threshold", "must not be negative (got %d)", c.Fetch.BreakerThreshold)
+	}
+	if c.Fetch.MinHostInterval < 0 {
+		fail("fetch.min_host_interval", "must not be negative (got %s)", c.Fetch.MinHostInterval)
+	}
+
+	if c.Server.RateLimit.RequestsPerSecond < 0 {
+		fail("server.rate_limit.requests_per_second", "must not be negative (got %g)", c.Server.RateLimit.RequestsPerSecond)
+	}
+	if c.Server.RateLimit.RequestsPerSecond > 0 && c.Server.RateLimit.Burst < 1 {
+		fail("server.rate_limit.burst", "must be at least 1 when rate limiting is enabled (got %d)", c.Server.RateLimit.Burst)
+	}
+
+	seen := make(map[string]bool)
+	switch fixture.Mode(c.Fetch.Fixtures.Mode) {
+	case "":
+	case fixture.ModeRecord, fixture.ModeReplay:
+		if c.Fetch.Fixtures.Dir == "" {
+			fail("fetch.fixtures.dir", "must be set to %s fixtures", c.Fetch.Fixtures.Mode)
+		}
+	default:
+		fail("fetch.fixtures.mode", "must be record, replay or empty (got %q)", c.Fetch.Fixtures.Mode)
+	}
+
+	for i, feedURL := range c.Feeds {
+		key := fmt.Sprintf("feeds[%d]", i)
+		localFile := c.Fetch.Fixtures.Offline() && strings.HasPrefix(feedURL, "file://") // Read from disk when replaying
+		if err := validateHTTPURL(feedURL); err != nil && !localFile {
+			fail(key, "%v", err)
+		}
+		if seen[feedURL] {
+			fail(key, "duplicate feed %q", feedURL)
+		}
+		seen[feedURL] = true
+	}
+
+	if !c.Summarizer.Stub {
+		if err := validateHTTPURL(c.Summarizer.OllamaURL); err != nil {
+			fail("summarizer.ollama_url", "%v", err)
+		}
+		if c.Summarizer.Model == "" {
+			fail("summarizer.model", "must not be empty")
+		}
+	}
+	if c.Summarizer.MaxTokens <= 0 {
+		fail("summarizer.max_tokens", "must be positive (got %d)", c.Summarizer.MaxTokens)
+	}
+
+	if _, err := c.Subscriptions.Key(); err != nil {
+		fail("subscriptions.secret_key", "%v", err)
+	}
+
+	if c.Webhooks.MaxAttempts < 1 {
+		fail("webhooks.max_attempts", "must be at least 1 (got %d)", c.Webhooks.MaxAttempts)
+	}
+	if c.Webhooks.MaxBackoff < c.Webhooks.InitialBackoff {
+		fail("webhooks.max_backoff", "must not be less than webhooks.initial_backoff (%s < %s)", c.Webhooks.MaxBackoff, c.Webhooks.InitialBackoff)
+	}
+
+	if c.WebSub.Enabled() {
+		if err := validateHTTPURL(c.WebSub.CallbackURL); err != nil {
+			fail("websub.callback_url", "%v", err)
+		}
+	}
+	if time.Duration(c.WebSub.Lease) < time.Minute {
+		fail("websub.lease", "must be at least 1m (got %s)", c.WebSub.Lease)
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Log.Level)); err != nil {
+		fail("log.level", "must be one of debug, info, warn, error (got %q)", c.Log.Level)
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateHTTPURL checks that raw is an absolute http or https URL.
+func validateHTTPURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", raw, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("URL %q must use http or https", raw)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("URL %q has no host", raw)
+	}
+	return nil
+}
+
+// =============================================================================
+// REDACTION
+// =============================================================================
+
+// redactedValue replaces secrets in printed configuration.
+const redactedValue = "REDACTED"
+
+// Redacted returns a copy of the config that is safe to print or log.
+// Credentials embedded in URLs and secret keys are masked.
+func (c Config) Redacted() Config {
+	r := c
+	r.Feeds = make([]string, len(c.Feeds))
+	for i, feedURL := range c.Feeds {
+		r.Feeds[i] = redactURL(feedURL)
+	}
+	r.Summarizer.OllamaURL = redactURL(c.Summarizer.OllamaURL)
+	r.Subscriptions.SecretKey = redactSecret(c.Subscriptions.SecretKey)
+	r.WebSub.CallbackURL = redactURL(c.WebSub.CallbackURL)
+	return r
+}
+
+// redactSecret masks a secret but keeps a short fingerprint of it, so a
+// reload can still report that it changed.
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(secret))
+	return redactedValue + " (sha256:" + hex.EncodeToString(sum[:4]) + ")"
+}
+
+// redactURL masks the password (or lone username) in a URL's userinfo.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	if _, hasPassword := u.User.Password(); hasPassword {
+		u.User = url.UserPassword(u.User.Username(), redactedValue)
+	} else {
+		u.User = url.User(redactedValue)
+	}
+	return u.String()
+}
+
+// =============================================================================
+// DURATION - Human-readable durations in JSON and YAML
+// =============================================================================
+
+// Duration is a time.Duration written as a string such as "15s" or "2m"
+// in config files, environment variables and flags.
+type Duration time.Duration
+
+// String formats the duration like time.Duration.
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// Set parses s as a duration. It also satisfies flag.Value.
+func (d *Duration) Set(s string) error {
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q (use a value like 15s or 2m)", s)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, which both
+// encoding/json and yaml.v3 use for string values.
+func (d *Duration) UnmarshalText(text []byte) error {
+	return d.Set(string(text))
+}
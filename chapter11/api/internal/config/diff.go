@@ -0,0 +1,146 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// =============================================================================
+// DIFF - Describing what a configuration reload changes
+// =============================================================================
+
+// Change describes one setting that differs between two configurations.
+type Change struct {
+	Key             string
+	From            string // Empty when a feed was added
+	To              string // Empty when a feed was removed
+	RestartRequired bool   // The running server cannot apply this change
+}
+
+// String formats the change for a reload summary.
+func (c Change) String() string {
+	var s string
+	switch {
+	case c.From == "":
+		s = fmt.Sprintf("%s: + %s", c.Key, c.To)
+	case c.To == "":
+		s = fmt.Sprintf("%s: - %s", c.Key, c.From)
+	default:
+		s = fmt.Sprintf("%s: %s -> %s", c.Key, c.From, c.To)
+	}
+	if c.RestartRequired {
+		s += " (requires restart)"
+	}
+	return s
+}
+
+// setting is one flattened config value used for diffing.
+type setting struct {
+	key        string
+	value      string
+	reloadable bool
+}
+
+// settings flattens the scalar values of c, redacted, in a stable order.
+// Add new fields here so reloads report them.
+func (c Config) settings() []setting {
+	r := c.Redacted()
+	return []setting{
+		{"server.host", r.Server.Host, false},
+		{"server.port", strconv.Itoa(r.Server.Port), false},
+		{"server.read_timeout", r.Server.ReadTimeout.String(), false},
+		{"server.write_timeout", r.Server.WriteTimeout.String(), false},
+		{"server.idle_timeout", r.Server.IdleTimeout.String(), false},
+		{"server.shutdown_timeout", r.Server.ShutdownTimeout.String(), true},
+		{"server.rate_limit.requests_per_second", strconv.FormatFloat(r.Server.RateLimit.RequestsPerSecond, 'g', -1, 64), true},
+		{"server.rate_limit.burst", strconv.Itoa(r.Server.RateLimit.Burst), true},
+		{"fetch.timeout", r.Fetch.Timeout.String(), false},
+		{"fetch.interval", r.Fetch.Interval.String(), true},
+		{"fetch.max_body_bytes", strconv.Itoa(r.Fetch.MaxBodyBytes), false},
+		{"fetch.workers", strconv.Itoa(r.Fetch.Workers), false},
+		{"fetch.per_host", strconv.Itoa(r.Fetch.PerHost), false},
+		{"fetch.startup_deadline", r.Fetch.StartupDeadline.String(), false},
+		{"fetch.max_attempts", strconv.Itoa(r.Fetch.MaxAttempts), false},
+		{"fetch.breaker_threshold", strconv.Itoa(r.Fetch.BreakerThreshold), false},
+		{"fetch.breaker_cooldown", r.Fetch.BreakerCooldown.String(), false},
+		{"fetch.respect_robots", strconv.FormatBool(r.Fetch.RespectRobots), false},
+		{"fetch.min_host_interval", r.Fetch.MinHostInterval.String(), false},
+		{"fetch.fixtures.mode", r.Fetch.Fixtures.Mode, false},
+		{"fetch.fixtures.dir", r.Fetch.Fixtures.Dir, false},
+		{"summarizer.stub", strconv.FormatBool(r.Summarizer.Stub), false},
+		{"summarizer.ollama_url", r.Summarizer.OllamaURL, false},
+		{"summarizer.model", r.Summarizer.Model, false},
+		{"summarizer.max_tokens", strconv.Itoa(r.Summarizer.MaxTokens), false},
+		{"subscriptions.state_file", r.Subscriptions.StateFile, false},
+		{"subscriptions.secret_key", r.Subscriptions.SecretKey, false},
+		{"webhooks.state_file", r.Webhooks.StateFile, false},
+		{"webhooks.max_attempts", strconv.Itoa(r.Webhooks.MaxAttempts), false},
+		{"webhooks.initial_backoff", r.Webhooks.InitialBackoff.String(), false},
+		{"webhooks.max_backoff", r.Webhooks.MaxBackoff.String(), false},
+		{"webhooks.timeout", r.Webhooks.Timeout.String(), false},
+		{"rules.state_file", r.Rules.StateFile, false},
+		{"websub.callback_url", r.WebSub.CallbackURL, false},
+		{"websub.lease", r.WebSub.Lease.String(), false},
+		{"log.level", r.Log.Level, true},
+	}
+}
+
+// Diff lists the changes from old to new. Feeds are compared as a set and
+// reported one addition or removal per entry; other settings are compared
+// by value. Changes the server cannot apply live are flagged.
+func Diff(old, new Config) []Change {
+	var changes []Change
+
+	oldFeeds := make(map[string]bool, len(old.Feeds))
+	for _, f := range old.Feeds {
+		oldFeeds[f] = true
+	}
+	newFeeds := make(map[string]bool, len(new.Feeds))
+	for _, f := range new.Feeds {
+		newFeeds[f] = true
+		if !oldFeeds[f] {
+			changes = append(changes, Change{Key: "feeds", To: redactURL(f)})
+		}
+	}
+	for _, f := range old.Feeds {
+		if !newFeeds[f] {
+			changes = append(changes, Change{Key: "feeds", From: redactURL(f)})
+		}
+	}
+
+	oldSettings, newSettings := old.settings(), new.settings()
+	for i := range oldSettings {
+		o, n := oldSettings[i], newSettings[i]
+		if o.value != n.value {
+			changes = append(changes, Change{
+				Key:             o.key,
+				From:            quoteEmpty(o.value),
+				To:              quoteEmpty(n.value),
+				RestartRequired: !o.reloadable,
+			})
+		}
+	}
+
+	return changes
+}
+
+// Reloaded returns c with the settings a running server applies live taken
+// from next: feeds, the polling interval, rate limit, shutdown timeout and
+// log level, the settings flagged reloadable above. The others keep c's
+// values, so a later Diff still reports a change awaiting a restart.
+func (c Config) Reloaded(next Config) Config {
+	c.Feeds = next.Feeds
+	c.Fetch.Interval = next.Fetch.Interval
+	c.Server.RateLimit = next.Server.RateLimit
+	c.Server.ShutdownTimeout = next.Server.ShutdownTimeout
+	c.Log.Level = next.Log.Level
+	return c
+}
+
+// quoteEmpty keeps empty values visible in the summary.
+func quoteEmpty(s string) string {
+	if s == "" {
+		return `""`
+	}
+	return s
+}
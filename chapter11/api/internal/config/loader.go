@@ -0,0 +1,157 @@
+package config
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/fixture"
+)
+
+// =============================================================================
+// LOADER - Layering defaults, file, environment and flags
+// =============================================================================
+
+// flagOverrides are the command-line flags that override config values.
+// Each flag is only applied when it is given explicitly, so an unset flag
+// never clobbers a value from the file or environment.
+var flagOverrides = []struct {
+	name  string
+	usage string
+	apply func(cfg *Config, value string) error
+}{
+	{"host", "interface to listen on", func(c *Config, v string) error { c.Server.Host = v; return nil }},
+	{"port", "port to listen on", func(c *Config, v string) error { return setInt(&c.Server.Port, v) }},
+	{"read-timeout", "HTTP server read timeout", func(c *Config, v string) error { return c.Server.ReadTimeout.Set(v) }},
+	{"write-timeout", "HTTP server write timeout", func(c *Config, v string) error { return c.Server.WriteTimeout.Set(v) }},
+	{"idle-timeout", "HTTP server idle timeout", func(c *Config, v string) error { return c.Server.IdleTimeout.Set(v) }},
+	{"shutdown-timeout", "graceful shutdown timeout", func(c *Config, v string) error { return c.Server.ShutdownTimeout.Set(v) }},
+	{"rate-limit", "requests per second per client (0 disables)", func(c *Config, v string) error { return setFloat(&c.Server.RateLimit.RequestsPerSecond, v) }},
+	{"rate-burst", "request burst size per client", func(c *Config, v string) error { return setInt(&c.Server.RateLimit.Burst, v) }},
+	{"feeds", "comma-separated feed URLs", func(c *Config, v string) error { c.Feeds = splitList(v); return nil }},
+	{"fetch-timeout", "timeout for a single feed fetch", func(c *Config, v string) error { return c.Fetch.Timeout.Set(v) }},
+	{"fetch-interval", "time between polls of each feed", func(c *Config, v string) error { return c.Fetch.Interval.Set(v) }},
+	{"fetch-max-body-bytes", "largest feed body accepted, after decompression", func(c *Config, v string) error { return setInt(&c.Fetch.MaxBodyBytes, v) }},
+	{"fetch-workers", "feeds fetched at once", func(c *Config, v string) error { return setInt(&c.Fetch.Workers, v) }},
+	{"fetch-per-host", "feeds fetched at once from one host", func(c *Config, v string) error { return setInt(&c.Fetch.PerHost, v) }},
+	{"fetch-startup-deadline", "time allowed for the initial fetch of all feeds", func(c *Config, v string) error { return c.Fetch.StartupDeadline.Set(v) }},
+	{"fetch-max-attempts", "attempts per fetch for timeouts, 5xx and 429", func(c *Config, v string) error { return setInt(&c.Fetch.MaxAttempts, v) }},
+	{"breaker-threshold", "consecutive failures before a feed backs off (0 disables)", func(c *Config, v string) error { return setInt(&c.Fetch.BreakerThreshold, v) }},
+	{"breaker-cooldown", "first backoff period for a failing feed", func(c *Config, v string) error { return c.Fetch.BreakerCooldown.Set(v) }},
+	{"offline", "replay feeds from this fixture directory instead of the network", func(c *Config, v string) error {
+		c.Fetch.Fixtures = FixturesConfig{Mode: string(fixture.ModeReplay), Dir: v}
+		return nil
+	}},
+	{"record", "fetch feeds normally and record the responses to this fixture directory", func(c *Config, v string) error {
+		c.Fetch.Fixtures = FixturesConfig{Mode: string(fixture.ModeRecord), Dir: v}
+		return nil
+	}},
+	{"fetch-min-host-interval", "minimum time between requests to one host (0 disables)", func(c *Config, v string) error { return c.Fetch.MinHostInterval.Set(v) }},
+	{"ollama-url", "Ollama server URL", func(c *Config, v string) error { c.Summarizer.OllamaURL = v; return nil }},
+	{"ollama-model", "Ollama model name", func(c *Config, v string) error { c.Summarizer.Model = v; return nil }},
+	{"max-tokens", "maximum tokens in a news report", func(c *Config, v string) error { return setInt(&c.Summarizer.MaxTokens, v) }},
+	{"subscriptions-state-file", "file persisting feeds added through the API", func(c *Config, v string) error { c.Subscriptions.StateFile = v; return nil }},
+	{"webhook-state-file", "file persisting webhooks and their retry queue", func(c *Config, v string) error { c.Webhooks.StateFile = v; return nil }},
+	{"rules-state-file", "file persisting ingest rules", func(c *Config, v string) error { c.Rules.StateFile = v; return nil }},
+	{"websub-callback-url", "public URL of /websub/callback (enables WebSub)", func(c *Config, v string) error { c.WebSub.CallbackURL = v; return nil }},
+	{"log-level", "log level: debug, info, warn or error", func(c *Config, v string) error { c.Log.Level = v; return nil }},
+}
+
+// Loader builds the effective configuration from all sources.
+// It is created once from the command line and can be asked to Load
+// repeatedly, which re-reads the file and environment each time.
+type Loader struct {
+	path        string
+	printConfig bool
+	lookupEnv   func(string) (string, bool)
+	overrides   []func(cfg *Config) error
+}
+
+// NewLoader parses command-line args. lookupEnv is usually os.LookupEnv.
+// The config file path comes from -config, falling back to NEWS_CONFIG.
+func NewLoader(args []string, lookupEnv func(string) (string, bool)) (*Loader, error) {
+	l := &Loader{lookupEnv: lookupEnv}
+
+	fs := flag.NewFlagSet("api", flag.ContinueOnError)
+	fs.StringVar(&l.path, "config", "", "path to a JSON or YAML config file (env NEWS_CONFIG)")
+	fs.BoolVar(&l.printConfig, "print-config", false, "print the effective config with secrets redacted and exit")
+	fs.BoolFunc("summarizer-stub", "always use the stub summarizer", func(v string) error {
+		l.overrides = append(l.overrides, func(c *Config) error { return setBool(&c.Summarizer.Stub, v) })
+		return nil
+	})
+	fs.BoolFunc("respect-robots", "honour robots.txt and Crawl-delay (default true)", func(v string) error {
+		l.overrides = append(l.overrides, func(c *Config) error { return setBool(&c.Fetch.RespectRobots, v) })
+		return nil
+	})
+
+	for _, override := range flagOverrides {
+		fs.Func(override.name, override.usage, func(v string) error {
+			// Apply to a scratch config so bad values fail at parse time
+			var scratch Config
+			if err := override.apply(&scratch, v); err != nil {
+				return err
+			}
+			l.overrides = append(l.overrides, func(c *Config) error { return override.apply(c, v) })
+			return nil
+		})
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	if l.path == "" {
+		l.path, _ = lookupEnv("NEWS_CONFIG")
+	}
+
+	return l, nil
+}
+
+// Path returns the config file path, or "" if none was given.
+func (l *Loader) Path() string {
+	return l.path
+}
+
+// PrintConfig reports whether -print-config was given.
+func (l *Loader) PrintConfig() bool {
+	return l.printConfig
+}
+
+// Load returns the validated configuration: defaults, then the config
+// file, then environment variables, then flags.
+func (l *Loader) Load() (Config, error) {
+	cfg := Default()
+
+	if l.path != "" {
+		if err := LoadFile(l.path, &cfg); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if err := ApplyEnv(&cfg, l.lookupEnv); err != nil {
+		return Config{}, fmt.Errorf("invalid environment: %w", err)
+	}
+
+	for _, override := range l.overrides {
+		if err := override(&cfg); err != nil {
+			return Config{}, fmt.Errorf("invalid flag: %w", err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	return cfg, nil
+}
+
+// Print writes the redacted configuration as indented JSON.
+func Print(w io.Writer, cfg Config) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(cfg.Redacted())
+}
@@ -0,0 +1,165 @@
+// Package dto defines the JSON bodies of the versioned API. Its types are
+// the API's contract: field names are snake_case and dates RFC 3339, and
+// they change only with the API version, whatever happens to the domain
+// types they are converted from.
+package dto
+
+import (
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/story"
+	"github.com/YOUR_USERNAME/go-news/api/internal/trends"
+)
+
+// =============================================================================
+// ARTICLES - Articles, stories, trends and summaries
+// =============================================================================
+
+// Article is one stored article.
+type Article struct {
+	ID                 string     `json:"id"` // Derived from the link, or the feed and title without one; stable across restarts
+	Title              string     `json:"title"`
+	Description        string     `json:"description"`
+	Link               string     `json:"link"`
+	Published          *time.Time `json:"published,omitempty"`
+	PublishedEstimated bool       `json:"published_estimated,omitempty"` // Published is when it was first seen
+	FeedTitle          string     `json:"feed_title"`
+	Tags               []string   `json:"tags,omitempty"`
+	Language           string     `json:"language,omitempty"` // ISO 639-1 code, if detected
+	Starred            bool       `json:"starred"`
+	Read               bool       `json:"read"`
+}
+
+// ArticlePage is a page of articles, newest first.
+type ArticlePage struct {
+	Articles   []Article `json:"articles"`
+	NextCursor string    `json:"next_cursor,omitempty"` // Pass as ?cursor= for the next page; absent on the last
+}
+
+// Story is the articles several feeds published about the same news.
+type Story struct {
+	ID       string     `json:"id"`
+	Article  Article    `json:"article"`           // The representative: the first to be published
+	Siblings []Article  `json:"siblings"`          // The other articles, newest first
+	Feeds    []string   `json:"feeds"`             // Titles of the feeds covering the story, sorted
+	Updated  *time.Time `json:"updated,omitempty"` // When the newest article was published
+}
+
+// TrendReport is the terms trending in one window.
+type TrendReport struct {
+	Window           string    `json:"window"` // A Go duration, such as 24h0m0s
+	From             time.Time `json:"from"`
+	To               time.Time `json:"to"`
+	Articles         int       `json:"articles"`          // Articles published in the window
+	BaselineArticles int       `json:"baseline_articles"` // Articles published in the baseline before it
+	Trends           []Trend   `json:"trends"`
+}
+
+// Trend is a term mentioned markedly more in the window than before.
+type Trend struct {
+	Term             string    `json:"term"`
+	Articles         int       `json:"articles"`
+	BaselineArticles int       `json:"baseline_articles"`
+	Burst            float64   `json:"burst"`
+	Score            float64   `json:"score"`
+	Supporting       []Article `json:"supporting"` // Newest first
+}
+
+// Summary is a news report about recent articles.
+type Summary struct {
+	ArticleCount int      `json:"article_count"`
+	Summary      string   `json:"summary"`
+	Trends       []string `json:"trends,omitempty"` // The trending terms reported on, with focus=trends
+}
+
+// FromArticle converts a stored article.
+func FromArticle(a *feed.Article) Article {
+	return Article{
+		ID:                 feed.ArticleID(a),
+		Title:              a.Title,
+		Description:        a.Description,
+		Link:               a.Link,
+		Published:          utc(a.Published),
+		PublishedEstimated: a.PublishedEstimated,
+		FeedTitle:          a.FeedTitle,
+		Tags:               a.Tags,
+		Language:           a.Language,
+		Starred:            a.Starred,
+		Read:               a.Read,
+	}
+}
+
+// FromArticles converts stored articles, returning an empty slice rather
+// than nil so lists encode as [].
+func FromArticles(articles []*feed.Article) []Article {
+	result := make([]Article, len(articles))
+	for i, a := range articles {
+		result[i] = FromArticle(a)
+	}
+	return result
+}
+
+// FromStories converts stories.
+func FromStories(stories []story.Story) []Story {
+	result := make([]Story, len(stories))
+	for i, s := range stories {
+		result[i] = Story{
+			ID:       s.ID,
+			Article:  FromArticle(s.Article),
+			Siblings: FromArticles(s.Siblings),
+			Feeds:    nonNil(s.Feeds),
+			Updated:  utc(s.Updated),
+		}
+	}
+	return result
+}
+
+// FromTrendReport converts a trend report.
+func FromTrendReport(r trends.Report) TrendReport {
+	report := TrendReport{
+		Window:           r.Window,
+		From:             r.From.UTC(),
+		To:               r.To.UTC(),
+		Articles:         r.Articles,
+		BaselineArticles: r.BaselineArticles,
+		Trends:           make([]Trend, len(r.Trends)),
+	}
+	for i, t := range r.Trends {
+		report.Trends[i] = Trend{
+			Term:             t.Term,
+			Articles:         t.Articles,
+			BaselineArticles: t.BaselineArticles,
+			Burst:            t.Burst,
+			Score:            t.Score,
+			Supporting:       FromArticles(t.Supporting),
+		}
+	}
+	return report
+}
+
+// utc returns t in UTC, or nil.
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
+// nonNil returns s, or an empty slice if it is nil.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+// Why DTOs separate from the domain types:
+//
+// - feed.Article has no JSON tags, so its Go field names were the wire
+//   format; renaming a field for the code's sake broke every client.
+// - Conversions are the one place the two meet, so domain types can grow
+//   fields, such as fingerprints, without them leaking into responses.
+// - The OpenAPI document is generated from these types, so what it
+//   promises is exactly what handlers encode.
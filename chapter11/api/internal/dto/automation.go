@@ -0,0 +1,193 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/rules"
+	"github.com/YOUR_USERNAME/go-news/api/internal/webhook"
+)
+
+// =============================================================================
+// AUTOMATION - Ingest rules and webhooks
+// =============================================================================
+
+// Rule applies its actions to every incoming article it matches.
+type Rule struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Match     RuleMatch   `json:"match"`
+	Actions   RuleActions `json:"actions"`
+	Disabled  bool        `json:"disabled,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// RuleMatch selects articles. Each non-empty criterion must match; text
+// comparisons ignore case. At least one criterion is required.
+type RuleMatch struct {
+	Title       string `json:"title,omitempty"`       // Substring of the title
+	Description string `json:"description,omitempty"` // Substring of the description
+	Feed        string `json:"feed,omitempty"`        // Feed title, or the URL the feed is fetched from
+	Host        string `json:"host,omitempty"`        // Host of the article link, or a parent domain of it
+	Regex       string `json:"regex,omitempty"`       // Go regular expression over title and description
+}
+
+// RuleActions are applied to matching articles. Drop discards the article
+// before it is stored, so the other actions only matter without it.
+type RuleActions struct {
+	Drop     bool     `json:"drop,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Star     bool     `json:"star,omitempty"`
+	MarkRead bool     `json:"mark_read,omitempty"`
+}
+
+// RuleRequest is the body accepted when creating, updating or trying a
+// rule. IDs and timestamps are assigned by the server.
+type RuleRequest struct {
+	Name     string      `json:"name"`
+	Match    RuleMatch   `json:"match"`
+	Actions  RuleActions `json:"actions"`
+	Disabled bool        `json:"disabled,omitempty"`
+}
+
+// DryRunResult lists the stored articles a rule would have affected.
+type DryRunResult struct {
+	Scanned  int          `json:"scanned"`
+	Matched  int          `json:"matched"`
+	Articles []RuleEffect `json:"articles"`
+}
+
+// RuleEffect is what a rule would have done to one stored article.
+type RuleEffect struct {
+	Article  Article  `json:"article"`
+	Drop     bool     `json:"drop,omitempty"`
+	AddTags  []string `json:"add_tags,omitempty"` // Tags the article doesn't already have
+	Star     bool     `json:"star,omitempty"`
+	MarkRead bool     `json:"mark_read,omitempty"`
+}
+
+// Webhook is a receiver new articles are posted to.
+type Webhook struct {
+	ID        string        `json:"id"`
+	URL       string        `json:"url"`
+	Secret    string        `json:"secret"` // HMAC-SHA256 signing key; REDACTED except when created
+	Filter    WebhookFilter `json:"filter"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// WebhookFilter limits the articles sent to a webhook. Each non-empty
+// list must have a match; the zero value sends every article.
+type WebhookFilter struct {
+	Feeds    []string `json:"feeds,omitempty"`    // Feed titles, case-insensitive
+	Keywords []string `json:"keywords,omitempty"` // Substrings of title or description
+	Tags     []string `json:"tags,omitempty"`     // Article tags
+}
+
+// WebhookRequest is the body of POST /v1/webhooks. Secret is optional;
+// one is generated when omitted.
+type WebhookRequest struct {
+	URL    string        `json:"url"`
+	Secret string        `json:"secret,omitempty"`
+	Filter WebhookFilter `json:"filter"`
+}
+
+// DeliveryAttempt is one attempt to deliver a batch to a webhook.
+type DeliveryAttempt struct {
+	DeliveryID  string     `json:"delivery_id"`
+	Attempt     int        `json:"attempt"`
+	Time        time.Time  `json:"time"`
+	StatusCode  int        `json:"status_code,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	Duration    int64      `json:"duration_ns"`
+	Outcome     string     `json:"outcome"`
+	NextAttempt *time.Time `json:"next_attempt,omitempty"`
+}
+
+// FromRule converts a rule.
+func FromRule(r rules.Rule) Rule {
+	return Rule{
+		ID:        r.ID,
+		Name:      r.Name,
+		Match:     RuleMatch(r.Match),
+		Actions:   RuleActions(r.Actions),
+		Disabled:  r.Disabled,
+		CreatedAt: r.CreatedAt.UTC(),
+	}
+}
+
+// FromRules converts rules.
+func FromRules(rs []rules.Rule) []Rule {
+	result := make([]Rule, len(rs))
+	for i, r := range rs {
+		result[i] = FromRule(r)
+	}
+	return result
+}
+
+// Rule returns the rule r describes, without an ID.
+func (r RuleRequest) Rule() rules.Rule {
+	return rules.Rule{
+		Name:     r.Name,
+		Match:    rules.Match(r.Match),
+		Actions:  rules.Actions(r.Actions),
+		Disabled: r.Disabled,
+	}
+}
+
+// FromDryRun converts a dry run's result.
+func FromDryRun(r rules.DryRunResult) DryRunResult {
+	result := DryRunResult{Scanned: r.Scanned, Matched: r.Matched, Articles: make([]RuleEffect, len(r.Articles))}
+	for i, e := range r.Articles {
+		result.Articles[i] = RuleEffect{
+			Article:  FromArticle(e.Article),
+			Drop:     e.Drop,
+			AddTags:  e.AddTags,
+			Star:     e.Star,
+			MarkRead: e.MarkRead,
+		}
+	}
+	return result
+}
+
+// FromWebhook converts a webhook; its secret is shown as the registry
+// gives it.
+func FromWebhook(h webhook.Hook) Webhook {
+	return Webhook{
+		ID:        h.ID,
+		URL:       h.URL,
+		Secret:    h.Secret,
+		Filter:    WebhookFilter(h.Filter),
+		CreatedAt: h.CreatedAt.UTC(),
+	}
+}
+
+// FromWebhooks converts webhooks.
+func FromWebhooks(hooks []webhook.Hook) []Webhook {
+	result := make([]Webhook, len(hooks))
+	for i, h := range hooks {
+		result[i] = FromWebhook(h)
+	}
+	return result
+}
+
+// Hook returns the webhook r asks for, without an ID.
+func (r WebhookRequest) Hook() webhook.Hook {
+	return webhook.Hook{URL: r.URL, Secret: r.Secret, Filter: webhook.Filter(r.Filter)}
+}
+
+// FromAttempts converts a webhook's delivery log.
+func FromAttempts(attempts []webhook.Attempt) []DeliveryAttempt {
+	result := make([]DeliveryAttempt, len(attempts))
+	for i, a := range attempts {
+		result[i] = DeliveryAttempt{
+			DeliveryID:  a.DeliveryID,
+			Attempt:     a.Attempt,
+			Time:        a.Time.UTC(),
+			StatusCode:  a.StatusCode,
+			Error:       a.Error,
+			Duration:    a.Duration.Nanoseconds(),
+			Outcome:     a.Outcome,
+			NextAttempt: utc(a.NextAttempt),
+		}
+	}
+	return result
+}
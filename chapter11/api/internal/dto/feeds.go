@@ -0,0 +1,156 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/subscription"
+	"github.com/YOUR_USERNAME/go-news/api/internal/websub"
+)
+
+// =============================================================================
+// FEEDS - Subscriptions, discovery and fetch health
+// =============================================================================
+
+// FeedStatus is a polled feed and its fetch health.
+type FeedStatus struct {
+	ID                  string     `json:"id"` // The subscription's, kept when the feed moves
+	URL                 string     `json:"url"`
+	State               string     `json:"state"` // pending, ok, failing, backing_off or dead
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastAttempt         *time.Time `json:"last_attempt,omitempty"`
+	LastSuccess         *time.Time `json:"last_success,omitempty"`
+	NextAttempt         *time.Time `json:"next_attempt,omitempty"`
+	UnparsedDates       int        `json:"unparsed_dates,omitempty"`        // Articles dated when first seen instead
+	UnparsedDateExample string     `json:"unparsed_date_example,omitempty"` // A date the feed sent that couldn't be parsed
+}
+
+// Candidate is a feed found at a web page.
+type Candidate struct {
+	URL    string `json:"url"`
+	Title  string `json:"title,omitempty"`
+	Type   string `json:"type"`   // Media type, e.g. application/atom+xml
+	Source string `json:"source"` // How it was found: direct, link or probe
+}
+
+// Subscription is a feed the server polls. Credentials in its settings
+// are redacted.
+type Subscription struct {
+	ID           string         `json:"id"`
+	URL          string         `json:"url"`
+	Title        string         `json:"title,omitempty"`
+	Source       string         `json:"source"`                  // config or api
+	RequestedURL string         `json:"requested_url,omitempty"` // What was asked for, if discovery chose another URL
+	MovedFrom    string         `json:"moved_from,omitempty"`    // The URL subscribed to, if the feed has moved since
+	Dead         bool           `json:"dead,omitempty"`          // Answered 410 Gone; no longer polled
+	CreatedAt    time.Time      `json:"created_at"`
+	Settings     *FetchSettings `json:"settings,omitempty"`
+}
+
+// FetchSettings are how one feed is fetched.
+type FetchSettings struct {
+	Auth     *Auth             `json:"auth,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`   // Extra request headers, e.g. User-Agent
+	CABundle string            `json:"ca_bundle,omitempty"` // PEM certificates trusted in addition to the system roots
+	Proxy    string            `json:"proxy,omitempty"`     // http, https or socks5 proxy URL
+}
+
+// Auth is HTTP basic or bearer authentication.
+type Auth struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty"`
+}
+
+// SubscribeRequest is the body of POST /v1/feeds. URL may be a feed or a
+// web page that links to one.
+type SubscribeRequest struct {
+	URL      string         `json:"url"`
+	Settings *FetchSettings `json:"settings,omitempty"`
+}
+
+// SubscribeResponse is a new subscription and every feed found at the
+// URL, the first of which was subscribed to.
+type SubscribeResponse struct {
+	Subscription
+	Candidates []Candidate `json:"candidates"`
+}
+
+// PushSubscription is a WebSub subscription to a feed's hub.
+type PushSubscription struct {
+	ID           string     `json:"id"`
+	FeedURL      string     `json:"feed_url"`
+	Topic        string     `json:"topic"`
+	Hub          string     `json:"hub"`
+	State        string     `json:"state"`
+	LeaseExpires *time.Time `json:"lease_expires,omitempty"`
+	LastPush     *time.Time `json:"last_push,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+}
+
+// FromCandidates converts discovered feeds.
+func FromCandidates(candidates []feed.Candidate) []Candidate {
+	result := make([]Candidate, len(candidates))
+	for i, c := range candidates {
+		result[i] = Candidate{URL: c.URL, Title: c.Title, Type: c.Type, Source: c.Source}
+	}
+	return result
+}
+
+// FromSubscription converts a subscription, redacting its credentials.
+func FromSubscription(s subscription.Subscription) Subscription {
+	s = s.Redacted()
+	return Subscription{
+		ID:           s.ID,
+		URL:          s.URL,
+		Title:        s.Title,
+		Source:       s.Source,
+		RequestedURL: s.RequestedURL,
+		MovedFrom:    s.MovedFrom,
+		Dead:         s.Dead,
+		CreatedAt:    s.CreatedAt.UTC(),
+		Settings:     fromFetchSettings(s.Settings),
+	}
+}
+
+// FromPushSubscriptions converts WebSub subscriptions.
+func FromPushSubscriptions(subs []websub.Subscription) []PushSubscription {
+	result := make([]PushSubscription, len(subs))
+	for i, s := range subs {
+		result[i] = PushSubscription{
+			ID:           s.ID,
+			FeedURL:      s.FeedURL,
+			Topic:        s.Topic,
+			Hub:          s.Hub,
+			State:        s.State,
+			LeaseExpires: utc(s.LeaseExpires),
+			LastPush:     utc(s.LastPush),
+			LastError:    s.LastError,
+		}
+	}
+	return result
+}
+
+// Settings returns s as fetch settings; nil stays nil, for the defaults.
+func (s *FetchSettings) Settings() *feed.FetchSettings {
+	if s == nil {
+		return nil
+	}
+	settings := &feed.FetchSettings{Headers: s.Headers, CABundle: s.CABundle, Proxy: s.Proxy}
+	if s.Auth != nil {
+		settings.Auth = &feed.Auth{Username: s.Auth.Username, Password: s.Auth.Password, Token: s.Auth.Token}
+	}
+	return settings
+}
+
+func fromFetchSettings(s *feed.FetchSettings) *FetchSettings {
+	if s == nil {
+		return nil
+	}
+	settings := &FetchSettings{Headers: s.Headers, CABundle: s.CABundle, Proxy: s.Proxy}
+	if s.Auth != nil {
+		settings.Auth = &Auth{Username: s.Auth.Username, Password: s.Auth.Password, Token: s.Auth.Token}
+	}
+	return settings
+}
@@ -0,0 +1,16 @@
+package dto
+
+// =============================================================================
+// PROBLEMS - Error responses
+// =============================================================================
+
+// Problem is an error response, an RFC 9457 problem details object served
+// as application/problem+json.
+type Problem struct {
+	Type      string `json:"type"`                 // Identifies the kind of problem; branch on this, not on Title
+	Title     string `json:"title"`                // The same for every problem of a type
+	Status    int    `json:"status"`               // The HTTP status, repeated
+	Detail    string `json:"detail,omitempty"`     // What went wrong with this request
+	Instance  string `json:"instance,omitempty"`   // The path that was requested
+	RequestID string `json:"request_id,omitempty"` // Also in the X-Request-ID header; quote it when reporting a problem
+}
@@ -0,0 +1,154 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+)
+
+// =============================================================================
+// EVENT BROKER - Fan-out of newly ingested articles to live subscribers
+// =============================================================================
+
+// Event is one newly ingested article with a sequence number.
+// IDs increase by one per article, so a client that remembers the last ID
+// it saw can ask for everything after it. They start from the broker's
+// creation time, so IDs from an earlier process are lower than any of
+// this one's.
+type Event struct {
+	ID      uint64
+	Article *feed.Article
+}
+
+// idsPerMilli is how many IDs each millisecond before the broker was
+// created is worth. A process that publishes fewer articles than that
+// per millisecond of its life hands out IDs below the next one's, and
+// IDs stay within the integers JavaScript represents exactly.
+const idsPerMilli = 1000
+
+// subscriberBuffer is how many events a subscriber can fall behind before
+// it is disconnected and must resume with its last event ID.
+const subscriberBuffer = 64
+
+// Broker fans out published articles to subscribers and keeps a bounded
+// replay buffer of recent events for clients that reconnect.
+type Broker struct {
+	mu          sync.Mutex
+	firstID     uint64 // The ID of this process's first event
+	lastID      uint64
+	replay      []Event // Oldest first, at most replaySize entries
+	replaySize  int
+	subscribers map[chan Event]struct{}
+	closed      bool
+}
+
+// NewBroker creates a broker that remembers the last replaySize events.
+func NewBroker(replaySize int) *Broker {
+	start := uint64(time.Now().UnixMilli()) * idsPerMilli
+	return &Broker{
+		firstID:     start + 1,
+		lastID:      start,
+		replaySize:  replaySize,
+		replay:      make([]Event, 0, replaySize),
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Publish assigns IDs to articles and delivers them to every subscriber.
+// It never blocks: a subscriber whose buffer is full is disconnected.
+// Its signature matches feed.ArticleNotifier subscriptions.
+func (b *Broker) Publish(articles []*feed.Article) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	for _, article := range articles {
+		b.lastID++
+		event := Event{ID: b.lastID, Article: article}
+
+		if len(b.replay) == b.replaySize && b.replaySize > 0 {
+			b.replay = append(b.replay[:0], b.replay[1:]...)
+		}
+		if b.replaySize > 0 {
+			b.replay = append(b.replay, event)
+		}
+
+		for ch := range b.subscribers {
+			select {
+			case ch <- event:
+			default:
+				// Too slow: disconnect rather than block ingestion
+				delete(b.subscribers, ch)
+				close(ch)
+			}
+		}
+	}
+}
+
+// Subscribe returns buffered events newer than lastID, followed by a
+// channel of live events. A lastID of 0 means "live events only". An ID
+// this broker didn't hand out, such as one from before a restart, gets
+// the whole buffer, since what the client missed can't be told apart.
+// The channel is closed when the subscriber falls too far behind or the
+// broker closes. Call cancel when done.
+func (b *Broker) Subscribe(lastID uint64) (replay []Event, live <-chan Event, cancel func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if lastID > 0 {
+		unknown := lastID < b.firstID || lastID > b.lastID
+		for _, event := range b.replay {
+			if unknown || event.ID > lastID {
+				replay = append(replay, event)
+			}
+		}
+	}
+
+	ch := make(chan Event, subscriberBuffer)
+	if b.closed {
+		close(ch)
+		return replay, ch, func() {}
+	}
+	b.subscribers[ch] = struct{}{}
+
+	cancel = func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return replay, ch, cancel
+}
+
+// Close disconnects every subscriber. Used during server shutdown so
+// long-lived streams don't hold up graceful termination.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+// Why a broker between storage and HTTP:
+//
+// - Storage only knows "these articles are new"; it shouldn't track
+//   HTTP clients, event IDs or replay history.
+// - Each subscriber gets its own buffered channel, so one slow dashboard
+//   cannot stall ingestion or other clients.
+// - The replay buffer is bounded, trading completeness for predictable
+//   memory: clients gone longer than the buffer covers should reload
+//   /articles instead.
+// - IDs aren't persisted, but start from the clock at startup, so a
+//   client resuming across a restart is recognised and sent everything
+//   buffered rather than nothing or the wrong slice.
@@ -0,0 +1,109 @@
+package events_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/events"
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+)
+
+func articles(titles ...string) []*feed.Article {
+	result := make([]*feed.Article, len(titles))
+	for i, title := range titles {
+		result[i] = &feed.Article{Title: title}
+	}
+	return result
+}
+
+// publish publishes articles with the given titles and returns their IDs.
+func publish(t *testing.T, b *events.Broker, titles ...string) []uint64 {
+	t.Helper()
+	_, live, cancel := b.Subscribe(0)
+	defer cancel()
+	b.Publish(articles(titles...))
+
+	ids := make([]uint64, len(titles))
+	for i := range ids {
+		ids[i] = (<-live).ID
+		if i > 0 && ids[i] != ids[i-1]+1 {
+			t.Fatalf("IDs %v don't increase by one", ids[:i+1])
+		}
+	}
+	return ids
+}
+
+// TestBrokerReplay verifies resume from a bounded replay buffer.
+func TestBrokerReplay(t *testing.T) {
+	b := events.NewBroker(3)
+	ids := publish(t, b, "a", "b", "c", "d", "e") // Buffer keeps c-e
+
+	tests := []struct {
+		name     string
+		lastID   uint64
+		expected []string
+	}{
+		{"live only", 0, nil},
+		{"resume inside buffer", ids[2], []string{"d", "e"}},
+		{"resume before buffer", ids[0], []string{"c", "d", "e"}},
+		{"fully caught up", ids[4], nil},
+		{"from an earlier process", 5, []string{"c", "d", "e"}},
+		{"never handed out", ids[4] + 1, []string{"c", "d", "e"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replay, _, cancel := b.Subscribe(tt.lastID)
+			defer cancel()
+
+			var got []string
+			for _, event := range replay {
+				got = append(got, event.Article.Title)
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("expected %v, got %v", tt.expected, got)
+				}
+			}
+		})
+	}
+}
+
+// TestBrokerIDsIncreaseAcrossRestarts verifies a new broker's IDs follow
+// an earlier one's, so resuming across a restart is recognised.
+func TestBrokerIDsIncreaseAcrossRestarts(t *testing.T) {
+	before := publish(t, events.NewBroker(1), "a", "b")
+	time.Sleep(2 * time.Millisecond)
+	after := publish(t, events.NewBroker(1), "c")
+	if after[0] <= before[1] {
+		t.Errorf("restarted broker's first ID %d, want above the earlier %d", after[0], before[1])
+	}
+	if after[0] > 1<<53 {
+		t.Errorf("ID %d is beyond the integers JavaScript represents exactly", after[0])
+	}
+}
+
+// TestBrokerSlowSubscriber verifies a lagging subscriber is disconnected
+// instead of blocking Publish.
+func TestBrokerSlowSubscriber(t *testing.T) {
+	b := events.NewBroker(0)
+	_, live, cancel := b.Subscribe(0)
+	defer cancel()
+
+	many := make([]*feed.Article, 1000)
+	for i := range many {
+		many[i] = &feed.Article{}
+	}
+	b.Publish(many) // Must not block
+
+	count := 0
+	for range live {
+		count++
+	}
+	if count == 0 || count == len(many) {
+		t.Errorf("expected a partial delivery before disconnect, got %d", count)
+	}
+}
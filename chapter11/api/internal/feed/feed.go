@@ -2,6 +2,13 @@ package feed
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -17,6 +24,46 @@ type Article struct {
 	Link        string
 	Published   *time.Time
 	FeedTitle   string
+	Tags        []string // Labels attached during ingestion, e.g. "security"
+	Language    string   // ISO 639-1 code detected during ingestion; empty if unknown
+	Starred     bool     // Flagged by a rule for attention
+	Read        bool     // Marked read by a rule, e.g. routine announcements
+
+	Fingerprint Fingerprint // SimHash of the normalised title and description, set during ingestion
+
+	RawDate            string // The feed's date for the article when it couldn't be parsed
+	PublishedEstimated bool   // Published is when the article was first seen, not a date from the feed
+}
+
+// Fingerprint is a 64-bit SimHash of an article's text: near-duplicate
+// texts have fingerprints that differ in few bits. Zero means none was
+// computed.
+type Fingerprint uint64
+
+// Distance returns the number of bits in which f and other differ.
+func (f Fingerprint) Distance(other Fingerprint) int {
+	return bits.OnesCount64(uint64(f ^ other))
+}
+
+// String returns f as 16 hex digits.
+func (f Fingerprint) String() string {
+	return fmt.Sprintf("%016x", uint64(f))
+}
+
+// MarshalText encodes f as hex, since JSON numbers lose precision
+// above 2^53.
+func (f Fingerprint) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText decodes the hex form written by MarshalText.
+func (f *Fingerprint) UnmarshalText(data []byte) error {
+	v, err := strconv.ParseUint(string(data), 16, 64)
+	if err != nil {
+		return fmt.Errorf("invalid fingerprint %q", data)
+	}
+	*f = Fingerprint(v)
+	return nil
 }
 
 // Feed represents an RSS/Atom feed with its articles.
@@ -26,6 +73,153 @@ type Feed struct {
 	Description string
 	Link        string
 	Articles    []*Article
+
+	Hub  string // WebSub hub that pushes updates, if the feed advertises one
+	Self string // The feed's own URL as it advertises it; the WebSub topic
+}
+
+// FetchStatus records the outcome of recent fetch attempts for one feed.
+// It lets the rest of the system reason about feed freshness without
+// knowing how fetching is implemented.
+type FetchStatus struct {
+	URL         string
+	LastAttempt time.Time
+	LastSuccess time.Time // Zero if the feed has never been fetched successfully
+	LastError   string    // Empty if the last attempt succeeded
+
+	ConsecutiveFailures int       // Failed fetches since the last success
+	RetryAt             time.Time // While in the future, fetches are skipped; zero if not backing off
+
+	Gone bool // The publisher answered 410 Gone; the feed is no longer polled
+
+	UnparsedDates       int    // Articles in the last fetch whose dates couldn't be parsed
+	UnparsedDateExample string // One of those dates, to show what the feed sends
+}
+
+// Candidate is a feed found by autodiscovery for a website URL.
+type Candidate struct {
+	URL    string `json:"url"`
+	Title  string `json:"title,omitempty"`
+	Type   string `json:"type"`   // Media type, e.g. application/atom+xml
+	Source string `json:"source"` // How it was found: direct, link or probe
+}
+
+// FetchSettings customise how one feed is requested, for feeds behind
+// authentication, picky hosts or private networks. The zero value fetches
+// with the reader's defaults.
+type FetchSettings struct {
+	Auth     *Auth             `json:"auth,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`   // Extra request headers, e.g. User-Agent
+	CABundle string            `json:"ca_bundle,omitempty"` // PEM certificates trusted in addition to the system roots
+	Proxy    string            `json:"proxy,omitempty"`     // http, https or socks5 proxy URL
+}
+
+// Auth holds credentials for a feed: either a username and password for
+// HTTP basic auth, or a bearer token.
+type Auth struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty"`
+}
+
+// redactedValue replaces secrets in API responses and logs.
+const redactedValue = "REDACTED"
+
+// IsZero reports whether s leaves every default in place.
+func (s FetchSettings) IsZero() bool {
+	return s.Auth == nil && len(s.Headers) == 0 && s.CABundle == "" && s.Proxy == ""
+}
+
+// HasSecrets reports whether s contains credentials: auth, a sensitive
+// header, or a proxy URL with a password.
+func (s FetchSettings) HasSecrets() bool {
+	return s.Redacted().differs(s)
+}
+
+// Redacted returns a copy of s that is safe to log or return from the API.
+// Passwords, tokens and sensitive header values are masked; usernames and
+// the CA bundle are not secret.
+func (s FetchSettings) Redacted() FetchSettings {
+	r := s
+	if s.Auth != nil {
+		auth := *s.Auth
+		if auth.Password != "" {
+			auth.Password = redactedValue
+		}
+		if auth.Token != "" {
+			auth.Token = redactedValue
+		}
+		r.Auth = &auth
+	}
+	if len(s.Headers) > 0 {
+		r.Headers = make(map[string]string, len(s.Headers))
+		for name, value := range s.Headers {
+			if sensitiveHeader(name) {
+				value = redactedValue
+			}
+			r.Headers[name] = value
+		}
+	}
+	if u, err := url.Parse(s.Proxy); err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redactedValue)
+			r.Proxy = u.String()
+		}
+	}
+	return r
+}
+
+// differs reports whether redaction changed anything in s.
+func (s FetchSettings) differs(original FetchSettings) bool {
+	if s.Proxy != original.Proxy {
+		return true
+	}
+	if s.Auth != nil && *s.Auth != *original.Auth {
+		return true
+	}
+	for name, value := range s.Headers {
+		if original.Headers[name] != value {
+			return true
+		}
+	}
+	return false
+}
+
+// sensitiveHeader reports whether a header's value is likely a credential.
+func sensitiveHeader(name string) bool {
+	name = strings.ToLower(name)
+	switch name {
+	case "authorization", "proxy-authorization", "cookie":
+		return true
+	}
+	for _, word := range []string{"token", "key", "secret", "auth", "password", "session"} {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// ID returns the stable identifier of the feed at url, used in API paths.
+// It is derived from the URL so it needs no storage and survives restarts.
+func ID(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:8])
+}
+
+// ArticleKey identifies an article for deduplication: its link, or its
+// feed and title when the feed doesn't provide links.
+func ArticleKey(article *Article) string {
+	if article.Link != "" {
+		return article.Link
+	}
+	return article.FeedTitle + "\x00" + article.Title
+}
+
+// ArticleID returns the stable identifier of an article, used in API
+// paths and cursors. Like ID, it survives restarts.
+func ArticleID(article *Article) string {
+	return ID(ArticleKey(article))
 }
 
 // =============================================================================
@@ -39,6 +233,36 @@ type Fetcher interface {
 	FetchFeed(ctx context.Context, url string) (*Feed, error)
 }
 
+// Backoff is implemented by fetchers that ask to skip a feed until a
+// given time, for example while its circuit breaker is open. Schedulers
+// check it to avoid polling feeds that would be rejected anyway.
+type Backoff interface {
+	RetryAt(url string) time.Time
+}
+
+// Watcher is told about permanent changes to a feed that a fetcher
+// discovers, so subscriptions can follow them.
+type Watcher interface {
+	// Moved is called when the feed at from has permanently moved to to.
+	Moved(from, to string)
+	// Gone is called when the feed at url has been permanently removed.
+	Gone(url string)
+}
+
+// HubWatcher is told, after each successful fetch, which WebSub hub a feed
+// advertises and under which topic URL. hub is empty if it advertises none.
+type HubWatcher interface {
+	HubAdvertised(url, hub, topic string)
+}
+
+// ArticleProcessor prepares a fetched feed's articles for storage, for
+// example by cleaning, annotating or dropping them. It returns the
+// articles to store; an error describes the articles that failed, which
+// are still returned unless a processor dropped them.
+type ArticleProcessor interface {
+	ProcessArticles(ctx context.Context, feedURL string, articles []*Article) ([]*Article, error)
+}
+
 // Storage defines how articles are persisted.
 // Like Fetcher, this is an abstraction that can be satisfied by
 // in-memory storage, databases, or any other implementation.
@@ -47,6 +271,12 @@ type Storage interface {
 	GetRecent(n int) []*Article
 }
 
+// ArticleNotifier is implemented by storage that can announce new articles.
+// Subscribers are called only with articles that weren't stored before.
+type ArticleNotifier interface {
+	Subscribe(fn func(articles []*Article)) (unsubscribe func())
+}
+
 // These interfaces demonstrate the Dependency Inversion Principle:
 // High-level domain logic depends on abstractions (interfaces),
 // not on low-level implementation details (concrete types).
@@ -0,0 +1,297 @@
+package feedtest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// FEEDTEST - A configurable fake feed server for end-to-end tests
+// =============================================================================
+
+// Format is the kind of document a Route serves.
+type Format string
+
+// Feed formats.
+const (
+	RSS  Format = "rss"
+	Atom Format = "atom"
+	JSON Format = "json"
+)
+
+// Epoch is the default publication time of item 0. Item n is published
+// n hours later, so adding items adds newer ones.
+var Epoch = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+// Route describes what the server answers for one path. The zero value
+// serves an empty RSS feed.
+type Route struct {
+	Format Format    // Defaults to RSS
+	Title  string    // Defaults to "Generated feed"
+	Items  int       // Generated items, newest first
+	Start  time.Time // Publication time of item 0; defaults to Epoch
+
+	Body        string // Served verbatim instead of a generated feed, e.g. a robots.txt
+	ContentType string // Overrides the format's media type
+
+	Malformed bool          // Cut the document off halfway
+	Delay     time.Duration // Wait before answering, unless the client gives up first
+	Gzip      bool          // Compress when the client accepts gzip
+	ETag      string        // Send this ETag and answer 304 to a matching If-None-Match
+
+	Statuses   []int       // Answered in turn, with an empty body, before the feed is served
+	Header     http.Header // Added to every response, e.g. Retry-After
+	RedirectTo string      // Redirect here (a path or URL) instead of serving anything
+	Redirect   int         // Redirect status; defaults to 302 Found
+}
+
+// Server is an httptest.Server serving generated feeds. Routes can be
+// changed while it runs, for example to publish new items to a poller.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	routes   map[string]Route
+	requests map[string][]*http.Request
+}
+
+// NewServer starts a server with the given routes and closes it when the
+// test ends.
+func NewServer(t testing.TB, routes map[string]Route) *Server {
+	t.Helper()
+	s := &Server{
+		routes:   make(map[string]Route),
+		requests: make(map[string][]*http.Request),
+	}
+	for path, route := range routes {
+		s.routes[path] = route
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Set adds or replaces the route for path.
+func (s *Server) Set(path string, route Route) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes[path] = route
+}
+
+// FeedURL returns the absolute URL of path on the server.
+func (s *Server) FeedURL(path string) string {
+	return s.URL + path
+}
+
+// Requests returns how many requests path has received.
+func (s *Server) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.requests[path])
+}
+
+// LastRequest returns the most recent request for path, or nil.
+func (s *Server) LastRequest(path string) *http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if reqs := s.requests[path]; len(reqs) > 0 {
+		return reqs[len(reqs)-1]
+	}
+	return nil
+}
+
+// serve answers one request according to its route.
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	route, ok := s.routes[r.URL.Path]
+	attempt := len(s.requests[r.URL.Path])
+	s.requests[r.URL.Path] = append(s.requests[r.URL.Path], r.Clone(r.Context()))
+	s.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if route.Delay > 0 {
+		select {
+		case <-time.After(route.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	for key, values := range route.Header {
+		w.Header()[key] = values
+	}
+
+	switch {
+	case attempt < len(route.Statuses):
+		w.WriteHeader(route.Statuses[attempt])
+		return
+	case route.RedirectTo != "":
+		code := route.Redirect
+		if code == 0 {
+			code = http.StatusFound
+		}
+		http.Redirect(w, r, route.RedirectTo, code)
+		return
+	case route.ETag != "":
+		w.Header().Set("ETag", route.ETag)
+		if r.Header.Get("If-None-Match") == route.ETag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	body, contentType := route.document(s.FeedURL(r.URL.Path))
+	if route.Malformed {
+		body = body[:len(body)/2]
+	}
+	w.Header().Set("Content-Type", contentType)
+	if route.Gzip && strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		defer gz.Close()
+		gz.Write(body)
+		return
+	}
+	w.Write(body)
+}
+
+// item is one generated entry.
+type item struct {
+	n         int
+	title     string
+	link      string
+	published time.Time
+}
+
+// items returns the route's items, newest first.
+func (r Route) items(feedURL string) []item {
+	start := r.Start
+	if start.IsZero() {
+		start = Epoch
+	}
+	items := make([]item, 0, r.Items)
+	for n := r.Items - 1; n >= 0; n-- {
+		items = append(items, item{
+			n:         n,
+			title:     fmt.Sprintf("Item %d", n),
+			link:      fmt.Sprintf("%s/items/%d", feedURL, n),
+			published: start.Add(time.Duration(n) * time.Hour),
+		})
+	}
+	return items
+}
+
+// document renders the route's body and its media type.
+func (r Route) document(feedURL string) ([]byte, string) {
+	title := r.Title
+	if title == "" {
+		title = "Generated feed"
+	}
+
+	var body []byte
+	contentType := r.ContentType
+	switch {
+	case r.Body != "":
+		body = []byte(r.Body)
+		if contentType == "" {
+			contentType = "text/plain; charset=utf-8"
+		}
+	case r.Format == Atom:
+		body = atomDocument(title, feedURL, r.items(feedURL))
+		if contentType == "" {
+			contentType = "application/atom+xml"
+		}
+	case r.Format == JSON:
+		body = jsonDocument(title, feedURL, r.items(feedURL))
+		if contentType == "" {
+			contentType = "application/feed+json"
+		}
+	default:
+		body = rssDocument(title, feedURL, r.items(feedURL))
+		if contentType == "" {
+			contentType = "application/rss+xml"
+		}
+	}
+	return body, contentType
+}
+
+// escape returns s escaped for XML text and attributes.
+func escape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+func rssDocument(title, feedURL string, items []item) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<rss version=\"2.0\"><channel>\n<title>%s</title><link>%s</link><description>Generated feed</description>\n",
+		escape(title), escape(feedURL))
+	for _, it := range items {
+		fmt.Fprintf(&buf, "<item><title>%s</title><link>%s</link><guid>%s</guid><pubDate>%s</pubDate><description>Body of item %d</description></item>\n",
+			escape(it.title), escape(it.link), escape(it.link), it.published.Format(time.RFC1123Z), it.n)
+	}
+	buf.WriteString("</channel></rss>\n")
+	return buf.Bytes()
+}
+
+func atomDocument(title, feedURL string, items []item) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<feed xmlns=\"http://www.w3.org/2005/Atom\">\n<title>%s</title><id>%s</id><link href=\"%s\"/><updated>%s</updated>\n",
+		escape(title), escape(feedURL), escape(feedURL), Epoch.Format(time.RFC3339))
+	for _, it := range items {
+		stamp := it.published.Format(time.RFC3339)
+		fmt.Fprintf(&buf, "<entry><title>%s</title><id>%s</id><link href=\"%s\"/><published>%s</published><updated>%s</updated><summary>Body of item %d</summary></entry>\n",
+			escape(it.title), escape(it.link), escape(it.link), stamp, stamp, it.n)
+	}
+	buf.WriteString("</feed>\n")
+	return buf.Bytes()
+}
+
+func jsonDocument(title, feedURL string, items []item) []byte {
+	type jsonItem struct {
+		ID            string `json:"id"`
+		URL           string `json:"url"`
+		Title         string `json:"title"`
+		ContentText   string `json:"content_text"`
+		DatePublished string `json:"date_published"`
+	}
+	doc := struct {
+		Version     string     `json:"version"`
+		Title       string     `json:"title"`
+		HomePageURL string     `json:"home_page_url"`
+		FeedURL     string     `json:"feed_url"`
+		Items       []jsonItem `json:"items"`
+	}{Version: "https://jsonfeed.org/version/1.1", Title: title, HomePageURL: feedURL, FeedURL: feedURL, Items: []jsonItem{}}
+	for _, it := range items {
+		doc.Items = append(doc.Items, jsonItem{
+			ID:            it.link,
+			URL:           it.link,
+			Title:         it.title,
+			ContentText:   fmt.Sprintf("Body of item %d", it.n),
+			DatePublished: it.published.Format(time.RFC3339),
+		})
+	}
+	body, _ := json.MarshalIndent(doc, "", "  ")
+	return body
+}
+
+// Why a generated server instead of canned files:
+//
+// - Tests state the behaviour they need (three items, two 503s, gzip)
+//   next to the assertion, rather than in a fixture somewhere else.
+// - Routes can change while the server runs, which is what a scheduler
+//   polling for new items needs to see.
+// - Requests are kept, so tests can check headers the reader sent as
+//   well as how often it asked.
@@ -0,0 +1,59 @@
+package feedtest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feedtest"
+)
+
+func TestServerConditionalRequests(t *testing.T) {
+	srv := feedtest.NewServer(t, map[string]feedtest.Route{
+		"/rss": {Items: 2, ETag: `"v1"`},
+	})
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        int
+	}{
+		{"unconditional", "", http.StatusOK},
+		{"matching ETag", `"v1"`, http.StatusNotModified},
+		{"stale ETag", `"v0"`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, srv.FeedURL("/rss"), nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want || resp.Header.Get("ETag") != `"v1"` {
+				t.Errorf("status = %d, ETag %q; want %d with the ETag", resp.StatusCode, resp.Header.Get("ETag"), tt.want)
+			}
+		})
+	}
+
+	if got := srv.Requests("/rss"); got != len(tests) {
+		t.Errorf("Requests = %d, want %d", got, len(tests))
+	}
+	if got := srv.LastRequest("/rss").Header.Get("If-None-Match"); got != `"v0"` {
+		t.Errorf("LastRequest If-None-Match = %q, want the last one sent", got)
+	}
+}
+
+func TestServerUnknownPath(t *testing.T) {
+	srv := feedtest.NewServer(t, nil)
+	resp, err := http.Get(srv.FeedURL("/missing"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", resp.StatusCode)
+	}
+}
@@ -0,0 +1,146 @@
+package fixture_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/config"
+	"github.com/YOUR_USERNAME/go-news/api/internal/fixture"
+	"github.com/YOUR_USERNAME/go-news/api/internal/reader"
+	"github.com/YOUR_USERNAME/go-news/api/internal/store"
+)
+
+const testRSS = `<?xml version="1.0"?>
+<rss version="2.0"><channel><title>Recorded</title><link>https://example.com</link>
+<item><title>One</title><link>https://example.com/1</link><pubDate>Tue, 05 Mar 2024 09:30:00 GMT</pubDate></item>
+</channel></rss>`
+
+// newReader returns a reader whose requests go through a fixture transport.
+func newReader(dir string, mode fixture.Mode) *reader.RSSReader {
+	return reader.NewRSSReader(store.NewArticleStore(),
+		reader.WithTransport(fixture.NewTransport(dir, mode, nil)),
+		reader.WithRetry(reader.RetryPolicy{MaxAttempts: 1}),
+		reader.WithPoliteness(reader.PolitenessPolicy{RespectRobots: true}),
+	)
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/rss", http.StatusFound)
+		case "/rss":
+			w.Header().Set("Content-Type", "application/rss+xml")
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret"})
+			w.Write([]byte(testRSS))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	dir := t.TempDir()
+
+	recorded, err := newReader(dir, fixture.ModeRecord).FetchFeed(context.Background(), srv.URL+"/old")
+	if err != nil {
+		t.Fatalf("recording FetchFeed error = %v", err)
+	}
+	srv.Close() // Replaying must not need the server
+
+	replayed, err := newReader(dir, fixture.ModeReplay).FetchFeed(context.Background(), srv.URL+"/old")
+	if err != nil {
+		t.Fatalf("replaying FetchFeed error = %v", err)
+	}
+	if replayed.Title != recorded.Title || len(replayed.Articles) != 1 || !replayed.Articles[0].Published.Equal(*recorded.Articles[0].Published) {
+		t.Errorf("replayed feed = %+v, want the recorded %+v", replayed, recorded)
+	}
+
+	u, _ := url.Parse(srv.URL + "/rss")
+	meta, err := os.ReadFile(fixture.Path(dir, u) + ".meta.json")
+	if err != nil {
+		t.Fatalf("no metadata recorded: %v", err)
+	}
+	if strings.Contains(string(meta), "secret") || !strings.Contains(string(meta), "application/rss+xml") {
+		t.Errorf("metadata = %s, want the content type and no cookies", meta)
+	}
+}
+
+func TestReplayDirectory(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "example.com", "blog"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "example.com", "blog", "feed.rss"), []byte(testRSS), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r := newReader(dir, fixture.ModeReplay)
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{"hand-made fixture", "https://example.com/blog/feed.rss", false},
+		{"file URL", "file://" + filepath.ToSlash(filepath.Join(dir, "example.com", "blog", "feed.rss")), false},
+		{"missing fixture", "https://example.com/other.rss", true},
+		{"missing file", "file:///no/such/feed.rss", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.FetchFeed(context.Background(), tt.url)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("FetchFeed succeeded, want a 404")
+				}
+				return
+			}
+			if err != nil || got.Title != "Recorded" {
+				t.Errorf("FetchFeed = %v, %v, want the fixture", got, err)
+			}
+		})
+	}
+}
+
+func TestPath(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://go.dev/blog/feed.atom", "go.dev/blog/feed.atom"},
+		{"https://go.dev/blog", "go.dev/blog/index"},
+		{"https://go.dev/blog/", "go.dev/blog/index"},
+		{"https://go.dev", "go.dev/index"},
+		{"http://localhost:8080/rss.xml?page=2&q=a b", "localhost_8080/rss.xml@page%3D2%26q%3Da+b"},
+		{"https://example.com/../../etc/passwd", "example.com/etc/passwd/index"},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fixture.Path("fixtures", u); got != filepath.Join("fixtures", filepath.FromSlash(tt.want)) {
+			t.Errorf("Path(%q) = %q, want fixtures/%s", tt.url, got, tt.want)
+		}
+	}
+}
+
+// TestShippedFixtures checks that the fixtures in the repository cover the
+// default feeds, so the API runs offline out of the box.
+func TestShippedFixtures(t *testing.T) {
+	cfg := config.Default()
+	cfg.Fetch.Fixtures = config.FixturesConfig{Mode: string(fixture.ModeReplay), Dir: "../../fixtures"}
+	r := reader.NewRSSReader(store.NewArticleStore(), cfg.Fetch.ReaderOptions()...)
+
+	report := r.FetchAll(context.Background(), cfg.Feeds, cfg.Fetch.Limits())
+	for _, result := range report.Results {
+		if result.Err != nil {
+			t.Errorf("%s: %v", result.URL, result.Err)
+		} else if result.Articles == 0 {
+			t.Errorf("%s: fixture has no articles", result.URL)
+		}
+	}
+}
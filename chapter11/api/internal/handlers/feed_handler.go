@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/dto"
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/openapi"
+	"github.com/YOUR_USERNAME/go-news/api/internal/subscription"
+)
+
+// =============================================================================
+// FEED HANDLERS - Per-feed fetch health
+// =============================================================================
+
+// FeedSchedule reports which feeds are polled and when.
+type FeedSchedule interface {
+	Feeds() []string
+	NextPoll(url string) (time.Time, bool)
+}
+
+// SubscriptionLister lists the subscriptions, whose IDs identify feeds.
+type SubscriptionLister interface {
+	List() []subscription.Subscription
+}
+
+// Feed states reported by the status endpoints.
+const (
+	FeedStatePending = "pending" // Not fetched yet
+	FeedStateOK      = "ok"
+	FeedStateFailing = "failing"     // Last fetch failed; still polled normally
+	FeedStateBackoff = "backing_off" // Circuit breaker open
+	FeedStateDead    = "dead"        // 410 Gone; no longer polled
+)
+
+// FeedHandlers serves feed health information.
+type FeedHandlers struct {
+	statuses      FeedStatusReporter
+	schedule      FeedSchedule
+	subscriptions SubscriptionLister
+}
+
+// NewFeedHandlers creates feed handlers from the reader's fetch history,
+// the scheduler's polling plan and the subscriptions that name the feeds.
+func NewFeedHandlers(statuses FeedStatusReporter, schedule FeedSchedule, subscriptions SubscriptionLister) *FeedHandlers {
+	return &FeedHandlers{
+		statuses:      statuses,
+		schedule:      schedule,
+		subscriptions: subscriptions,
+	}
+}
+
+// RegisterRoutes mounts the feed routes on the provided mux.
+func (h *FeedHandlers) RegisterRoutes(mux *http.ServeMux) {
+	register(mux, h.Routes())
+}
+
+// Routes describes the feed health routes.
+func (h *FeedHandlers) Routes() []Route {
+	return []Route{
+		{
+			Endpoint: openapi.Endpoint{
+				Method:   http.MethodGet,
+				Path:     "/feeds",
+				Summary:  "Polled feeds with their fetch health, sorted by URL",
+				Response: []dto.FeedStatus{},
+			},
+			Handler: h.listHandler,
+		},
+		{
+			Endpoint: openapi.Endpoint{
+				Method:   http.MethodGet,
+				Path:     "/feeds/{id}/status",
+				Summary:  "One feed's failures, last error, last success and next attempt",
+				Response: dto.FeedStatus{},
+				Errors:   []int{http.StatusNotFound},
+			},
+			Handler: h.statusHandler,
+		},
+	}
+}
+
+// listHandler returns the status of every subscribed feed, sorted by URL.
+func (h *FeedHandlers) listHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.report())
+}
+
+// statusHandler returns the status of the feed with the given ID.
+func (h *FeedHandlers) statusHandler(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	for _, status := range h.report() {
+		if status.ID == id {
+			writeJSON(w, http.StatusOK, status)
+			return
+		}
+	}
+	writeProblem(w, r, problemNotFound, "No polled feed has ID "+id)
+}
+
+// report merges the polled feeds with their fetch history. Feeds that
+// were fetched but are no longer polled are left out, unless they are
+// gone: those stay listed as dead so the user notices. Feeds carry their
+// subscription's ID, which a move doesn't change, so it matches the one
+// DELETE /feeds/{id} takes.
+func (h *FeedHandlers) report() []dto.FeedStatus {
+	ids := make(map[string]string)
+	for _, sub := range h.subscriptions.List() {
+		ids[sub.URL] = sub.ID
+	}
+
+	history := make(map[string]feed.FetchStatus)
+	urls := h.schedule.Feeds()
+	for _, status := range h.statuses.FeedStatuses() {
+		history[status.URL] = status
+		if status.Gone && !slices.Contains(urls, status.URL) {
+			urls = append(urls, status.URL)
+		}
+	}
+	sort.Strings(urls)
+
+	result := make([]dto.FeedStatus, 0, len(urls))
+	for _, url := range urls {
+		id, ok := ids[url]
+		if !ok {
+			id = feed.ID(url) // A dead feed that was unsubscribed since
+		}
+		resp := dto.FeedStatus{
+			ID:    id,
+			URL:   url,
+			State: FeedStatePending,
+		}
+
+		if status, ok := history[url]; ok {
+			resp.ConsecutiveFailures = status.ConsecutiveFailures
+			resp.LastError = status.LastError
+			resp.LastAttempt = timePtr(status.LastAttempt)
+			resp.LastSuccess = timePtr(status.LastSuccess)
+			resp.UnparsedDates = status.UnparsedDates
+			resp.UnparsedDateExample = status.UnparsedDateExample
+
+			switch {
+			case status.Gone:
+				resp.State = FeedStateDead
+			case time.Now().Before(status.RetryAt):
+				resp.State = FeedStateBackoff
+			case status.LastError != "":
+				resp.State = FeedStateFailing
+			default:
+				resp.State = FeedStateOK
+			}
+		}
+
+		if next, ok := h.schedule.NextPoll(url); ok {
+			resp.NextAttempt = timePtr(next)
+		}
+
+		result = append(result, resp)
+	}
+
+	return result
+}
+
+// timePtr returns nil for the zero time so it is omitted from JSON.
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
@@ -0,0 +1,171 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/handlers"
+	"github.com/YOUR_USERNAME/go-news/api/internal/subscription"
+)
+
+// mockSchedule is a test double for FeedSchedule.
+type mockSchedule struct {
+	next map[string]time.Time
+}
+
+func (m *mockSchedule) Feeds() []string {
+	urls := make([]string, 0, len(m.next))
+	for url := range m.next {
+		urls = append(urls, url)
+	}
+	return urls
+}
+
+func (m *mockSchedule) NextPoll(url string) (time.Time, bool) {
+	next, ok := m.next[url]
+	return next, ok
+}
+
+// mockSubscriptions is a test double for SubscriptionLister.
+type mockSubscriptions []subscription.Subscription
+
+func (m mockSubscriptions) List() []subscription.Subscription { return m }
+
+func TestFeedStatusHandler(t *testing.T) {
+	now := time.Now()
+	const (
+		okURL      = "https://ok.example/rss"
+		backoffURL = "https://down.example/rss"
+		pendingURL = "https://new.example/rss"
+		goneURL    = "https://gone.example/rss" // No longer scheduled
+	)
+
+	statuses := &mockFeedStatuses{statuses: []feed.FetchStatus{
+		{URL: okURL, LastAttempt: now, LastSuccess: now},
+		{
+			URL:                 backoffURL,
+			LastAttempt:         now,
+			LastSuccess:         now.Add(-time.Hour),
+			LastError:           "unexpected status code: 503",
+			ConsecutiveFailures: 4,
+			RetryAt:             now.Add(time.Hour),
+		},
+		{URL: goneURL, LastAttempt: now, LastError: "unexpected status code: 410", ConsecutiveFailures: 1, Gone: true},
+	}}
+	schedule := &mockSchedule{next: map[string]time.Time{
+		okURL:      now.Add(15 * time.Minute),
+		backoffURL: now.Add(time.Hour),
+		pendingURL: now.Add(15 * time.Minute),
+	}}
+
+	mux := http.NewServeMux()
+	handlers.NewFeedHandlers(statuses, schedule, mockSubscriptions{}).RegisterRoutes(mux)
+
+	tests := []struct {
+		name         string
+		url          string
+		wantState    string
+		wantFailures int
+		wantError    bool
+	}{
+		{"healthy feed", okURL, handlers.FeedStateOK, 0, false},
+		{"backing off", backoffURL, handlers.FeedStateBackoff, 4, true},
+		{"never fetched", pendingURL, handlers.FeedStatePending, 0, false},
+		{"gone", goneURL, handlers.FeedStateDead, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/feeds/"+feed.ID(tt.url)+"/status", nil)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200", rec.Code)
+			}
+
+			var body struct {
+				URL                 string     `json:"url"`
+				State               string     `json:"state"`
+				ConsecutiveFailures int        `json:"consecutive_failures"`
+				LastError           string     `json:"last_error"`
+				NextAttempt         *time.Time `json:"next_attempt"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+
+			if body.URL != tt.url || body.State != tt.wantState {
+				t.Errorf("got %s in state %q, want %s in state %q", body.URL, body.State, tt.url, tt.wantState)
+			}
+			if body.ConsecutiveFailures != tt.wantFailures {
+				t.Errorf("consecutive_failures = %d, want %d", body.ConsecutiveFailures, tt.wantFailures)
+			}
+			if (body.LastError != "") != tt.wantError {
+				t.Errorf("last_error = %q", body.LastError)
+			}
+			if (body.NextAttempt == nil) != (tt.wantState == handlers.FeedStateDead) {
+				t.Errorf("next_attempt = %v; only dead feeds have none", body.NextAttempt)
+			}
+		})
+	}
+
+	t.Run("unknown feed", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/feeds/0123456789abcdef/status", nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want 404", rec.Code)
+		}
+	})
+}
+
+func TestFeedStatusAfterMove(t *testing.T) {
+	const (
+		oldURL = "https://old.example/rss"
+		newURL = "https://new.example/rss"
+	)
+	store, err := subscription.NewStore("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub, err := store.Add(subscription.Subscription{URL: oldURL, Source: subscription.SourceAPI})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Move(oldURL, newURL); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	statuses := &mockFeedStatuses{statuses: []feed.FetchStatus{{URL: newURL, LastAttempt: now, LastSuccess: now}}}
+	schedule := &mockSchedule{next: map[string]time.Time{newURL: now.Add(15 * time.Minute)}}
+	mux := http.NewServeMux()
+	handlers.NewFeedHandlers(statuses, schedule, store).RegisterRoutes(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/feeds/"+sub.ID+"/status", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status of %s after the move = %d, want 200", sub.ID, rec.Code)
+	}
+	var body struct {
+		ID    string `json:"id"`
+		URL   string `json:"url"`
+		State string `json:"state"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.ID != sub.ID || body.URL != newURL || body.State != handlers.FeedStateOK {
+		t.Errorf("got %+v, want %s polling %s", body, sub.ID, newURL)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/feeds/"+feed.ID(newURL)+"/status", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status under the new URL's ID = %d, want 404", rec.Code)
+	}
+}
@@ -2,10 +2,15 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/YOUR_USERNAME/go-news/api/internal/dto"
 	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/openapi"
+	"github.com/YOUR_USERNAME/go-news/api/internal/story"
 )
 
 // =============================================================================
@@ -19,6 +24,11 @@ type ArticleReader interface {
 	GetRecent(n int) []*feed.Article
 }
 
+// searchWindow is how many recent articles are searched when /articles
+// is filtered by feed or keyword, or paged with a cursor. Paging stops at
+// its end, or at storyWindow's with collapse=true.
+const searchWindow = 5000
+
 // Handlers manages HTTP request handlers with their dependencies.
 type Handlers struct {
 	articles ArticleReader
@@ -36,33 +46,94 @@ func New(articles ArticleReader) *Handlers {
 // This pattern keeps route definitions co-located with their handlers
 // and makes testing easier - tests can create their own mux.
 func (h *Handlers) RegisterRoutes(mux *http.ServeMux) {
-	mux.HandleFunc("/articles", h.articlesHandler)
+	register(mux, h.Routes())
+}
+
+// Routes describes the article routes.
+func (h *Handlers) Routes() []Route {
+	return []Route{{
+		Endpoint: openapi.Endpoint{
+			Method:  http.MethodGet,
+			Path:    "/articles",
+			Summary: "Recent articles, newest first, a page at a time",
+			Params: []openapi.Param{
+				countParam("articles per page", 10), feedParam, keywordParam,
+				{Name: "collapse", Type: "boolean", Description: "One article per story"},
+				{Name: "cursor", Type: "string", Description: fmt.Sprintf(
+					"The next_cursor of the previous page. Paging reaches back %d articles, or %d with collapse=true; "+
+						"the page that ends there has no next_cursor", searchWindow, storyWindow)},
+			},
+			Response: dto.ArticlePage{},
+			Errors:   []int{http.StatusBadRequest},
+		},
+		Handler: h.articlesHandler,
+	}}
 }
 
 // articlesHandler returns recent articles as JSON.
-// Supports ?count=N query parameter to control number of articles returned.
+// Supports ?count=N query parameter to control number of articles returned,
+// ?feed=NAME (repeatable) and ?q=KEYWORD filters like the stream's,
+// ?collapse=true to return one article per story, and ?cursor= to fetch
+// the page named by the previous response's next_cursor or Link header.
+// Pages only reach as far back as the window they are taken from.
 func (h *Handlers) articlesHandler(w http.ResponseWriter, r *http.Request) {
-	// Only allow GET requests
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	// Parse count parameter with default of 10
+	n, err := parseCount(r, 10)
+	if err != nil {
+		writeError(w, r, err, problemInvalid)
 		return
 	}
 
-	// Parse count parameter with default of 10
-	n := 10
-	if countStr := r.URL.Query().Get("count"); countStr != "" {
-		if count, err := strconv.Atoi(countStr); err == nil && count > 0 {
-			n = count
+	// Fetch articles from storage, searching further back when filtering
+	// or paging and collapsing near-duplicates if asked. One more than
+	// asked for tells whether there is a next page.
+	filter := streamFilter{
+		feeds:   r.URL.Query()["feed"],
+		keyword: strings.ToLower(r.URL.Query().Get("q")),
+	}
+	collapse, _ := strconv.ParseBool(r.URL.Query().Get("collapse"))
+	cursor := r.URL.Query().Get("cursor")
+	var articles []*feed.Article
+	switch {
+	case len(filter.feeds) > 0 || filter.keyword != "":
+		for _, article := range h.articles.GetRecent(searchWindow) {
+			if filter.matches(article) {
+				articles = append(articles, article)
+			}
 		}
+	case collapse:
+		articles = h.articles.GetRecent(max(n+1, storyWindow))
+	case cursor != "":
+		articles = h.articles.GetRecent(searchWindow)
+	default:
+		articles = h.articles.GetRecent(n + 1)
+	}
+	if collapse {
+		articles = story.Collapse(articles, story.DefaultMaxDistance)
+	}
+	if cursor != "" {
+		if articles, err = afterCursor(articles, cursor); err != nil {
+			writeError(w, r, err, problemInvalid)
+			return
+		}
+	}
+	var next string
+	if len(articles) > n {
+		articles = articles[:n]
+		next = setNextLink(w, r, articles[n-1])
+	}
+	if isV1(r) {
+		writeJSON(w, http.StatusOK, dto.ArticlePage{Articles: dto.FromArticles(articles), NextCursor: next})
+		return
+	}
+	if articles == nil {
+		articles = []*feed.Article{}
 	}
-
-	// Fetch articles from storage
-	articles := h.articles.GetRecent(n)
 
 	// Return as JSON
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(articles); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		writeError(w, r, err, problemInternal)
 		return
 	}
 }
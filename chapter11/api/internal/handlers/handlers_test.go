@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/YOUR_USERNAME/go-news/api/internal/dto"
 	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
 	"github.com/YOUR_USERNAME/go-news/api/internal/handlers"
 )
@@ -149,6 +152,125 @@ func TestArticlesHandler_EmptyStore(t *testing.T) {
 	}
 }
 
+// TestArticlesHandlerPages follows the Link header through every page,
+// including after the article a cursor names has gone.
+// getPage fetches a legacy page of articles and the link to the next one.
+func getPage(t *testing.T, mux *http.ServeMux, target string) ([]*feed.Article, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s: status %d", target, rec.Code)
+	}
+	var page []*feed.Article
+	if err := json.NewDecoder(rec.Body).Decode(&page); err != nil {
+		t.Fatal(err)
+	}
+	for _, link := range rec.Header().Values("Link") {
+		if next, ok := strings.CutSuffix(link, `>; rel="next"`); ok {
+			return page, strings.TrimPrefix(next, "<")
+		}
+	}
+	return page, ""
+}
+
+func TestArticlesHandlerPages(t *testing.T) {
+	now := time.Now()
+	var articles []*feed.Article
+	for i := 0; i < 5; i++ {
+		published := now.Add(-time.Duration(i) * time.Minute)
+		articles = append(articles, &feed.Article{
+			Title:     "Article " + string(rune('A'+i)),
+			Link:      "https://example.com/" + string(rune('A'+i)),
+			Published: &published,
+		})
+	}
+	mock := &mockArticleReader{articles: articles}
+	mux := http.NewServeMux()
+	handlers.New(mock).RegisterRoutes(mux)
+
+	var titles []string
+	var links []string
+	for target := "/articles?count=2"; target != ""; {
+		var page []*feed.Article
+		page, target = getPage(t, mux, target)
+		for _, a := range page {
+			titles = append(titles, a.Title)
+		}
+		links = append(links, target)
+	}
+	if want := []string{"Article A", "Article B", "Article C", "Article D", "Article E"}; !slices.Equal(titles, want) {
+		t.Errorf("paged through %q, want %q", titles, want)
+	}
+	if len(links) != 3 || !strings.Contains(links[0], "count=2") {
+		t.Errorf("next links = %q, want two keeping count", links)
+	}
+
+	// The article the cursor names is dropped; paging resumes after its time
+	_, next := getPage(t, mux, "/articles?count=2")
+	mock.articles = slices.Delete(slices.Clone(articles), 1, 2)
+	page, _ := getPage(t, mux, next)
+	if len(page) != 2 || page[0].Title != "Article C" {
+		t.Errorf("page after a dropped article starts at %v, want Article C", page)
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/articles?cursor=bogus", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid cursor: status %d, want 400", rec.Code)
+	}
+}
+
+func TestArticlesHandlerPagesWithoutLinks(t *testing.T) {
+	now := time.Now()
+	var articles []*feed.Article
+	for i, title := range []string{"a", "b", "c", "d"} {
+		published := now.Add(-time.Duration(i) * time.Minute)
+		articles = append(articles, &feed.Article{Title: title, FeedTitle: "Mailing list", Published: &published})
+	}
+	mux := http.NewServeMux()
+	handlers.New(&mockArticleReader{articles: articles}).RegisterRoutes(mux)
+
+	var titles []string
+	for target, pages := "/articles?count=1", 0; target != ""; pages++ {
+		if pages > len(articles) {
+			t.Fatalf("still paging after %d pages: %q", pages, titles)
+		}
+		var page []*feed.Article
+		page, target = getPage(t, mux, target)
+		for _, a := range page {
+			titles = append(titles, a.Title)
+		}
+	}
+	if want := []string{"a", "b", "c", "d"}; !slices.Equal(titles, want) {
+		t.Errorf("paged through %q, want %q", titles, want)
+	}
+}
+
+func TestArticleIDsWithoutLinks(t *testing.T) {
+	articles := []*feed.Article{
+		{Title: "a", FeedTitle: "Mailing list"},
+		{Title: "b", FeedTitle: "Mailing list"},
+	}
+	mux := http.NewServeMux()
+	handlers.RegisterV1(mux, handlers.New(&mockArticleReader{articles: articles}).Routes()...)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/articles", nil))
+	var page dto.ArticlePage
+	if err := json.NewDecoder(rec.Body).Decode(&page); err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Articles) != 2 || page.Articles[0].ID == page.Articles[1].ID {
+		t.Fatalf("articles = %+v, want two with distinct IDs", page.Articles)
+	}
+	for i, a := range page.Articles {
+		if a.ID != feed.ArticleID(articles[i]) {
+			t.Errorf("%s: id %s, want %s", a.Title, a.ID, feed.ArticleID(articles[i]))
+		}
+	}
+}
+
 // Benefits of this testing approach:
 //
 // 1. No external dependencies - tests run fast and reliably
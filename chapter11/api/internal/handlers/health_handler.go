@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/newsroom"
+)
+
+// =============================================================================
+// HEALTH HANDLERS - Liveness and readiness probes for orchestrators
+// =============================================================================
+
+// Overall and per-check status values reported by the probes.
+const (
+	StatusOK          = "ok"
+	StatusDegraded    = "degraded"
+	StatusUnavailable = "unavailable"
+)
+
+// StoragePinger checks whether storage is reachable.
+type StoragePinger interface {
+	Ping(ctx context.Context) error
+}
+
+// FeedStatusReporter exposes the fetch history of each feed.
+type FeedStatusReporter interface {
+	FeedStatuses() []feed.FetchStatus
+}
+
+// HealthConfig holds the dependencies inspected by the readiness probe.
+type HealthConfig struct {
+	Storage    StoragePinger
+	Feeds      FeedStatusReporter
+	Summarizer Summarizer
+
+	// StaleAfter marks a feed as stale when its last successful fetch is
+	// older than this. Zero disables the staleness check.
+	StaleAfter time.Duration
+}
+
+// HealthHandlers serves /healthz and /readyz.
+type HealthHandlers struct {
+	config HealthConfig
+}
+
+// NewHealthHandlers creates health handlers for the given dependencies.
+func NewHealthHandlers(config HealthConfig) *HealthHandlers {
+	return &HealthHandlers{config: config}
+}
+
+// RegisterRoutes mounts the health probe routes on the provided mux.
+func (h *HealthHandlers) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/healthz", h.livenessHandler)
+	mux.HandleFunc("/readyz", h.readinessHandler)
+}
+
+// livenessHandler reports that the process is up and serving HTTP.
+// It deliberately checks no dependencies: a failing dependency should make
+// the service unready, not get it restarted.
+func (h *HealthHandlers) livenessHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeProblem(w, r, problemMethod, r.Method+" is not allowed; use GET or HEAD")
+		return
+	}
+
+	writeHealth(w, http.StatusOK, map[string]string{"status": StatusOK})
+}
+
+// readinessReport is the JSON body returned by /readyz.
+type readinessReport struct {
+	Status     string            `json:"status"`
+	Storage    checkResult       `json:"storage"`
+	Summarizer summarizerResult  `json:"summarizer"`
+	Feeds      []feedCheckResult `json:"feeds"`
+}
+
+type checkResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type summarizerResult struct {
+	Status         string `json:"status"`
+	Implementation string `json:"implementation"`
+}
+
+type feedCheckResult struct {
+	URL              string     `json:"url"`
+	Status           string     `json:"status"`
+	LastSuccess      *time.Time `json:"last_success,omitempty"`
+	SinceLastSuccess string     `json:"since_last_success,omitempty"`
+	LastError        string     `json:"last_error,omitempty"`
+}
+
+// readinessHandler checks each dependency and reports the combined result.
+//
+// The service is unavailable (503) when storage is unreachable or when no
+// feed has ever been fetched successfully. It is degraded (200) when feeds
+// are failing or stale, or the stub summarizer is in use, since it can
+// still serve useful responses.
+func (h *HealthHandlers) readinessHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeProblem(w, r, problemMethod, r.Method+" is not allowed; use GET or HEAD")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+
+	report := readinessReport{
+		Status:     StatusOK,
+		Storage:    h.checkStorage(ctx),
+		Summarizer: h.checkSummarizer(),
+		Feeds:      h.checkFeeds(time.Now()),
+	}
+
+	healthyFeeds, usableFeeds := 0, 0
+	for _, f := range report.Feeds {
+		if f.Status == StatusOK {
+			healthyFeeds++
+		}
+		if f.Status != StatusUnavailable {
+			usableFeeds++
+		}
+	}
+
+	switch {
+	case report.Storage.Status != StatusOK:
+		report.Status = StatusUnavailable
+	case len(report.Feeds) > 0 && usableFeeds == 0:
+		report.Status = StatusUnavailable
+	case healthyFeeds < len(report.Feeds), report.Summarizer.Status != StatusOK:
+		report.Status = StatusDegraded
+	}
+
+	code := http.StatusOK
+	if report.Status == StatusUnavailable {
+		code = http.StatusServiceUnavailable
+	}
+	writeHealth(w, code, report)
+}
+
+func (h *HealthHandlers) checkStorage(ctx context.Context) checkResult {
+	if h.config.Storage == nil {
+		return checkResult{Status: StatusOK}
+	}
+	if err := h.config.Storage.Ping(ctx); err != nil {
+		return checkResult{Status: StatusUnavailable, Error: err.Error()}
+	}
+	return checkResult{Status: StatusOK}
+}
+
+// checkSummarizer reports which summarizer implementation is wired in.
+// The stub keeps /summary working, so it degrades rather than fails.
+func (h *HealthHandlers) checkSummarizer() summarizerResult {
+	switch h.config.Summarizer.(type) {
+	case *newsroom.ArticleSummarizer:
+		return summarizerResult{Status: StatusOK, Implementation: "ollama"}
+	case *newsroom.StubSummarizer:
+		return summarizerResult{Status: StatusDegraded, Implementation: "stub"}
+	case nil:
+		return summarizerResult{Status: StatusDegraded, Implementation: "none"}
+	default:
+		return summarizerResult{Status: StatusOK, Implementation: "custom"}
+	}
+}
+
+func (h *HealthHandlers) checkFeeds(now time.Time) []feedCheckResult {
+	if h.config.Feeds == nil {
+		return []feedCheckResult{}
+	}
+
+	statuses := h.config.Feeds.FeedStatuses()
+	results := make([]feedCheckResult, 0, len(statuses))
+	for _, s := range statuses {
+		result := feedCheckResult{
+			URL:       s.URL,
+			Status:    StatusOK,
+			LastError: s.LastError,
+		}
+
+		if s.LastSuccess.IsZero() {
+			result.Status = StatusUnavailable
+		} else {
+			lastSuccess := s.LastSuccess
+			since := now.Sub(lastSuccess)
+			result.LastSuccess = &lastSuccess
+			result.SinceLastSuccess = since.Round(time.Second).String()
+
+			if s.LastError != "" {
+				result.Status = StatusDegraded
+			}
+			if h.config.StaleAfter > 0 && since > h.config.StaleAfter {
+				result.Status = StatusDegraded
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// writeHealth encodes a probe response. Probes are never cached.
+func writeHealth(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, code, body)
+}
+
+// Liveness vs readiness:
+//
+// - /healthz answers "is the process alive?" An orchestrator restarts the
+//   container when it fails, so it must not depend on anything external.
+// - /readyz answers "should this instance receive traffic?" It inspects
+//   storage, feed freshness and the summarizer, and returns 503 when the
+//   instance cannot serve meaningful responses.
+//
+// Both handlers depend on narrow interfaces, so tests can exercise every
+// status combination without a network or a real summarizer.
@@ -0,0 +1,149 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/handlers"
+	"github.com/YOUR_USERNAME/go-news/newsroom"
+)
+
+// mockPinger is a test double for StoragePinger.
+type mockPinger struct {
+	err error
+}
+
+func (m *mockPinger) Ping(ctx context.Context) error {
+	return m.err
+}
+
+// mockFeedStatuses is a test double for FeedStatusReporter.
+type mockFeedStatuses struct {
+	statuses []feed.FetchStatus
+}
+
+func (m *mockFeedStatuses) FeedStatuses() []feed.FetchStatus {
+	return m.statuses
+}
+
+// mockSummarizer stands in for a real (non-stub) summarizer.
+type mockSummarizer struct{}
+
+func (m *mockSummarizer) Summarize(ctx context.Context, articles []newsroom.Article) (string, error) {
+	return "summary", nil
+}
+
+// TestReadinessHandler covers the combinations of dependency health.
+func TestReadinessHandler(t *testing.T) {
+	now := time.Now()
+	healthy := feed.FetchStatus{URL: "https://a.example/rss", LastAttempt: now, LastSuccess: now}
+	failing := feed.FetchStatus{URL: "https://b.example/rss", LastAttempt: now, LastError: "timeout"}
+	stale := feed.FetchStatus{URL: "https://c.example/rss", LastAttempt: now, LastSuccess: now.Add(-2 * time.Hour)}
+
+	tests := []struct {
+		name           string
+		storageErr     error
+		feeds          []feed.FetchStatus
+		summarizer     handlers.Summarizer
+		expectedStatus int
+		expectedState  string
+	}{
+		{
+			name:           "all healthy",
+			feeds:          []feed.FetchStatus{healthy},
+			summarizer:     &mockSummarizer{},
+			expectedStatus: http.StatusOK,
+			expectedState:  handlers.StatusOK,
+		},
+		{
+			name:           "storage unreachable",
+			storageErr:     errors.New("connection refused"),
+			feeds:          []feed.FetchStatus{healthy},
+			summarizer:     &mockSummarizer{},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedState:  handlers.StatusUnavailable,
+		},
+		{
+			name:           "stub summarizer degrades",
+			feeds:          []feed.FetchStatus{healthy},
+			summarizer:     newsroom.NewStubSummarizer(),
+			expectedStatus: http.StatusOK,
+			expectedState:  handlers.StatusDegraded,
+		},
+		{
+			name:           "one feed failing degrades",
+			feeds:          []feed.FetchStatus{healthy, failing},
+			summarizer:     &mockSummarizer{},
+			expectedStatus: http.StatusOK,
+			expectedState:  handlers.StatusDegraded,
+		},
+		{
+			name:           "stale feed degrades",
+			feeds:          []feed.FetchStatus{stale},
+			summarizer:     &mockSummarizer{},
+			expectedStatus: http.StatusOK,
+			expectedState:  handlers.StatusDegraded,
+		},
+		{
+			name:           "no feed ever fetched",
+			feeds:          []feed.FetchStatus{failing},
+			summarizer:     &mockSummarizer{},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedState:  handlers.StatusUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlers.NewHealthHandlers(handlers.HealthConfig{
+				Storage:    &mockPinger{err: tt.storageErr},
+				Feeds:      &mockFeedStatuses{statuses: tt.feeds},
+				Summarizer: tt.summarizer,
+				StaleAfter: time.Hour,
+			})
+			mux := http.NewServeMux()
+			h.RegisterRoutes(mux)
+
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			var body struct {
+				Status string `json:"status"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if body.Status != tt.expectedState {
+				t.Errorf("expected state %q, got %q", tt.expectedState, body.Status)
+			}
+		})
+	}
+}
+
+// TestLivenessHandler verifies liveness ignores failing dependencies.
+func TestLivenessHandler(t *testing.T) {
+	h := handlers.NewHealthHandlers(handlers.HealthConfig{
+		Storage: &mockPinger{err: errors.New("down")},
+	})
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+}
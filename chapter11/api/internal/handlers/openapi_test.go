@@ -0,0 +1,270 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/events"
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/handlers"
+	"github.com/YOUR_USERNAME/go-news/api/internal/openapi"
+	"github.com/YOUR_USERNAME/go-news/api/internal/reader"
+	"github.com/YOUR_USERNAME/go-news/api/internal/rules"
+	"github.com/YOUR_USERNAME/go-news/api/internal/webhook"
+	"github.com/YOUR_USERNAME/go-news/api/internal/websub"
+)
+
+// mockPushReceiver is a test double for PushReceiver.
+type mockPushReceiver struct {
+	subscriptions []websub.Subscription
+}
+
+func (m *mockPushReceiver) Verify(id string, intent websub.Intent) error { return nil }
+
+func (m *mockPushReceiver) Receive(id, contentType, signature string, body io.Reader) error {
+	return nil
+}
+
+func (m *mockPushReceiver) Subscriptions() []websub.Subscription {
+	return m.subscriptions
+}
+
+// v1API mounts every documented handler under /v1, with fakes whose data
+// fills in the optional fields, and returns it with its document.
+func v1API(t *testing.T) (*http.ServeMux, *openapi.Document) {
+	t.Helper()
+	now := time.Now()
+
+	articles := trendingArticles()
+	first := articles.articles[0]
+	first.FeedTitle, first.Tags, first.Language, first.Starred = "Go Blog", []string{"go"}, "en", true
+	articles.articles[1].PublishedEstimated = true
+	articles.articles = append(articles.articles, releaseArticles().articles...)
+
+	broker := events.NewBroker(8)
+	broker.Publish([]*feed.Article{first})
+
+	engine, err := rules.NewEngine("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := engine.AddRule(rules.Rule{Name: "go", Match: rules.Match{Title: "go"}, Actions: rules.Actions{Tags: []string{"go"}}}); err != nil {
+		t.Fatal(err)
+	}
+	dispatcher, err := webhook.NewDispatcher(webhook.Options{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := &mockFeedStatuses{statuses: []feed.FetchStatus{{
+		URL: "https://down.example/rss", LastAttempt: now, LastSuccess: now.Add(-time.Hour),
+		LastError: "unexpected status code: 503", ConsecutiveFailures: 2, RetryAt: now.Add(time.Hour),
+	}}}
+	schedule := &mockSchedule{next: map[string]time.Time{"https://down.example/rss": now.Add(time.Hour)}}
+	subscriber := &mockSubscriber{
+		candidates: map[string][]feed.Candidate{
+			"blog.example": {{URL: "https://blog.example/rss.xml", Title: "Blog", Type: "application/rss+xml", Source: reader.SourceLink}},
+		},
+		errs: map[string]error{"": reader.ErrInvalidURL},
+	}
+	lease := now.Add(24 * time.Hour)
+	receiver := &mockPushReceiver{subscriptions: []websub.Subscription{{
+		ID: "abc", FeedURL: "https://blog.example/rss.xml", Topic: "https://blog.example/rss.xml",
+		Hub: "https://hub.example/", State: "active", LeaseExpires: &lease, LastPush: &now,
+	}}}
+
+	var routes []handlers.Route
+	for _, h := range []interface{ Routes() []handlers.Route }{
+		handlers.New(articles),
+		handlers.NewStoryHandlers(releaseArticles()),
+		handlers.NewTrendHandlers(articles),
+		handlers.NewSummaryHandlers(articles, &mockSummarizer{}),
+		handlers.NewStreamHandlers(broker, time.Minute),
+		handlers.NewWebhookHandlers(dispatcher),
+		handlers.NewRuleHandlers(engine, articles),
+		handlers.NewFeedHandlers(statuses, schedule, mockSubscriptions{}),
+		handlers.NewSubscriptionHandlers(subscriber),
+		handlers.NewWebSubHandlers(receiver),
+	} {
+		routes = append(routes, h.Routes()...)
+	}
+	mux := http.NewServeMux()
+	handlers.RegisterV1(mux, routes...)
+	return mux, openapi.Build(openapi.Info{Title: "test", Version: "1"}, handlers.V1Endpoints(routes...))
+}
+
+// TestOpenAPIMatchesHandlers calls every operation in the document and
+// checks that handlers answer with a status it declares and a body that
+// matches its schema, so handlers and document can't drift apart.
+func TestOpenAPIMatchesHandlers(t *testing.T) {
+	mux, doc := v1API(t)
+
+	const rule = `{"name":"mute","match":{"title":"weekly"},"actions":{"drop":true}}`
+	tests := []struct {
+		method, target, body string
+		want                 int
+	}{
+		{"GET", "/v1/articles?count=2&q=go&feed=Go+Blog", "", 200},
+		{"GET", "/v1/articles?count=2", "", 200},
+		{"GET", "/v1/articles?cursor=bogus", "", 400},
+		{"GET", "/v1/articles?count=ten", "", 400},
+		{"GET", "/v1/stories?count=5", "", 200},
+		{"GET", "/v1/trends?window=24h", "", 200},
+		{"GET", "/v1/trends?window=forever", "", 400},
+		{"GET", "/v1/summary?focus=trends", "", 200},
+		{"GET", "/v1/articles/stream", "", 200},
+		{"GET", "/v1/rules", "", 200},
+		{"POST", "/v1/rules", rule, 201},
+		{"POST", "/v1/rules", `{"name":"empty"}`, 400},
+		{"POST", "/v1/rules/dry-run?count=10", rule, 200},
+		{"GET", "/v1/rules/missing", "", 404},
+		{"PUT", "/v1/rules/missing", rule, 404},
+		{"DELETE", "/v1/rules/missing", "", 404},
+		{"GET", "/v1/webhooks", "", 200},
+		{"POST", "/v1/webhooks", `{"url":"https://hooks.example/in","filter":{"keywords":["go"]}}`, 201},
+		{"POST", "/v1/webhooks", `{"url":"not a url"}`, 400},
+		{"DELETE", "/v1/webhooks/missing", "", 404},
+		{"GET", "/v1/webhooks/missing/deliveries", "", 404},
+		{"GET", "/v1/feeds", "", 200},
+		{"GET", "/v1/feeds/" + feed.ID("https://down.example/rss") + "/status", "", 200},
+		{"GET", "/v1/feeds/missing/status", "", 404},
+		{"GET", "/v1/discover?url=blog.example", "", 200},
+		{"GET", "/v1/discover", "", 400},
+		{"POST", "/v1/feeds", `{"url":"blog.example","settings":{"auth":{"token":"secret"}}}`, 201},
+		{"PUT", "/v1/feeds/abc/settings", `{"headers":{"User-Agent":"go-news"},"proxy":"http://proxy.example:3128"}`, 200},
+		{"PUT", "/v1/feeds/abc/settings", `null`, 200},
+		{"DELETE", "/v1/feeds/abc", "", 204},
+		{"GET", "/v1/websub/subscriptions", "", 200},
+	}
+
+	// Saved rules and webhooks, once created, are fetched by ID too
+	created := map[string]string{}
+	tests = append(tests, []struct {
+		method, target, body string
+		want                 int
+	}{
+		{"GET", "/v1/rules/{rule}", "", 200},
+		{"PUT", "/v1/rules/{rule}", rule, 200},
+		{"GET", "/v1/rules/{rule}/dry-run", "", 200},
+		{"DELETE", "/v1/rules/{rule}", "", 204},
+		{"GET", "/v1/webhooks/{webhook}/deliveries", "", 200},
+		{"DELETE", "/v1/webhooks/{webhook}", "", 204},
+	}...)
+
+	exercised := map[string]bool{}
+	for _, tt := range tests {
+		target := tt.target
+		for name, id := range created {
+			target = strings.ReplaceAll(target, "{"+name+"}", id)
+		}
+		name := tt.method + " " + target
+		ctx, cancel := context.WithCancel(context.Background())
+		if strings.HasSuffix(target, "/stream") {
+			cancel() // The stream ends once its replay is written
+		}
+		req := httptest.NewRequest(tt.method, target, strings.NewReader(tt.body)).WithContext(ctx)
+		_, pattern := mux.Handler(req)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		cancel()
+
+		method, path, _ := strings.Cut(pattern, " ")
+		op := doc.Operation(method, path)
+		if op == nil {
+			t.Errorf("%s: served by %q, which isn't documented", name, pattern)
+			continue
+		}
+		if rec.Code != tt.want {
+			t.Errorf("%s: status %d, want %d: %s", name, rec.Code, tt.want, rec.Body)
+			continue
+		}
+		response := op.Responses[strconv.Itoa(rec.Code)]
+		if response == nil {
+			t.Errorf("%s: status %d isn't documented", name, rec.Code)
+			continue
+		}
+		if rec.Code < 400 {
+			exercised[pattern] = true
+			if tt.body != "" {
+				if err := validateJSON(doc, op.RequestBody.Content["application/json"], []byte(tt.body)); err != nil {
+					t.Errorf("%s: request body: %v", name, err)
+				}
+			}
+		}
+		if len(response.Content) == 0 {
+			if rec.Body.Len() > 0 {
+				t.Errorf("%s: undocumented body %s", name, rec.Body)
+			}
+			continue
+		}
+		contentType, _, _ := strings.Cut(rec.Header().Get("Content-Type"), ";")
+		media, ok := response.Content[contentType]
+		if !ok {
+			t.Errorf("%s: content type %q isn't documented", name, contentType)
+			continue
+		}
+		if contentType != "application/json" && contentType != openapi.ProblemContentType {
+			continue
+		}
+		if err := validateJSON(doc, media, rec.Body.Bytes()); err != nil {
+			t.Errorf("%s: response body: %v\n%s", name, err, rec.Body)
+		}
+
+		var body struct {
+			ID string `json:"id"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &body)
+		switch {
+		case rec.Code != http.StatusCreated:
+		case pattern == "POST /v1/rules":
+			created["rule"] = body.ID
+		case pattern == "POST /v1/webhooks":
+			created["webhook"] = body.ID
+		}
+	}
+
+	for _, operation := range doc.Operations() {
+		if !exercised[operation] {
+			t.Errorf("%s is documented but never answered successfully", operation)
+		}
+	}
+}
+
+// validateJSON checks that data matches media's schema.
+func validateJSON(doc *openapi.Document, media openapi.MediaType, data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	return doc.Validate(media.Schema, v)
+}
+
+// TestUnversionedRoutesAreDeprecated checks that the routes predating
+// /v1 still answer, and point at their successor.
+func TestUnversionedRoutesAreDeprecated(t *testing.T) {
+	mux := http.NewServeMux()
+	handlers.New(trendingArticles()).RegisterRoutes(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/articles?count=1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d", rec.Code)
+	}
+	if got := rec.Header().Get("Deprecation"); got != "true" {
+		t.Errorf("Deprecation = %q, want true", got)
+	}
+	if got := rec.Header().Values("Link"); len(got) == 0 || got[0] != `</v1/articles>; rel="successor-version"` {
+		t.Errorf("Link = %q, want the /v1 successor first", got)
+	}
+	var legacy []map[string]any
+	if err := json.NewDecoder(rec.Body).Decode(&legacy); err != nil || len(legacy) != 1 || legacy[0]["Title"] == nil {
+		t.Errorf("legacy body = %v, %v; want the original article array", legacy, err)
+	}
+}
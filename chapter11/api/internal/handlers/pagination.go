@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+)
+
+// =============================================================================
+// PAGINATION - Cursors over newest-first article lists
+// =============================================================================
+
+// errInvalidCursor is returned for a cursor this server didn't issue.
+var errInvalidCursor = errors.New("invalid cursor")
+
+// encodeCursor returns the cursor of the page following article: its
+// publication time and ID, opaque to clients.
+func encodeCursor(article *feed.Article) string {
+	var nanos int64
+	if article.Published != nil {
+		nanos = article.Published.UnixNano()
+	}
+	raw := strconv.FormatInt(nanos, 10) + ":" + feed.ArticleID(article)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// afterCursor returns the articles, newest first, that follow the one the
+// cursor was issued for. If that article is no longer among them, the
+// page resumes at the first article published before it.
+func afterCursor(articles []*feed.Article, cursor string) ([]*feed.Article, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	nanosStr, id, ok := strings.Cut(string(raw), ":")
+	nanos, err := strconv.ParseInt(nanosStr, 10, 64)
+	if !ok || err != nil || id == "" {
+		return nil, errInvalidCursor
+	}
+
+	for i, article := range articles {
+		if feed.ArticleID(article) == id {
+			return articles[i+1:], nil
+		}
+	}
+	published := time.Unix(0, nanos)
+	for i, article := range articles {
+		if article.Published == nil || article.Published.Before(published) {
+			return articles[i:], nil
+		}
+	}
+	return nil, nil
+}
+
+// setNextLink points the Link header at the page after last, keeping the
+// request's other query parameters, and returns the page's cursor.
+func setNextLink(w http.ResponseWriter, r *http.Request, last *feed.Article) string {
+	cursor := encodeCursor(last)
+	query := r.URL.Query()
+	query.Set("cursor", cursor)
+	next := url.URL{Path: r.URL.Path, RawQuery: query.Encode()}
+	w.Header().Add("Link", "<"+next.String()+`>; rel="next"`)
+	return cursor
+}
+
+// Why cursors rather than offsets:
+//
+// - New articles arrive at the front of the list while a client pages
+//   through it, so an offset would repeat articles; a cursor names the
+//   last article seen and the next page starts after it.
+// - The cursor carries the article's publication time as well as its ID,
+//   so a page can still resume if that article has been dropped.
+// - The next page is announced in a Link header, as RFC 8288 describes,
+//   which leaves the legacy response body the plain array it always was;
+//   /v1 bodies carry the cursor too.
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/dto"
+	"github.com/YOUR_USERNAME/go-news/api/internal/openapi"
+	"github.com/YOUR_USERNAME/go-news/api/internal/reader"
+	"github.com/YOUR_USERNAME/go-news/api/internal/rules"
+	"github.com/YOUR_USERNAME/go-news/api/internal/subscription"
+	"github.com/YOUR_USERNAME/go-news/api/internal/webhook"
+)
+
+// =============================================================================
+// PROBLEMS - application/problem+json error responses
+// =============================================================================
+
+// problemTypeBase prefixes the slug of each problem kind to make its type.
+const problemTypeBase = "/problems/"
+
+// problemKind is one type of problem: what clients branch on, its title
+// and the status it is served with.
+type problemKind struct {
+	slug   string
+	title  string
+	status int
+}
+
+// The kinds of problem the API answers with.
+var (
+	problemInvalid       = problemKind{"invalid-request", "The request is invalid", http.StatusBadRequest}
+	problemNotFound      = problemKind{"not-found", "The resource was not found", http.StatusNotFound}
+	problemMethod        = problemKind{"method-not-allowed", "The method is not allowed on this resource", http.StatusMethodNotAllowed}
+	problemConflict      = problemKind{"conflict", "The request conflicts with the resource's state", http.StatusConflict}
+	problemUnprocessable = problemKind{"unprocessable", "The request is valid but can't be carried out", http.StatusUnprocessableEntity}
+	problemRateLimited   = problemKind{"rate-limited", "Too many requests", http.StatusTooManyRequests}
+	problemInternal      = problemKind{"internal", "The server failed to handle the request", http.StatusInternalServerError}
+	problemUpstream      = problemKind{"upstream-failure", "A service the request depends on failed", http.StatusBadGateway}
+)
+
+// Errors handlers report that aren't some other package's.
+var (
+	// errInvalidCount is a ?count= that isn't a positive whole number.
+	errInvalidCount = errors.New("invalid count")
+
+	// errNoArticles is asking for a summary before any article is stored.
+	errNoArticles = errors.New("no articles available")
+
+	// errSummarizer is the summarizer failing to write a report.
+	errSummarizer = errors.New("summarizer failed")
+)
+
+// domainProblems classifies the errors handlers meet, so that each is
+// the same problem wherever it surfaces. The first match wins.
+var domainProblems = []struct {
+	err  error
+	kind problemKind
+}{
+	{errInvalidCount, problemInvalid},
+	{errInvalidCursor, problemInvalid},
+	{reader.ErrInvalidURL, problemInvalid},
+	{reader.ErrInvalidSettings, problemInvalid},
+	{rules.ErrNotFound, problemNotFound},
+	{subscription.ErrNotFound, problemNotFound},
+	{webhook.ErrNotFound, problemNotFound},
+	{errNoArticles, problemNotFound},
+	{subscription.ErrExists, problemConflict},
+	{subscription.ErrConfigured, problemConflict},
+	{reader.ErrNoFeedFound, problemUnprocessable},
+	{reader.ErrDisallowed, problemUnprocessable},
+	{subscription.ErrNoSecretKey, problemUnprocessable},
+	{errSummarizer, problemUpstream},
+}
+
+// writeProblem answers r with a problem of kind, detail saying what went
+// wrong with this request.
+func writeProblem(w http.ResponseWriter, r *http.Request, kind problemKind, detail string) {
+	w.Header().Set("Content-Type", openapi.ProblemContentType)
+	w.WriteHeader(kind.status)
+	json.NewEncoder(w).Encode(dto.Problem{
+		Type:      problemTypeBase + kind.slug,
+		Title:     kind.title,
+		Status:    kind.status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		RequestID: requestIDFrom(r.Context()),
+	})
+}
+
+// writeError answers r with the problem err is: the kind of the domain
+// error it wraps, or fallback. Internal errors are logged rather than
+// shown, since their text is for operators.
+func writeError(w http.ResponseWriter, r *http.Request, err error, fallback problemKind) {
+	kind := fallback
+	for _, p := range domainProblems {
+		if errors.Is(err, p.err) {
+			kind = p.kind
+			break
+		}
+	}
+	detail := err.Error()
+	if kind == problemInternal {
+		slog.Error("request failed", "method", r.Method, "path", r.URL.Path,
+			"request_id", requestIDFrom(r.Context()), "error", err)
+		detail = ""
+	}
+	writeProblem(w, r, kind, detail)
+}
+
+// WithProblems answers requests no route of mux matches with problems,
+// where ServeMux would write plain-text 404 and 405 responses.
+func WithProblems(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, pattern := mux.Handler(r); pattern == "" {
+			w = &unmatchedWriter{ResponseWriter: w, r: r}
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// unmatchedWriter replaces ServeMux's error response with a problem.
+type unmatchedWriter struct {
+	http.ResponseWriter
+	r       *http.Request
+	written bool
+}
+
+func (u *unmatchedWriter) WriteHeader(code int) {
+	if u.written {
+		return
+	}
+	u.written = true
+	if code == http.StatusMethodNotAllowed {
+		allowed := strings.Join(u.Header().Values("Allow"), ", ")
+		writeProblem(u.ResponseWriter, u.r, problemMethod, fmt.Sprintf("%s is not allowed on %s; use %s", u.r.Method, u.r.URL.Path, allowed))
+		return
+	}
+	writeProblem(u.ResponseWriter, u.r, problemNotFound, "No route matches "+u.r.URL.Path)
+}
+
+// Write discards ServeMux's plain-text message.
+func (u *unmatchedWriter) Write(p []byte) (int, error) {
+	u.WriteHeader(http.StatusNotFound)
+	return len(p), nil
+}
+
+// Why problem details:
+//
+// - Plain-text errors left clients matching on sentences such as "No
+//   articles available". A problem's type is a stable identifier, and
+//   its detail stays free to change.
+// - Domain errors are classified in one table, so "not found" from rules,
+//   subscriptions and webhooks is the same problem with the same status.
+// - The request ID in the body and the X-Request-ID header is also in the
+//   log line of an internal error, whose text clients never see.
+// - WebSub callbacks keep plain-text answers: hubs read only the status.
@@ -0,0 +1,151 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/dto"
+	"github.com/YOUR_USERNAME/go-news/api/internal/handlers"
+	"github.com/YOUR_USERNAME/go-news/api/internal/openapi"
+	"github.com/YOUR_USERNAME/go-news/api/internal/rules"
+	"github.com/YOUR_USERNAME/go-news/newsroom"
+)
+
+// failingSummarizer stands in for a summarizer whose model is down.
+type failingSummarizer struct{}
+
+func (failingSummarizer) Summarize(ctx context.Context, articles []newsroom.Article) (string, error) {
+	return "", errors.New("connection refused")
+}
+
+// decodeProblem checks that rec holds a problem and returns it.
+func decodeProblem(t *testing.T, rec *httptest.ResponseRecorder) dto.Problem {
+	t.Helper()
+	if ct := rec.Header().Get("Content-Type"); ct != openapi.ProblemContentType {
+		t.Fatalf("Content-Type = %q, want %q; body %s", ct, openapi.ProblemContentType, rec.Body)
+	}
+	var problem dto.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil {
+		t.Fatal(err)
+	}
+	if problem.Status != rec.Code {
+		t.Errorf("problem status %d, response status %d", problem.Status, rec.Code)
+	}
+	return problem
+}
+
+func TestProblems(t *testing.T) {
+	engine, err := rules.NewEngine("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	articles := trendingArticles()
+	mux := http.NewServeMux()
+	handlers.New(articles).RegisterRoutes(mux)
+	handlers.NewStoryHandlers(articles).RegisterRoutes(mux)
+	handlers.NewTrendHandlers(articles).RegisterRoutes(mux)
+	handlers.NewSummaryHandlers(articles, failingSummarizer{}).RegisterRoutes(mux)
+	handlers.NewRuleHandlers(engine, articles).RegisterRoutes(mux)
+	empty := http.NewServeMux()
+	handlers.NewSummaryHandlers(&mockArticleReader{}, &mockSummarizer{}).RegisterRoutes(empty)
+	server := handlers.RequestID(handlers.WithProblems(mux))
+
+	tests := []struct {
+		name       string
+		handler    http.Handler
+		method     string
+		target     string
+		wantStatus int
+		wantType   string
+		wantDetail string
+	}{
+		{"non-numeric count", server, "GET", "/articles?count=ten", 400, "/problems/invalid-request", `invalid count "ten"`},
+		{"zero count", server, "GET", "/stories?count=0", 400, "/problems/invalid-request", `invalid count "0"`},
+		{"negative count", server, "GET", "/trends?count=-3", 400, "/problems/invalid-request", `invalid count "-3"`},
+		{"summary count", server, "GET", "/summary?count=1.5", 400, "/problems/invalid-request", `invalid count "1.5"`},
+		{"dry run count", server, "POST", "/rules/dry-run?count=lots", 400, "/problems/invalid-request", `invalid count "lots"`},
+		{"bad cursor", server, "GET", "/articles?cursor=!", 400, "/problems/invalid-request", "invalid cursor"},
+		{"bad window", server, "GET", "/trends?window=forever", 400, "/problems/invalid-request", "window"},
+		{"unknown rule", server, "GET", "/rules/missing", 404, "/problems/not-found", "rule not found"},
+		{"summarizer down", server, "GET", "/summary", 502, "/problems/upstream-failure", "connection refused"},
+		{"no articles", empty, "GET", "/summary", 404, "/problems/not-found", "no articles available"},
+		{"no route", server, "GET", "/nothing/here", 404, "/problems/not-found", "No route matches /nothing/here"},
+		{"wrong method", server, "DELETE", "/articles", 405, "/problems/method-not-allowed", "use GET, HEAD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			body := strings.NewReader(`{"name":"go","match":{"title":"go"},"actions":{"star":true}}`)
+			tt.handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, body))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			problem := decodeProblem(t, rec)
+			if problem.Type != tt.wantType || problem.Title == "" || !strings.Contains(problem.Detail, tt.wantDetail) {
+				t.Errorf("problem = %+v, want type %s with detail containing %q", problem, tt.wantType, tt.wantDetail)
+			}
+			if path, _, _ := strings.Cut(tt.target, "?"); problem.Instance != path {
+				t.Errorf("instance = %q, want %q", problem.Instance, path)
+			}
+		})
+	}
+}
+
+func TestProblemRequestID(t *testing.T) {
+	mux := http.NewServeMux()
+	handlers.NewRuleHandlers(nil, nil).RegisterRoutes(mux)
+	server := handlers.RequestID(handlers.WithProblems(mux))
+
+	tests := []struct {
+		name   string
+		header string
+		want   string // "" for a generated ID
+	}{
+		{"generated", "", ""},
+		{"from the proxy", "edge-4f2a", "edge-4f2a"},
+		{"unprintable replaced", "bad\tid", ""},
+		{"too long replaced", strings.Repeat("x", 65), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/nowhere", nil)
+			if tt.header != "" {
+				req.Header.Set(handlers.RequestIDHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+
+			id := rec.Header().Get(handlers.RequestIDHeader)
+			if problem := decodeProblem(t, rec); problem.RequestID != id {
+				t.Errorf("body request_id %q, header %q", problem.RequestID, id)
+			}
+			switch {
+			case tt.want != "" && id != tt.want:
+				t.Errorf("request ID = %q, want %q", id, tt.want)
+			case tt.want == "" && (len(id) != 16 || id == tt.header):
+				t.Errorf("request ID = %q, want a generated one", id)
+			}
+		})
+	}
+}
+
+func TestRateLimitProblem(t *testing.T) {
+	limiter := handlers.NewRateLimiter(1, 1)
+	server := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	server.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/articles", nil))
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/articles", nil))
+
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("status %d, Retry-After %q; want 429 with Retry-After", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	if problem := decodeProblem(t, rec); problem.Type != "/problems/rate-limited" {
+		t.Errorf("type = %q", problem.Type)
+	}
+}
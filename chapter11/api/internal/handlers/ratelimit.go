@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// RATE LIMITING - Per-client token buckets as HTTP middleware
+// =============================================================================
+
+// RateLimiter limits requests per client IP using token buckets.
+// Limits can be changed at runtime, for example on a config reload.
+type RateLimiter struct {
+	mu      sync.Mutex
+	rate    float64 // Tokens added per second; 0 disables limiting
+	burst   int     // Bucket capacity
+	clients map[string]*bucket
+	pruned  time.Time // When idle buckets were last dropped
+	now     func() time.Time
+}
+
+// bucket is one client's token bucket.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// idleClientTTL is how long an unused bucket is kept before pruning.
+const idleClientTTL = 10 * time.Minute
+
+// NewRateLimiter creates a limiter allowing rate requests per second per
+// client with the given burst. A rate of zero disables limiting.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		rate:    rate,
+		burst:   burst,
+		clients: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// SetLimit changes the rate and burst for all clients.
+func (l *RateLimiter) SetLimit(rate float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rate = rate
+	l.burst = burst
+}
+
+// Middleware rejects requests over the limit with a 429 Too Many Requests
+// problem and a Retry-After header.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if wait, ok := l.allow(clientIP(r)); !ok {
+			seconds := int(math.Ceil(wait.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+			writeProblem(w, r, problemRateLimited, fmt.Sprintf("This client is over its request limit; retry after %d seconds", seconds))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allow takes a token for client. When none is available it returns how
+// long until the next one.
+func (l *RateLimiter) allow(client string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate <= 0 {
+		return 0, true
+	}
+
+	now := l.now()
+	b, ok := l.clients[client]
+	if !ok {
+		l.pruneLocked(now)
+		b = &bucket{tokens: float64(l.burst), last: now}
+		l.clients[client] = b
+	}
+
+	// Refill for the time elapsed since the last request
+	b.tokens = math.Min(float64(l.burst), b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / l.rate * float64(time.Second)), false
+	}
+	b.tokens--
+	return 0, true
+}
+
+// pruneLocked drops buckets idle for longer than idleClientTTL. It scans
+// the clients at most once per idleClientTTL, so a burst of new clients
+// doesn't rescan the map for each one.
+func (l *RateLimiter) pruneLocked(now time.Time) {
+	if now.Sub(l.pruned) < idleClientTTL {
+		return
+	}
+	l.pruned = now
+	for client, b := range l.clients {
+		if now.Sub(b.last) > idleClientTTL {
+			delete(l.clients, client)
+		}
+	}
+}
+
+// clientIP returns the remote IP without its port.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// =============================================================================
+// REQUEST IDS - Correlating responses with logs
+// =============================================================================
+
+// RequestIDHeader carries a request's ID, in requests and responses.
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey is the context key of a request's ID.
+type requestIDKey struct{}
+
+// RequestID gives every request an ID, reported in the X-Request-ID
+// response header and in problem bodies. An ID the client or a proxy
+// already set is kept if it is short and printable.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+	})
+}
+
+// requestIDFrom returns the ID RequestID gave the request, or "".
+func requestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// validRequestID reports whether id is safe to echo in headers and logs:
+// 1 to 64 visible ASCII characters.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 64 {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns 16 random hex digits.
+func newRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/dto"
+	"github.com/YOUR_USERNAME/go-news/api/internal/openapi"
+)
+
+// =============================================================================
+// ROUTES - Versioned mounting and the OpenAPI document
+// =============================================================================
+
+// V1Prefix is where the versioned API is mounted.
+const V1Prefix = "/v1"
+
+// Route is an endpoint's documentation and the handler serving it. Paths
+// are relative to the version prefix.
+type Route struct {
+	openapi.Endpoint
+	Handler http.HandlerFunc
+}
+
+// versionKey marks requests that arrived through a /v1 route.
+type versionKey struct{}
+
+// isV1 reports whether r arrived through a /v1 route, so the handler
+// answers with the versioned DTOs rather than the legacy bodies.
+func isV1(r *http.Request) bool {
+	v1, _ := r.Context().Value(versionKey{}).(bool)
+	return v1
+}
+
+// location returns path as a Location header for r: under V1Prefix if r
+// came through a /v1 route.
+func location(r *http.Request, path string) string {
+	if isV1(r) {
+		return V1Prefix + path
+	}
+	return path
+}
+
+// register mounts routes at their unversioned paths. These are the
+// deprecated routes that predate /v1: responses say so and point at
+// their successor.
+func register(mux *http.ServeMux, routes []Route) {
+	for _, route := range routes {
+		successor := `<` + V1Prefix + route.Path + `>; rel="successor-version"`
+		handler := route.Handler
+		mux.HandleFunc(route.Method+" "+route.Path, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Add("Link", successor)
+			handler(w, r)
+		})
+	}
+}
+
+// RegisterV1 mounts routes under V1Prefix.
+func RegisterV1(mux *http.ServeMux, routes ...Route) {
+	for _, route := range routes {
+		handler := route.Handler
+		mux.HandleFunc(route.Method+" "+V1Prefix+route.Path, func(w http.ResponseWriter, r *http.Request) {
+			handler(w, r.WithContext(context.WithValue(r.Context(), versionKey{}, true)))
+		})
+	}
+}
+
+// V1Endpoints returns the documentation of routes at their /v1 paths.
+// Errors are problems, and any route may be rate limited.
+func V1Endpoints(routes ...Route) []openapi.Endpoint {
+	endpoints := make([]openapi.Endpoint, len(routes))
+	for i, route := range routes {
+		endpoints[i] = route.Endpoint
+		endpoints[i].Path = V1Prefix + route.Path
+		endpoints[i].Errors = append(slices.Clip(route.Errors), http.StatusTooManyRequests)
+		endpoints[i].ErrorBody = dto.Problem{}
+	}
+	return endpoints
+}
+
+// OpenAPIHandler serves doc, as GET /openapi.json.
+func OpenAPIHandler(doc *openapi.Document) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, doc)
+	}
+}
+
+// IndexHandler serves GET /{$}: the service, where its OpenAPI document is,
+// a one-line summary of each of its operations and of the routes outside
+// it, and a note on the unversioned routes.
+func IndexHandler(doc *openapi.Document, other map[string]string) http.HandlerFunc {
+	endpoints := make(map[string]string, len(other))
+	for key, summary := range other {
+		endpoints[key] = summary
+	}
+	for path, item := range doc.Paths {
+		for method, op := range *item {
+			endpoints[strings.ToUpper(method)+" "+path] = op.Summary
+		}
+	}
+	keys := make([]string, 0, len(endpoints))
+	for key := range endpoints {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"service":   doc.Info.Title,
+			"version":   doc.Info.Version,
+			"openapi":   "/openapi.json",
+			"endpoints": endpoints,
+			"deprecated": "The /v1 routes are also served without the prefix, with their " +
+				"original bodies; those responses carry Deprecation and successor-version Link headers",
+		})
+	}
+}
+
+// Query parameters shared by several routes.
+var (
+	feedParam = openapi.Param{
+		Name: "feed", Type: "string", Repeated: true,
+		Description: "Only articles from feeds with this title, ignoring case",
+	}
+	keywordParam = openapi.Param{
+		Name: "q", Type: "string",
+		Description: "Only articles mentioning this in title or description, ignoring case",
+	}
+	windowParam = openapi.Param{
+		Name: "window", Type: "string",
+		Description: "The trend window: a Go duration such as 6h, or days such as 7d; up to 30d, default 24h",
+	}
+)
+
+// countParam documents ?count= for things, defaulting to def.
+func countParam(things string, def int) openapi.Param {
+	return openapi.Param{
+		Name: "count", Type: "integer",
+		Description: "How many " + things + ", default " + strconv.Itoa(def),
+	}
+}
+
+// parseCount returns ?count=, or def if it is absent. Anything but a
+// positive whole number is errInvalidCount.
+func parseCount(r *http.Request, def int) (int, error) {
+	countStr := r.URL.Query().Get("count")
+	if countStr == "" {
+		return def, nil
+	}
+	count, err := strconv.Atoi(countStr)
+	if err != nil || count <= 0 {
+		return 0, fmt.Errorf("%w %q: want a positive whole number", errInvalidCount, countStr)
+	}
+	return count, nil
+}
+
+// Why one description drives routing and documentation:
+//
+// - Each handler lists its routes once, with what they accept and
+//   return; mounting and the OpenAPI document both read that list, so a
+//   route can't be served without being documented.
+// - The unversioned routes are the same handlers, mounted without the
+//   prefix. They keep their original bodies so existing clients work,
+//   and the Deprecation header tells them to move to /v1.
+// - Whether a request came through /v1 travels in its context, so a
+//   handler only branches where the two bodies actually differ.
@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/dto"
+	"github.com/YOUR_USERNAME/go-news/api/internal/openapi"
+	"github.com/YOUR_USERNAME/go-news/api/internal/rules"
+)
+
+// =============================================================================
+// RULE HANDLERS - Managing ingest rules and trying them on stored articles
+// =============================================================================
+
+// RuleRegistry manages the rules applied to incoming articles.
+type RuleRegistry interface {
+	AddRule(rule rules.Rule) (rules.Rule, error)
+	Rules() []rules.Rule
+	Rule(id string) (rules.Rule, error)
+	UpdateRule(id string, rule rules.Rule) (rules.Rule, error)
+	RemoveRule(id string) error
+}
+
+// defaultDryRunCount is how many recent articles a dry run scans unless
+// ?count=N says otherwise.
+const defaultDryRunCount = 500
+
+// RuleHandlers exposes rule management over HTTP.
+type RuleHandlers struct {
+	registry RuleRegistry
+	articles ArticleReader
+}
+
+// NewRuleHandlers creates rule handlers backed by registry. Dry runs are
+// evaluated against articles.
+func NewRuleHandlers(registry RuleRegistry, articles ArticleReader) *RuleHandlers {
+	return &RuleHandlers{registry: registry, articles: articles}
+}
+
+// RegisterRoutes mounts the rule routes on the provided mux.
+func (h *RuleHandlers) RegisterRoutes(mux *http.ServeMux) {
+	register(mux, h.Routes())
+}
+
+// Routes describes the rule routes.
+func (h *RuleHandlers) Routes() []Route {
+	scanned := countParam("recent articles scanned", defaultDryRunCount)
+	return []Route{
+		{
+			Endpoint: openapi.Endpoint{
+				Method:   http.MethodGet,
+				Path:     "/rules",
+				Summary:  "Ingest rules in evaluation order",
+				Response: []dto.Rule{},
+			},
+			Handler: h.listHandler,
+		},
+		{
+			Endpoint: openapi.Endpoint{
+				Method:   http.MethodPost,
+				Path:     "/rules",
+				Summary:  "Add a rule that tags, stars, marks read or drops matching incoming articles",
+				Request:  dto.RuleRequest{},
+				Status:   http.StatusCreated,
+				Response: dto.Rule{},
+				Errors:   []int{http.StatusBadRequest},
+			},
+			Handler: h.createHandler,
+		},
+		{
+			Endpoint: openapi.Endpoint{
+				Method:   http.MethodPost,
+				Path:     "/rules/dry-run",
+				Summary:  "The recent articles a rule would have affected, without saving it",
+				Params:   []openapi.Param{scanned},
+				Request:  dto.RuleRequest{},
+				Response: dto.DryRunResult{},
+				Errors:   []int{http.StatusBadRequest},
+			},
+			Handler: h.dryRunHandler,
+		},
+		{
+			Endpoint: openapi.Endpoint{
+				Method:   http.MethodGet,
+				Path:     "/rules/{id}",
+				Summary:  "One rule",
+				Response: dto.Rule{},
+				Errors:   []int{http.StatusNotFound},
+			},
+			Handler: h.getHandler,
+		},
+		{
+			Endpoint: openapi.Endpoint{
+				Method:   http.MethodPut,
+				Path:     "/rules/{id}",
+				Summary:  "Replace a rule, keeping its ID and place in the order",
+				Request:  dto.RuleRequest{},
+				Response: dto.Rule{},
+				Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+			},
+			Handler: h.updateHandler,
+		},
+		{
+			Endpoint: openapi.Endpoint{
+				Method:  http.MethodDelete,
+				Path:    "/rules/{id}",
+				Summary: "Remove a rule",
+				Status:  http.StatusNoContent,
+				Errors:  []int{http.StatusNotFound, http.StatusInternalServerError},
+			},
+			Handler: h.deleteHandler,
+		},
+		{
+			Endpoint: openapi.Endpoint{
+				Method:   http.MethodGet,
+				Path:     "/rules/{id}/dry-run",
+				Summary:  "The recent articles a saved rule would have affected",
+				Params:   []openapi.Param{scanned},
+				Response: dto.DryRunResult{},
+				Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+			},
+			Handler: h.dryRunSavedHandler,
+		},
+	}
+}
+
+// decodeRule reads a rule from the request body, answering 400 itself if
+// it can't.
+func decodeRule(w http.ResponseWriter, r *http.Request) (rules.Rule, bool) {
+	var req dto.RuleRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeProblem(w, r, problemInvalid, "Invalid JSON body: "+err.Error())
+		return rules.Rule{}, false
+	}
+	return req.Rule(), true
+}
+
+// createHandler adds a rule. It applies to articles ingested from now on.
+func (h *RuleHandlers) createHandler(w http.ResponseWriter, r *http.Request) {
+	rule, ok := decodeRule(w, r)
+	if !ok {
+		return
+	}
+	rule, err := h.registry.AddRule(rule)
+	if err != nil {
+		writeError(w, r, err, problemInvalid)
+		return
+	}
+
+	w.Header().Set("Location", location(r, "/rules/"+rule.ID))
+	writeJSON(w, http.StatusCreated, dto.FromRule(rule))
+}
+
+// listHandler returns every rule in evaluation order.
+func (h *RuleHandlers) listHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, dto.FromRules(h.registry.Rules()))
+}
+
+// getHandler returns one rule.
+func (h *RuleHandlers) getHandler(w http.ResponseWriter, r *http.Request) {
+	rule, err := h.registry.Rule(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, err, problemInternal)
+		return
+	}
+	writeJSON(w, http.StatusOK, dto.FromRule(rule))
+}
+
+// updateHandler replaces a rule, keeping its ID and place in the order.
+func (h *RuleHandlers) updateHandler(w http.ResponseWriter, r *http.Request) {
+	rule, ok := decodeRule(w, r)
+	if !ok {
+		return
+	}
+	rule, err := h.registry.UpdateRule(r.PathValue("id"), rule)
+	if err != nil {
+		writeError(w, r, err, problemInvalid)
+		return
+	}
+	writeJSON(w, http.StatusOK, dto.FromRule(rule))
+}
+
+// deleteHandler removes a rule.
+func (h *RuleHandlers) deleteHandler(w http.ResponseWriter, r *http.Request) {
+	if err := h.registry.RemoveRule(r.PathValue("id")); err != nil {
+		writeError(w, r, err, problemInternal)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// dryRunHandler shows which recent articles the rule in the body would
+// have affected, without saving it. Supports ?count=N articles scanned.
+func (h *RuleHandlers) dryRunHandler(w http.ResponseWriter, r *http.Request) {
+	rule, ok := decodeRule(w, r)
+	if !ok {
+		return
+	}
+	h.dryRun(w, r, rule)
+}
+
+// dryRunSavedHandler is dryRunHandler for a saved rule.
+func (h *RuleHandlers) dryRunSavedHandler(w http.ResponseWriter, r *http.Request) {
+	rule, err := h.registry.Rule(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, err, problemInternal)
+		return
+	}
+	h.dryRun(w, r, rule)
+}
+
+func (h *RuleHandlers) dryRun(w http.ResponseWriter, r *http.Request, rule rules.Rule) {
+	n, err := parseCount(r, defaultDryRunCount)
+	if err != nil {
+		writeError(w, r, err, problemInvalid)
+		return
+	}
+
+	result, err := rules.DryRun(rule, h.articles.GetRecent(n))
+	if err != nil {
+		writeError(w, r, err, problemInvalid)
+		return
+	}
+	if isV1(r) {
+		writeJSON(w, http.StatusOK, dto.FromDryRun(result))
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,97 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/handlers"
+	"github.com/YOUR_USERNAME/go-news/api/internal/rules"
+)
+
+func TestRuleHandlers(t *testing.T) {
+	engine, err := rules.NewEngine("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	articles := &mockArticleReader{articles: []*feed.Article{
+		{Title: "Weekly Questions Thread", FeedTitle: "golang"},
+		{Title: "Go 1.22 released", FeedTitle: "Go Blog"},
+		{Title: "Weekly questions thread (old)", FeedTitle: "golang"},
+	}}
+	mux := http.NewServeMux()
+	handlers.NewRuleHandlers(engine, articles).RegisterRoutes(mux)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return rec
+	}
+	const mute = `{"name":"mute","match":{"feed":"golang","title":"weekly questions thread"},"actions":{"drop":true}}`
+
+	// Dry run before saving: two stored articles would have been dropped
+	rec := do(http.MethodPost, "/rules/dry-run", mute)
+	var result rules.DryRunResult
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("dry run = %d, %v", rec.Code, err)
+	}
+	if result.Scanned != 3 || result.Matched != 2 || !result.Articles[0].Drop {
+		t.Errorf("dry run = %+v, want 2 of 3 articles dropped", result)
+	}
+	if rec := do(http.MethodPost, "/rules/dry-run?count=1", mute); !strings.Contains(rec.Body.String(), `"scanned":1`) {
+		t.Errorf("dry run with count=1 = %s", rec.Body)
+	}
+
+	// Create, read, update, dry-run and delete a saved rule
+	rec = do(http.MethodPost, "/rules", mute)
+	var created rules.Rule
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil || rec.Code != http.StatusCreated {
+		t.Fatalf("create = %d, %v", rec.Code, err)
+	}
+	if rec.Header().Get("Location") != "/rules/"+created.ID {
+		t.Errorf("Location = %q", rec.Header().Get("Location"))
+	}
+	if rec := do(http.MethodGet, "/rules/"+created.ID, ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"mute"`) {
+		t.Errorf("get = %d %s", rec.Code, rec.Body)
+	}
+	rec = do(http.MethodPut, "/rules/"+created.ID, `{"name":"star","match":{"title":"released"},"actions":{"star":true,"tags":["release"]}}`)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), created.ID) {
+		t.Errorf("update = %d %s", rec.Code, rec.Body)
+	}
+	rec = do(http.MethodGet, "/rules/"+created.ID+"/dry-run", "")
+	if !strings.Contains(rec.Body.String(), `"matched":1`) || !strings.Contains(rec.Body.String(), `"add_tags":["release"]`) {
+		t.Errorf("saved dry run = %s", rec.Body)
+	}
+	if rec := do(http.MethodGet, "/rules", ""); !strings.Contains(rec.Body.String(), `"star"`) {
+		t.Errorf("list = %s", rec.Body)
+	}
+	if rec := do(http.MethodDelete, "/rules/"+created.ID, ""); rec.Code != http.StatusNoContent {
+		t.Errorf("delete = %d", rec.Code)
+	}
+
+	// Errors
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		want   int
+	}{
+		{"unknown field", http.MethodPost, "/rules", `{"match":{"title":"x"},"actions":{"drop":true},"id":"mine"}`, http.StatusBadRequest},
+		{"no criteria", http.MethodPost, "/rules", `{"actions":{"drop":true}}`, http.StatusBadRequest},
+		{"bad regex", http.MethodPost, "/rules/dry-run", `{"match":{"regex":"("},"actions":{"drop":true}}`, http.StatusBadRequest},
+		{"update missing", http.MethodPut, "/rules/missing", mute, http.StatusNotFound},
+		{"update invalid", http.MethodPut, "/rules/missing", `{}`, http.StatusBadRequest},
+		{"get missing", http.MethodGet, "/rules/" + created.ID, "", http.StatusNotFound},
+		{"delete missing", http.MethodDelete, "/rules/" + created.ID, "", http.StatusNotFound},
+		{"dry run missing", http.MethodGet, "/rules/missing/dry-run", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if rec := do(tt.method, tt.target, tt.body); rec.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d (%s)", tt.name, rec.Code, tt.want, strings.TrimSpace(rec.Body.String()))
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/dto"
+	"github.com/YOUR_USERNAME/go-news/api/internal/openapi"
+	"github.com/YOUR_USERNAME/go-news/api/internal/story"
+)
+
+// =============================================================================
+// STORY HANDLERS - Near-duplicate articles grouped into stories
+// =============================================================================
+
+// storyWindow is how many recent articles are grouped into stories. A
+// story's coverage arrives within hours, so older articles rarely join.
+const storyWindow = 500
+
+// StoryHandlers serves recent articles grouped by story.
+type StoryHandlers struct {
+	articles ArticleReader
+}
+
+// NewStoryHandlers creates story handlers over articles.
+func NewStoryHandlers(articles ArticleReader) *StoryHandlers {
+	return &StoryHandlers{articles: articles}
+}
+
+// RegisterRoutes mounts the story routes on the provided mux.
+func (h *StoryHandlers) RegisterRoutes(mux *http.ServeMux) {
+	register(mux, h.Routes())
+}
+
+// Routes describes the story routes.
+func (h *StoryHandlers) Routes() []Route {
+	return []Route{{
+		Endpoint: openapi.Endpoint{
+			Method:   http.MethodGet,
+			Path:     "/stories",
+			Summary:  "Recent stories: near-duplicate articles grouped across feeds",
+			Params:   []openapi.Param{countParam("stories", 10)},
+			Response: []dto.Story{},
+			Errors:   []int{http.StatusBadRequest},
+		},
+		Handler: h.storiesHandler,
+	}}
+}
+
+// storiesHandler returns the most recent stories, each with its
+// representative article and the siblings covering the same news.
+// Supports ?count=N stories, default 10.
+func (h *StoryHandlers) storiesHandler(w http.ResponseWriter, r *http.Request) {
+	n, err := parseCount(r, 10)
+	if err != nil {
+		writeError(w, r, err, problemInvalid)
+		return
+	}
+
+	stories := story.Group(h.articles.GetRecent(storyWindow), story.DefaultMaxDistance)
+	stories = stories[:min(n, len(stories))]
+	if isV1(r) {
+		writeJSON(w, http.StatusOK, dto.FromStories(stories))
+		return
+	}
+	writeJSON(w, http.StatusOK, stories)
+}
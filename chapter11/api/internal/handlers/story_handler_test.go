@@ -0,0 +1,114 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/handlers"
+	"github.com/YOUR_USERNAME/go-news/api/internal/story"
+)
+
+// releaseArticles is one release covered by three feeds, newest first,
+// around an unrelated article.
+func releaseArticles() *mockArticleReader {
+	return &mockArticleReader{articles: []*feed.Article{
+		{Title: "Go 1.23 released", Link: "https://news.example/1", FeedTitle: "HN"},
+		{Title: "Kubernetes 1.31 is out", Link: "https://k8s.example/1", FeedTitle: "Lobsters"},
+		{Title: "Go 1.23 is released!", Link: "https://reddit.example/1", FeedTitle: "Reddit"},
+		{Title: "Go 1.23 is released", Link: "https://go.dev/blog/go1.23", FeedTitle: "Go Blog"},
+	}}
+}
+
+func TestStoriesHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		wantStories  int
+		wantSiblings int // Of the first story
+	}{
+		{"default", "", 2, 2},
+		{"count", "?count=1", 1, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			handlers.NewStoryHandlers(releaseArticles()).RegisterRoutes(mux)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stories"+tt.query, nil))
+
+			var stories []story.Story
+			if err := json.NewDecoder(rec.Body).Decode(&stories); err != nil || rec.Code != http.StatusOK {
+				t.Fatalf("status %d, %v", rec.Code, err)
+			}
+			if len(stories) != tt.wantStories || len(stories[0].Siblings) != tt.wantSiblings {
+				t.Fatalf("got %d stories, first with %d siblings; want %d and %d",
+					len(stories), len(stories[0].Siblings), tt.wantStories, tt.wantSiblings)
+			}
+			if len(stories[0].Feeds) != 3 {
+				t.Errorf("feeds = %v, want all three", stories[0].Feeds)
+			}
+		})
+	}
+}
+
+func TestArticlesHandlerCollapse(t *testing.T) {
+	tests := []struct {
+		query     string
+		wantLinks []string
+	}{
+		{"?collapse=true", []string{"https://news.example/1", "https://k8s.example/1"}},
+		{"?collapse=true&count=1", []string{"https://news.example/1"}},
+		{"?collapse=false", []string{"https://news.example/1", "https://k8s.example/1", "https://reddit.example/1", "https://go.dev/blog/go1.23"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := articleLinks(t, tt.query); !slices.Equal(got, tt.wantLinks) {
+				t.Errorf("links = %v, want %v", got, tt.wantLinks)
+			}
+		})
+	}
+}
+
+func TestArticlesHandlerFilters(t *testing.T) {
+	tests := []struct {
+		query     string
+		wantLinks []string
+	}{
+		{"?feed=hn&feed=REDDIT", []string{"https://news.example/1", "https://reddit.example/1"}},
+		{"?q=RELEASED", []string{"https://news.example/1", "https://reddit.example/1", "https://go.dev/blog/go1.23"}},
+		{"?q=released&count=1", []string{"https://news.example/1"}},
+		{"?q=released&collapse=true", []string{"https://news.example/1"}},
+		{"?q=nothing", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := articleLinks(t, tt.query); !slices.Equal(got, tt.wantLinks) {
+				t.Errorf("links = %v, want %v", got, tt.wantLinks)
+			}
+		})
+	}
+}
+
+// articleLinks returns the links of the articles /articles returns for
+// query over releaseArticles.
+func articleLinks(t *testing.T, query string) []string {
+	t.Helper()
+	mux := http.NewServeMux()
+	handlers.New(releaseArticles()).RegisterRoutes(mux)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/articles"+query, nil))
+
+	var articles []*feed.Article
+	if err := json.NewDecoder(rec.Body).Decode(&articles); err != nil {
+		t.Fatal(err)
+	}
+	links := []string{}
+	for _, a := range articles {
+		links = append(links, a.Link)
+	}
+	return links
+}
@@ -0,0 +1,70 @@
+package handlers_test
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/events"
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/handlers"
+)
+
+// TestStreamHandler verifies replay, filtering and live delivery over a
+// real HTTP connection.
+func TestStreamHandler(t *testing.T) {
+	broker := events.NewBroker(10)
+	_, published, cancel := broker.Subscribe(0)
+	defer cancel()
+	broker.Publish([]*feed.Article{
+		{Title: "Old Go news", FeedTitle: "Go Blog"},      // first
+		{Title: "Rust release", FeedTitle: "Other Blog"},  // first+1
+		{Title: "Go 1.23 released", FeedTitle: "Go Blog"}, // first+2
+	})
+	first := (<-published).ID
+	id := func(n uint64) string { return strconv.FormatUint(first+n, 10) }
+
+	mux := http.NewServeMux()
+	handlers.NewStreamHandlers(broker, time.Hour).RegisterRoutes(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/articles/stream?feed=go+blog", nil)
+	req.Header.Set("Last-Event-ID", id(0))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %q", ct)
+	}
+
+	// Publish a live article once the replay has been read
+	lines := bufio.NewScanner(resp.Body)
+	var ids []string
+	for lines.Scan() {
+		line := lines.Text()
+		if event, ok := strings.CutPrefix(line, "id: "); ok {
+			ids = append(ids, event)
+			if event == id(2) {
+				broker.Publish([]*feed.Article{
+					{Title: "Filtered out", FeedTitle: "Other Blog"}, // first+3
+					{Title: "Live Go post", FeedTitle: "Go Blog"},    // first+4
+				})
+			}
+			if event == id(4) {
+				break
+			}
+		}
+	}
+
+	if want := id(2) + "," + id(4); strings.Join(ids, ",") != want {
+		t.Errorf("expected events %s, got %v", want, ids)
+	}
+}
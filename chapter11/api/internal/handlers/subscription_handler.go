@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/dto"
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/openapi"
+	"github.com/YOUR_USERNAME/go-news/api/internal/subscription"
+)
+
+// =============================================================================
+// SUBSCRIPTION HANDLERS - Adding feeds by website URL
+// =============================================================================
+
+// Subscriber discovers feeds and changes what is polled.
+type Subscriber interface {
+	Discover(ctx context.Context, url string) ([]feed.Candidate, error)
+	Subscribe(ctx context.Context, url string, settings *feed.FetchSettings) (subscription.Subscription, []feed.Candidate, error)
+	UpdateSettings(id string, settings *feed.FetchSettings) (subscription.Subscription, error)
+	Unsubscribe(id string) error
+}
+
+// SubscriptionHandlers exposes feed discovery and subscriptions over HTTP.
+type SubscriptionHandlers struct {
+	subscriber Subscriber
+}
+
+// NewSubscriptionHandlers creates subscription handlers backed by subscriber.
+func NewSubscriptionHandlers(subscriber Subscriber) *SubscriptionHandlers {
+	return &SubscriptionHandlers{subscriber: subscriber}
+}
+
+// RegisterRoutes mounts the subscription routes on the provided mux.
+func (h *SubscriptionHandlers) RegisterRoutes(mux *http.ServeMux) {
+	register(mux, h.Routes())
+}
+
+// Routes describes the subscription routes.
+func (h *SubscriptionHandlers) Routes() []Route {
+	return []Route{
+		{
+			Endpoint: openapi.Endpoint{
+				Method:  http.MethodGet,
+				Path:    "/discover",
+				Summary: "The feeds a website offers, without subscribing",
+				Params: []openapi.Param{
+					{Name: "url", Type: "string", Required: true, Description: "A feed, or a web page that links to one"},
+				},
+				Response: []dto.Candidate{},
+				Errors:   []int{http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusBadGateway},
+			},
+			Handler: h.discoverHandler,
+		},
+		{
+			Endpoint: openapi.Endpoint{
+				Method:   http.MethodPost,
+				Path:     "/feeds",
+				Summary:  "Subscribe to a feed, or to the first feed a website links to",
+				Request:  dto.SubscribeRequest{},
+				Status:   http.StatusCreated,
+				Response: dto.SubscribeResponse{},
+				Errors:   []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusBadGateway},
+			},
+			Handler: h.subscribeHandler,
+		},
+		{
+			Endpoint: openapi.Endpoint{
+				Method:  http.MethodDelete,
+				Path:    "/feeds/{id}",
+				Summary: "Unsubscribe from a feed added through the API",
+				Status:  http.StatusNoContent,
+				Errors:  []int{http.StatusNotFound, http.StatusConflict},
+			},
+			Handler: h.unsubscribeHandler,
+		},
+		{
+			Endpoint: openapi.Endpoint{
+				Method:   http.MethodPut,
+				Path:     "/feeds/{id}/settings",
+				Summary:  "Replace a feed's credentials, headers, CA bundle and proxy; null restores the defaults",
+				Request:  (*dto.FetchSettings)(nil),
+				Response: dto.Subscription{},
+				Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity},
+			},
+			Handler: h.settingsHandler,
+		},
+	}
+}
+
+// discoverHandler lists the feeds found at ?url= without subscribing.
+func (h *SubscriptionHandlers) discoverHandler(w http.ResponseWriter, r *http.Request) {
+	candidates, err := h.subscriber.Discover(r.Context(), r.URL.Query().Get("url"))
+	if err != nil {
+		writeError(w, r, err, problemUpstream)
+		return
+	}
+	writeJSON(w, http.StatusOK, dto.FromCandidates(candidates))
+}
+
+// subscribeHandler subscribes to the first feed discovered at the URL.
+func (h *SubscriptionHandlers) subscribeHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.SubscribeRequest
+	if !decodeSubscriptionBody(w, r, &req) {
+		return
+	}
+
+	sub, candidates, err := h.subscriber.Subscribe(r.Context(), req.URL, req.Settings.Settings())
+	if err != nil {
+		writeError(w, r, err, problemUpstream)
+		return
+	}
+
+	w.Header().Set("Location", location(r, "/feeds/"+sub.ID+"/status"))
+	writeJSON(w, http.StatusCreated, dto.SubscribeResponse{
+		Subscription: dto.FromSubscription(sub),
+		Candidates:   dto.FromCandidates(candidates),
+	})
+}
+
+// settingsHandler replaces the fetch settings of a feed added through the
+// API. The body is the settings object; null restores the defaults.
+func (h *SubscriptionHandlers) settingsHandler(w http.ResponseWriter, r *http.Request) {
+	var settings *dto.FetchSettings
+	if !decodeSubscriptionBody(w, r, &settings) {
+		return
+	}
+
+	sub, err := h.subscriber.UpdateSettings(r.PathValue("id"), settings.Settings())
+	if err != nil {
+		writeError(w, r, err, problemInternal)
+		return
+	}
+	writeJSON(w, http.StatusOK, dto.FromSubscription(sub))
+}
+
+// decodeSubscriptionBody decodes a JSON request body into v, writing a 400
+// response and returning false if it is malformed.
+func decodeSubscriptionBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 256<<10)) // Room for a CA bundle
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeProblem(w, r, problemInvalid, "Invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// unsubscribeHandler stops polling a feed added through the API.
+func (h *SubscriptionHandlers) unsubscribeHandler(w http.ResponseWriter, r *http.Request) {
+	if err := h.subscriber.Unsubscribe(r.PathValue("id")); err != nil {
+		writeError(w, r, err, problemInternal)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,12 +2,13 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
-	"strconv"
+	"time"
 
+	"github.com/YOUR_USERNAME/go-news/api/internal/dto"
 	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/openapi"
 	"github.com/YOUR_USERNAME/go-news/newsroom"
 )
 
@@ -25,6 +26,7 @@ type Summarizer interface {
 type SummaryHandlers struct {
 	articles   ArticleReader // For fetching articles
 	summarizer Summarizer    // For AI-powered summarization
+	now        func() time.Time
 }
 
 // NewSummaryHandlers creates handlers with summarization support.
@@ -33,36 +35,74 @@ func NewSummaryHandlers(articles ArticleReader, summarizer Summarizer) *SummaryH
 	return &SummaryHandlers{
 		articles:   articles,
 		summarizer: summarizer,
+		now:        time.Now,
 	}
 }
 
 // RegisterRoutes mounts summary handler routes on the provided mux.
 func (h *SummaryHandlers) RegisterRoutes(mux *http.ServeMux) {
-	mux.HandleFunc("/summary", h.newsReportHandler)
+	register(mux, h.Routes())
+}
+
+// Routes describes the summary routes.
+func (h *SummaryHandlers) Routes() []Route {
+	return []Route{{
+		Endpoint: openapi.Endpoint{
+			Method:  http.MethodGet,
+			Path:    "/summary",
+			Summary: "An AI-generated report on recent or trending articles",
+			Params: []openapi.Param{
+				countParam("articles to cover", 5),
+				{Name: "focus", Type: "string", Enum: []string{"recent", "trends"}, Description: "Cover the latest articles, or those behind trending terms"},
+				windowParam,
+			},
+			Response: dto.Summary{},
+			Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusBadGateway},
+		},
+		Handler: h.newsReportHandler,
+	}}
 }
 
 // newsReportHandler compiles recent articles into an AI-generated news report.
-// Supports ?count=N query parameter to control number of articles.
+// Supports ?count=N query parameter to control number of articles, and
+// ?focus=trends to report on the articles behind the terms trending in
+// ?window= (default 24h) rather than the most recent ones.
 func (h *SummaryHandlers) newsReportHandler(w http.ResponseWriter, r *http.Request) {
-	// Only allow GET requests
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	// Parse count parameter with default of 5
+	n, err := parseCount(r, 5)
+	if err != nil {
+		writeError(w, r, err, problemInvalid)
 		return
 	}
 
-	// Parse count parameter with default of 5
-	n := 5
-	if countStr := r.URL.Query().Get("count"); countStr != "" {
-		if count, err := strconv.Atoi(countStr); err == nil && count > 0 {
-			n = count
+	// Fetch articles from storage: the most recent, or those behind what
+	// is trending when there is anything
+	var articles []*feed.Article
+	var trending []string
+	switch focus := r.URL.Query().Get("focus"); focus {
+	case "", "recent":
+		articles = h.articles.GetRecent(n)
+	case "trends":
+		window, err := parseWindow(r.URL.Query().Get("window"))
+		if err != nil {
+			writeError(w, r, err, problemInvalid)
+			return
+		}
+		report := detectTrends(h.articles, h.now(), window, n)
+		trending = make([]string, len(report.Trends))
+		for i, trend := range report.Trends {
+			trending[i] = trend.Term
+		}
+		if articles = trendingArticles(report, n); len(articles) == 0 {
+			articles = h.articles.GetRecent(n)
 		}
+	default:
+		writeProblem(w, r, problemInvalid, fmt.Sprintf("Invalid focus %q (use recent or trends)", focus))
+		return
 	}
 
-	// Fetch articles from storage
-	articles := h.articles.GetRecent(n)
-
 	if len(articles) == 0 {
-		http.Error(w, "No articles available", http.StatusNotFound)
+		writeError(w, r, errNoArticles, problemNotFound)
 		return
 	}
 
@@ -81,22 +121,16 @@ func (h *SummaryHandlers) newsReportHandler(w http.ResponseWriter, r *http.Reque
 	// Generate AI-powered summary
 	summary, err := h.summarizer.Summarize(r.Context(), newsroomArticles)
 	if err != nil {
-		fmt.Printf("Failed to generate summary: %v\n", err)
-		http.Error(w, "Failed to generate summary", http.StatusInternalServerError)
+		writeError(w, r, fmt.Errorf("%w: %v", errSummarizer, err), problemUpstream)
 		return
 	}
 
 	// Return summary as JSON
-	w.Header().Set("Content-Type", "application/json")
-	response := map[string]interface{}{
-		"article_count": len(articles),
-		"summary":       summary,
-	}
-
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-		return
-	}
+	writeJSON(w, http.StatusOK, dto.Summary{
+		ArticleCount: len(articles),
+		Summary:      summary,
+		Trends:       trending,
+	})
 }
 
 // The beauty of this design:
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/dto"
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/openapi"
+	"github.com/YOUR_USERNAME/go-news/api/internal/trends"
+)
+
+// =============================================================================
+// TREND HANDLERS - Terms rising in recent articles
+// =============================================================================
+
+const (
+	// defaultTrendWindow is the window trends are computed over unless
+	// ?window= says otherwise.
+	defaultTrendWindow = 24 * time.Hour
+
+	// maxTrendWindow bounds ?window=, since the baseline spans several
+	// windows before it.
+	maxTrendWindow = 30 * 24 * time.Hour
+
+	// trendScan is how many recent articles are searched for the window
+	// and its baseline.
+	trendScan = 5000
+)
+
+// TrendHandlers serves the terms rising in recent articles.
+type TrendHandlers struct {
+	articles ArticleReader
+	now      func() time.Time
+}
+
+// NewTrendHandlers creates trend handlers over articles.
+func NewTrendHandlers(articles ArticleReader) *TrendHandlers {
+	return &TrendHandlers{articles: articles, now: time.Now}
+}
+
+// RegisterRoutes mounts the trend routes on the provided mux.
+func (h *TrendHandlers) RegisterRoutes(mux *http.ServeMux) {
+	register(mux, h.Routes())
+}
+
+// Routes describes the trend routes.
+func (h *TrendHandlers) Routes() []Route {
+	return []Route{{
+		Endpoint: openapi.Endpoint{
+			Method:   http.MethodGet,
+			Path:     "/trends",
+			Summary:  "Terms rising against the seven windows before, with their supporting articles",
+			Params:   []openapi.Param{windowParam, countParam("terms", 10)},
+			Response: dto.TrendReport{},
+			Errors:   []int{http.StatusBadRequest},
+		},
+		Handler: h.trendsHandler,
+	}}
+}
+
+// trendsHandler returns the top rising terms of the window ending now,
+// each with its supporting articles. Supports ?window=24h (Go durations,
+// or days such as 7d) and ?count=N terms, default 10.
+func (h *TrendHandlers) trendsHandler(w http.ResponseWriter, r *http.Request) {
+	window, err := parseWindow(r.URL.Query().Get("window"))
+	if err != nil {
+		writeError(w, r, err, problemInvalid)
+		return
+	}
+	n, err := parseCount(r, 10)
+	if err != nil {
+		writeError(w, r, err, problemInvalid)
+		return
+	}
+
+	report := detectTrends(h.articles, h.now(), window, n)
+	if isV1(r) {
+		writeJSON(w, http.StatusOK, dto.FromTrendReport(report))
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// detectTrends computes trends over the articles recent enough to fall in
+// the window or its baseline.
+func detectTrends(articles ArticleReader, now time.Time, window time.Duration, n int) trends.Report {
+	return trends.Detect(articles.GetRecent(trendScan), now, window, n)
+}
+
+// trendingArticles returns up to n articles supporting report's trends,
+// those of the top trend first, each once.
+func trendingArticles(report trends.Report, n int) []*feed.Article {
+	var result []*feed.Article
+	seen := make(map[*feed.Article]bool)
+	for _, trend := range report.Trends {
+		for _, article := range trend.Supporting {
+			if len(result) == n {
+				return result
+			}
+			if !seen[article] {
+				seen[article] = true
+				result = append(result, article)
+			}
+		}
+	}
+	return result
+}
+
+// parseWindow parses a trend window such as "24h" or "7d", returning the
+// default for an empty one.
+func parseWindow(s string) (time.Duration, error) {
+	if s == "" {
+		return defaultTrendWindow, nil
+	}
+	window, err := time.ParseDuration(s)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		var n int
+		n, err = strconv.Atoi(days)
+		window = time.Duration(n) * 24 * time.Hour
+	}
+	if err != nil || window <= 0 || window > maxTrendWindow {
+		return 0, fmt.Errorf("invalid window %q (use a duration such as 6h, 24h or 7d, up to 30d)", s)
+	}
+	return window, nil
+}
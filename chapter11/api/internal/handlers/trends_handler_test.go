@@ -0,0 +1,127 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/handlers"
+	"github.com/YOUR_USERNAME/go-news/api/internal/trends"
+	"github.com/YOUR_USERNAME/go-news/newsroom"
+)
+
+// trendingArticles is a week of routine articles and, in the last hours,
+// three about one release.
+func trendingArticles() *mockArticleReader {
+	at := func(title string, ago time.Duration) *feed.Article {
+		published := time.Now().Add(-ago)
+		return &feed.Article{Title: title, Link: "https://example.com/" + title, Published: &published}
+	}
+	articles := []*feed.Article{
+		at("Go 1.23 released with iterators", time.Hour),
+		at("Go 1.23 is out", 2*time.Hour),
+		at("Weekly Go tips", 3*time.Hour),
+		at("Trying Go 1.23 today", 4*time.Hour),
+	}
+	for i := range 14 {
+		articles = append(articles, at("Weekly Go tips", time.Duration(30+i*10)*time.Hour))
+	}
+	return &mockArticleReader{articles: articles}
+}
+
+func TestTrendsHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantWindow string
+	}{
+		{"default window", "", http.StatusOK, "24h0m0s"},
+		{"hours", "?window=6h", http.StatusOK, "6h0m0s"},
+		{"days", "?window=2d", http.StatusOK, "48h0m0s"},
+		{"invalid", "?window=soon", http.StatusBadRequest, ""},
+		{"negative", "?window=-1h", http.StatusBadRequest, ""},
+		{"too long", "?window=90d", http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			handlers.NewTrendHandlers(trendingArticles()).RegisterRoutes(mux)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/trends"+tt.query, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var report trends.Report
+			if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+				t.Fatal(err)
+			}
+			if report.Window != tt.wantWindow || len(report.Trends) == 0 || report.Trends[0].Term != "go 1.23" {
+				t.Errorf("report = %+v, want go 1.23 trending over %s", report, tt.wantWindow)
+			}
+			if len(report.Trends[0].Supporting) != 3 {
+				t.Errorf("supporting = %d articles, want 3", len(report.Trends[0].Supporting))
+			}
+		})
+	}
+}
+
+// recordingSummarizer remembers the articles it was asked to summarise.
+type recordingSummarizer struct {
+	titles []string
+}
+
+func (s *recordingSummarizer) Summarize(ctx context.Context, articles []newsroom.Article) (string, error) {
+	s.titles = nil
+	for _, a := range articles {
+		s.titles = append(s.titles, a.Title)
+	}
+	return "report", nil
+}
+
+func TestSummaryHandlerFocus(t *testing.T) {
+	tests := []struct {
+		query      string
+		wantStatus int
+		wantTitles []string
+		wantTrends bool
+	}{
+		{"?count=2", http.StatusOK, []string{"Go 1.23 released with iterators", "Go 1.23 is out"}, false},
+		{"?focus=trends&count=3", http.StatusOK, []string{"Go 1.23 released with iterators", "Go 1.23 is out", "Trying Go 1.23 today"}, true},
+		{"?focus=trends&window=bad", http.StatusBadRequest, nil, false},
+		{"?focus=popular", http.StatusBadRequest, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			summarizer := &recordingSummarizer{}
+			mux := http.NewServeMux()
+			handlers.NewSummaryHandlers(trendingArticles(), summarizer).RegisterRoutes(mux)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/summary"+tt.query, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if !slices.Equal(summarizer.titles, tt.wantTitles) {
+				t.Errorf("summarised %q, want %q", summarizer.titles, tt.wantTitles)
+			}
+			var response map[string]any
+			json.NewDecoder(rec.Body).Decode(&response)
+			if _, ok := response["trends"]; ok != tt.wantTrends {
+				t.Errorf("response = %v, want trends listed: %v", response, tt.wantTrends)
+			}
+		})
+	}
+}
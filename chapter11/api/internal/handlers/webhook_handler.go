@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/dto"
+	"github.com/YOUR_USERNAME/go-news/api/internal/openapi"
+	"github.com/YOUR_USERNAME/go-news/api/internal/webhook"
+)
+
+// =============================================================================
+// WEBHOOK HANDLERS - Registering receivers and inspecting deliveries
+// =============================================================================
+
+// WebhookRegistry manages webhook receivers and their delivery logs.
+type WebhookRegistry interface {
+	AddHook(hook webhook.Hook) (webhook.Hook, error)
+	Hooks() []webhook.Hook
+	RemoveHook(id string) error
+	Deliveries(id string) ([]webhook.Attempt, error)
+}
+
+// WebhookHandlers exposes webhook management over HTTP.
+type WebhookHandlers struct {
+	registry WebhookRegistry
+}
+
+// NewWebhookHandlers creates webhook handlers backed by registry.
+func NewWebhookHandlers(registry WebhookRegistry) *WebhookHandlers {
+	return &WebhookHandlers{registry: registry}
+}
+
+// RegisterRoutes mounts the webhook routes on the provided mux.
+// Method-and-path patterns need Go 1.22's enhanced ServeMux.
+func (h *WebhookHandlers) RegisterRoutes(mux *http.ServeMux) {
+	register(mux, h.Routes())
+}
+
+// Routes describes the webhook routes.
+func (h *WebhookHandlers) Routes() []Route {
+	return []Route{
+		{
+			Endpoint: openapi.Endpoint{
+				Method:   http.MethodGet,
+				Path:     "/webhooks",
+				Summary:  "Registered webhooks, oldest first, with secrets redacted",
+				Response: []dto.Webhook{},
+			},
+			Handler: h.listHandler,
+		},
+		{
+			Endpoint: openapi.Endpoint{
+				Method:   http.MethodPost,
+				Path:     "/webhooks",
+				Summary:  "Register a webhook with feed, keyword and tag filters; the only response with its secret",
+				Request:  dto.WebhookRequest{},
+				Status:   http.StatusCreated,
+				Response: dto.Webhook{},
+				Errors:   []int{http.StatusBadRequest},
+			},
+			Handler: h.createHandler,
+		},
+		{
+			Endpoint: openapi.Endpoint{
+				Method:  http.MethodDelete,
+				Path:    "/webhooks/{id}",
+				Summary: "Remove a webhook and its pending deliveries",
+				Status:  http.StatusNoContent,
+				Errors:  []int{http.StatusNotFound, http.StatusInternalServerError},
+			},
+			Handler: h.deleteHandler,
+		},
+		{
+			Endpoint: openapi.Endpoint{
+				Method:   http.MethodGet,
+				Path:     "/webhooks/{id}/deliveries",
+				Summary:  "A webhook's delivery log, newest first",
+				Response: []dto.DeliveryAttempt{},
+				Errors:   []int{http.StatusNotFound, http.StatusInternalServerError},
+			},
+			Handler: h.deliveriesHandler,
+		},
+	}
+}
+
+// createHandler registers a webhook. The response is the only time the
+// signing secret is returned.
+func (h *WebhookHandlers) createHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.WebhookRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeProblem(w, r, problemInvalid, "Invalid JSON body: "+err.Error())
+		return
+	}
+
+	hook, err := h.registry.AddHook(req.Hook())
+	if err != nil {
+		writeError(w, r, err, problemInvalid)
+		return
+	}
+
+	w.Header().Set("Location", location(r, "/webhooks/"+hook.ID))
+	writeJSON(w, http.StatusCreated, dto.FromWebhook(hook))
+}
+
+// listHandler returns all webhooks with secrets redacted.
+func (h *WebhookHandlers) listHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, dto.FromWebhooks(h.registry.Hooks()))
+}
+
+// deleteHandler removes a webhook and its pending deliveries.
+func (h *WebhookHandlers) deleteHandler(w http.ResponseWriter, r *http.Request) {
+	if err := h.registry.RemoveHook(r.PathValue("id")); err != nil {
+		writeError(w, r, err, problemInternal)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// deliveriesHandler returns a webhook's delivery log, newest first.
+func (h *WebhookHandlers) deliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	attempts, err := h.registry.Deliveries(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, err, problemInternal)
+		return
+	}
+	writeJSON(w, http.StatusOK, dto.FromAttempts(attempts))
+}
+
+// writeJSON encodes body as the JSON response with the given status.
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
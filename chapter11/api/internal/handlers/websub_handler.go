@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/dto"
+	"github.com/YOUR_USERNAME/go-news/api/internal/openapi"
+	"github.com/YOUR_USERNAME/go-news/api/internal/reader"
+	"github.com/YOUR_USERNAME/go-news/api/internal/websub"
+)
+
+// =============================================================================
+// WEBSUB HANDLERS - Hub callbacks and push subscription status
+// =============================================================================
+
+// PushReceiver verifies hub intents, receives pushed content and lists
+// push subscriptions.
+type PushReceiver interface {
+	Verify(id string, intent websub.Intent) error
+	Receive(id, contentType, signature string, body io.Reader) error
+	Subscriptions() []websub.Subscription
+}
+
+// WebSubHandlers exposes the WebSub callback and subscription list.
+type WebSubHandlers struct {
+	receiver PushReceiver
+}
+
+// NewWebSubHandlers creates WebSub handlers backed by receiver.
+func NewWebSubHandlers(receiver PushReceiver) *WebSubHandlers {
+	return &WebSubHandlers{receiver: receiver}
+}
+
+// RegisterRoutes mounts the push subscription list on the provided mux.
+func (h *WebSubHandlers) RegisterRoutes(mux *http.ServeMux) {
+	register(mux, h.Routes())
+}
+
+// Routes describes the push subscription routes; the callbacks are for
+// hubs, not API clients, and aren't among them.
+func (h *WebSubHandlers) Routes() []Route {
+	return []Route{{
+		Endpoint: openapi.Endpoint{
+			Method:   http.MethodGet,
+			Path:     "/websub/subscriptions",
+			Summary:  "WebSub push subscriptions with their hub, state and lease expiry",
+			Response: []dto.PushSubscription{},
+		},
+		Handler: h.listHandler,
+	}}
+}
+
+// RegisterCallbacks mounts the callback hubs call. It belongs outside the
+// rate limiter: one hub may push for many feeds at once.
+func (h *WebSubHandlers) RegisterCallbacks(mux *http.ServeMux) {
+	mux.HandleFunc("GET /websub/callback/{id}", h.verifyHandler)
+	mux.HandleFunc("POST /websub/callback/{id}", h.pushHandler)
+}
+
+// listHandler returns every push subscription and its state.
+func (h *WebSubHandlers) listHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, dto.FromPushSubscriptions(h.receiver.Subscriptions()))
+}
+
+// verifyHandler answers a hub's intent verification by echoing
+// hub.challenge, or 404 if we didn't ask for what it is verifying.
+func (h *WebSubHandlers) verifyHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	lease, _ := strconv.Atoi(query.Get("hub.lease_seconds"))
+	intent := websub.Intent{
+		Mode:         query.Get("hub.mode"),
+		Topic:        query.Get("hub.topic"),
+		Challenge:    query.Get("hub.challenge"),
+		LeaseSeconds: lease,
+		Reason:       query.Get("hub.reason"),
+	}
+
+	if err := h.receiver.Verify(r.PathValue("id"), intent); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, intent.Challenge)
+}
+
+// pushHandler ingests content a hub pushed. Unknown callbacks get 410 Gone
+// so the hub stops pushing; bad signatures are acknowledged but dropped,
+// and content over the size limit gets 413.
+func (h *WebSubHandlers) pushHandler(w http.ResponseWriter, r *http.Request) {
+	err := h.receiver.Receive(r.PathValue("id"), r.Header.Get("Content-Type"), r.Header.Get(websub.SignatureHeader), r.Body)
+
+	var tooLarge *reader.BodyTooLargeError
+	switch {
+	case errors.Is(err, websub.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusGone)
+	case errors.Is(err, websub.ErrInvalidSignature):
+		w.WriteHeader(http.StatusAccepted) // WebSub: acknowledge, then ignore
+	case errors.Is(err, websub.ErrTooLarge), errors.As(err, &tooLarge):
+		slog.Warn("rejected WebSub push", "error", err)
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case err != nil:
+		slog.Warn("rejected WebSub push", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
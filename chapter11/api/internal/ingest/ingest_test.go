@@ -0,0 +1,112 @@
+package ingest_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/ingest"
+)
+
+// recorder is a processor that notes the articles it sees, drops those
+// whose title is in drop, and fails or panics on request.
+func recorder(name string, seen *[]string, drop, fail, panics string) ingest.Processor {
+	return ingest.ProcessorFunc{ProcessorName: name, Fn: func(ctx context.Context, article *feed.Article) (bool, error) {
+		*seen = append(*seen, name+":"+article.Title+"@"+ingest.FeedURL(ctx))
+		switch article.Title {
+		case fail:
+			return true, errors.New("boom")
+		case panics:
+			panic("bad article")
+		}
+		return article.Title != drop, nil
+	}}
+}
+
+func articles(titles ...string) []*feed.Article {
+	result := make([]*feed.Article, len(titles))
+	for i, title := range titles {
+		result[i] = &feed.Article{Title: title, Link: "https://example.com/" + title}
+	}
+	return result
+}
+
+func titles(articles []*feed.Article) []string {
+	result := make([]string, len(articles))
+	for i, article := range articles {
+		result[i] = article.Title
+	}
+	return result
+}
+
+func TestPipelineOrderAndDrops(t *testing.T) {
+	var seen []string
+	p := ingest.New(
+		recorder("first", &seen, "b", "", ""),
+		recorder("second", &seen, "c", "", ""),
+	)
+
+	got, err := p.ProcessArticles(context.Background(), "feed", articles("a", "b", "c"))
+	if err != nil {
+		t.Fatalf("ProcessArticles error = %v", err)
+	}
+	if !slices.Equal(titles(got), []string{"a"}) {
+		t.Errorf("kept %v, want [a]", titles(got))
+	}
+	want := []string{"first:a@feed", "second:a@feed", "first:b@feed", "first:c@feed", "second:c@feed"}
+	if !slices.Equal(seen, want) {
+		t.Errorf("processors saw %v, want %v", seen, want)
+	}
+	if names := p.Processors(); !slices.Equal(names, []string{"first", "second"}) {
+		t.Errorf("Processors() = %v", names)
+	}
+}
+
+func TestPipelineErrorsArePerArticle(t *testing.T) {
+	var seen []string
+	p := ingest.New(
+		recorder("flaky", &seen, "", "b", "c"),
+		recorder("after", &seen, "", "", ""),
+	)
+
+	got, err := p.ProcessArticles(context.Background(), "feed", articles("a", "b", "c"))
+	if !slices.Equal(titles(got), []string{"a", "b", "c"}) {
+		t.Errorf("kept %v, want every article despite failures", titles(got))
+	}
+	if !slices.Contains(seen, "after:b@feed") || !slices.Contains(seen, "after:c@feed") {
+		t.Errorf("failed articles didn't continue down the pipeline: %v", seen)
+	}
+
+	var articleErr *ingest.ArticleError
+	if !errors.As(err, &articleErr) || articleErr.Processor != "flaky" || articleErr.Link != "https://example.com/b" {
+		t.Fatalf("error = %v, want an ArticleError from flaky for b", err)
+	}
+	if !strings.Contains(err.Error(), "panic: bad article") {
+		t.Errorf("error = %v, want the panic on c reported", err)
+	}
+}
+
+func TestPipelineStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := ingest.New(ingest.ProcessorFunc{ProcessorName: "cancel", Fn: func(ctx context.Context, article *feed.Article) (bool, error) {
+		cancel()
+		return true, nil
+	}})
+
+	got, err := p.ProcessArticles(ctx, "feed", articles("a", "b"))
+	if !slices.Equal(titles(got), []string{"a"}) || !errors.Is(err, context.Canceled) {
+		t.Errorf("ProcessArticles = %v, %v; want [a] and context.Canceled", titles(got), err)
+	}
+}
+
+func TestNilPipelinePassesThrough(t *testing.T) {
+	var p *ingest.Pipeline
+	in := articles("a")
+	got, err := p.ProcessArticles(context.Background(), "feed", in)
+	if err != nil || len(got) != 1 || got[0] != in[0] {
+		t.Errorf("ProcessArticles = %v, %v; want the input unchanged", got, err)
+	}
+}
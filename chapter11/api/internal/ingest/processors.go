@@ -0,0 +1,194 @@
+package ingest
+
+import (
+	"context"
+	"html"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/subscription"
+	"github.com/YOUR_USERNAME/go-news/api/internal/text"
+)
+
+// =============================================================================
+// PROCESSORS - Built-in cleaning, deduplication and annotation steps
+// =============================================================================
+
+// Sanitize returns a processor that turns titles and descriptions into
+// plain text: markup is removed, with script and style contents, entities
+// are decoded and whitespace is collapsed. Articles left with no title,
+// description or link are dropped.
+func Sanitize() Processor {
+	return ProcessorFunc{ProcessorName: "sanitize", Fn: func(ctx context.Context, article *feed.Article) (bool, error) {
+		article.Title = plainText(article.Title)
+		article.Description = plainText(article.Description)
+		article.Link = strings.TrimSpace(article.Link)
+		return article.Title != "" || article.Description != "" || article.Link != "", nil
+	}}
+}
+
+// plainText strips HTML from s and normalises its whitespace.
+func plainText(s string) string {
+	if !strings.ContainsAny(s, "<&") {
+		return strings.Join(strings.Fields(s), " ")
+	}
+
+	var out strings.Builder
+	for s != "" {
+		start := strings.IndexByte(s, '<')
+		if start < 0 {
+			out.WriteString(s)
+			break
+		}
+		out.WriteString(s[:start])
+		end := strings.IndexByte(s[start:], '>')
+		if end < 0 {
+			break // An unterminated tag runs to the end
+		}
+		tag := strings.ToLower(s[start+1 : start+end])
+		s = s[start+end+1:]
+		out.WriteByte(' ') // Tags separate words: "a<br>b" is two
+
+		// Drop what scripts and styles contain, not just their tags
+		for _, element := range []string{"script", "style"} {
+			if tag == element || strings.HasPrefix(tag, element+" ") {
+				if close := strings.Index(strings.ToLower(s), "</"+element); close >= 0 {
+					s = s[close:]
+				} else {
+					s = ""
+				}
+			}
+		}
+	}
+	return strings.Join(strings.Fields(html.UnescapeString(out.String())), " ")
+}
+
+// Dedupe returns a processor that canonicalises links and drops articles
+// already ingested from another feed, remembering the last capacity
+// links. Links are canonicalised like subscriptions, without tracking
+// parameters or fragments, so the store's own deduplication catches the
+// variants feeds add. Repeats from the same feed are left to the store.
+func Dedupe(capacity int) Processor {
+	d := &dedupe{capacity: capacity, seen: make(map[string]string)}
+	return ProcessorFunc{ProcessorName: "dedupe", Fn: d.process}
+}
+
+// dedupe remembers which feed each recent link came from.
+type dedupe struct {
+	mu       sync.Mutex
+	capacity int
+	seen     map[string]string // Canonical link to feed URL
+	order    []string          // Links in seen, oldest first
+}
+
+func (d *dedupe) process(ctx context.Context, article *feed.Article) (bool, error) {
+	if article.Link == "" {
+		return true, nil
+	}
+	article.Link = subscription.Canonicalize(article.Link)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	feedURL := FeedURL(ctx)
+	if first, ok := d.seen[article.Link]; ok {
+		return first == feedURL, nil
+	}
+	if d.capacity > 0 && len(d.order) >= d.capacity {
+		delete(d.seen, d.order[0])
+		d.order = d.order[1:]
+	}
+	d.seen[article.Link] = feedURL
+	d.order = append(d.order, article.Link)
+	return true, nil
+}
+
+// DefaultTags are the tags attached by Tag in the default pipeline, each
+// with the words and phrases that earn it.
+var DefaultTags = map[string][]string{
+	"security": {"security", "vulnerability", "vulnerabilities", "cve", "exploit", "advisory", "patch", "malware"},
+	"release":  {"release", "released", "releases", "announcing", "changelog", "release candidate"},
+	"ai":       {"ai", "llm", "llms", "machine learning", "neural network", "gpt"},
+}
+
+// Tag returns a processor that attaches each tag whose words or phrases
+// appear in an article's title or description. Matching ignores case and
+// punctuation and is by whole word, so "ai" doesn't match "said".
+func Tag(tags map[string][]string) Processor {
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return ProcessorFunc{ProcessorName: "tag", Fn: func(ctx context.Context, article *feed.Article) (bool, error) {
+		content := " " + strings.Join(text.Words(article.Title+" "+article.Description), " ") + " "
+		for _, name := range names {
+			if slices.Contains(article.Tags, name) {
+				continue
+			}
+			for _, phrase := range tags[name] {
+				if strings.Contains(content, " "+strings.Join(text.Words(phrase), " ")+" ") {
+					article.Tags = append(article.Tags, name)
+					break
+				}
+			}
+		}
+		return true, nil
+	}}
+}
+
+// minLanguageEvidence is how many stopwords must back a language, and by
+// how many it must lead the next, before it is assigned.
+const minLanguageEvidence = 2
+
+// DetectLanguage returns a processor that sets Language from the
+// stopwords in an article's title and description, for English, French,
+// German, Spanish, Italian, Portuguese and Dutch. Articles whose language
+// is already set, or too short to tell, are left alone.
+func DetectLanguage() Processor {
+	return ProcessorFunc{ProcessorName: "language", Fn: func(ctx context.Context, article *feed.Article) (bool, error) {
+		if article.Language == "" {
+			article.Language = detectLanguage(article.Title + " " + article.Description)
+		}
+		return true, nil
+	}}
+}
+
+// detectLanguage returns the ISO 639-1 code of the language content is
+// most likely in, or "" if the evidence is too thin.
+func detectLanguage(content string) string {
+	scores := make(map[string]int)
+	for _, word := range text.Words(content) {
+		for _, lang := range text.StopwordLanguages(word) {
+			scores[lang]++
+		}
+	}
+
+	best, bestScore, runnerUp := "", 0, 0
+	for lang, score := range scores {
+		switch {
+		case score > bestScore || (score == bestScore && lang < best):
+			best, bestScore, runnerUp = lang, score, max(bestScore, runnerUp)
+		case score > runnerUp:
+			runnerUp = score
+		}
+	}
+	if bestScore < minLanguageEvidence || bestScore-runnerUp < minLanguageEvidence {
+		return ""
+	}
+	return best
+}
+
+// Why these processors, in this order:
+//
+// - Sanitize runs first so every later step sees the same plain text the
+//   API serves, instead of each parsing HTML its own way.
+// - Dedupe canonicalises links before anything is spent on an article the
+//   store would discard, and catches the tracking-parameter variants the
+//   store's exact-match key can't.
+// - Language and tags are cheap, dependency-free heuristics: stopword
+//   counts and whole-word matches are wrong sometimes, but predictably,
+//   and leave room for better detectors behind the same interface.
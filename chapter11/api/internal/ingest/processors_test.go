@@ -0,0 +1,128 @@
+package ingest_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/ingest"
+)
+
+// process runs one article through p as part of feedURL's batch.
+func process(t *testing.T, p ingest.Processor, feedURL string, article *feed.Article) bool {
+	t.Helper()
+	kept, err := ingest.New(p).ProcessArticles(context.Background(), feedURL, []*feed.Article{article})
+	if err != nil {
+		t.Fatalf("%s: %v", p.Name(), err)
+	}
+	return len(kept) == 1
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        feed.Article
+		wantTitle string
+		wantDesc  string
+		wantKept  bool
+	}{
+		{"plain text", feed.Article{Title: "  Go 1.22\n released ", Description: "Notes"}, "Go 1.22 released", "Notes", true},
+		{"markup", feed.Article{Title: "<b>Bold</b> news", Description: "<p>One<br>two</p><p>three &amp; four</p>"}, "Bold news", "One two three & four", true},
+		{"script and style", feed.Article{Title: "T", Description: `<style>p{color:red}</style>Hi<script type="x">alert("x<y")</script> there`}, "T", "Hi there", true},
+		{"entities", feed.Article{Title: "Caf&eacute; &lt;3 &#8217;", Description: ""}, "Café <3 ’", "", true},
+		{"unterminated tag", feed.Article{Title: "T", Description: "Text <a href="}, "T", "Text", true},
+		{"empty after cleaning", feed.Article{Title: "<br/>", Description: " <p></p> "}, "", "", false},
+		{"link only", feed.Article{Link: " https://example.com/a "}, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			article := tt.in
+			kept := process(t, ingest.Sanitize(), "feed", &article)
+			if kept != tt.wantKept || article.Title != tt.wantTitle || article.Description != tt.wantDesc {
+				t.Errorf("got %q / %q (kept %v), want %q / %q (kept %v)",
+					article.Title, article.Description, kept, tt.wantTitle, tt.wantDesc, tt.wantKept)
+			}
+		})
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	dedupe := ingest.Dedupe(2)
+	steps := []struct {
+		feed     string
+		link     string
+		wantKept bool
+	}{
+		{"a", "https://example.com/1?utm_source=a", true},
+		{"a", "https://example.com/1", true},      // Same feed: left to the store
+		{"b", "https://EXAMPLE.com/1#top", false}, // Another feed's copy
+		{"b", "https://example.com/2", true},
+		{"b", "https://example.com/3", true},                // Evicts /1
+		{"c", "https://example.com/1?utm_campaign=c", true}, // Forgotten, so new again
+		{"c", "", true}, // Nothing to compare
+	}
+	for i, step := range steps {
+		article := &feed.Article{Title: "t", Link: step.link}
+		if kept := process(t, dedupe, step.feed, article); kept != step.wantKept {
+			t.Errorf("step %d: %s from %s kept = %v, want %v", i, step.link, step.feed, kept, step.wantKept)
+		}
+	}
+}
+
+func TestTag(t *testing.T) {
+	tag := ingest.Tag(map[string][]string{
+		"security": {"CVE", "vulnerability"},
+		"ai":       {"ai", "machine learning"},
+	})
+	tests := []struct {
+		name     string
+		article  feed.Article
+		wantTags []string
+	}{
+		{"title word", feed.Article{Title: "Fix for CVE-2024-1234"}, []string{"security"}},
+		{"description phrase", feed.Article{Title: "News", Description: "Advances in Machine  Learning."}, []string{"ai"}},
+		{"several tags in order", feed.Article{Title: "AI finds vulnerability"}, []string{"ai", "security"}},
+		{"whole words only", feed.Article{Title: "He said the CVEs were vulnerabilityish"}, nil},
+		{"existing tags kept once", feed.Article{Title: "AI", Tags: []string{"ai", "starred"}}, []string{"ai", "starred"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			article := tt.article
+			process(t, tag, "feed", &article)
+			if !slices.Equal(article.Tags, tt.wantTags) {
+				t.Errorf("tags = %v, want %v", article.Tags, tt.wantTags)
+			}
+		})
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"The Go team is happy to announce the release of Go 1.22, which is available now from the downloads page.", "en"},
+		{"La nouvelle version est disponible dans les dépôts et elle apporte des améliorations pour les développeurs.", "fr"},
+		{"Die neue Version ist ab sofort verfügbar und bringt auch eine Reihe von Verbesserungen für den Compiler mit sich.", "de"},
+		{"La nueva versión ya está disponible para los usuarios y trae mejoras en el rendimiento del compilador.", "es"},
+		{"La nuova versione è disponibile per gli utenti e porta anche miglioramenti nel compilatore.", "it"},
+		{"A nova versão já está disponível para os usuários e traz melhorias no desempenho do compilador, mas não para todos.", "pt"},
+		{"De nieuwe versie is nu beschikbaar voor gebruikers en het brengt ook verbeteringen in de compiler.", "nl"},
+		{"Go 1.22", ""},
+		{"Kubernetes", ""},
+	}
+	for _, tt := range tests {
+		article := &feed.Article{Title: tt.text}
+		process(t, ingest.DetectLanguage(), "feed", article)
+		if article.Language != tt.want {
+			t.Errorf("language of %q = %q, want %q", tt.text, article.Language, tt.want)
+		}
+	}
+
+	preset := &feed.Article{Title: "The cat is on the mat and it is happy", Language: "xx"}
+	process(t, ingest.DetectLanguage(), "feed", preset)
+	if preset.Language != "xx" {
+		t.Errorf("preset language overwritten with %q", preset.Language)
+	}
+}
@@ -0,0 +1,256 @@
+// Package openapi builds an OpenAPI 3 document from endpoint descriptions
+// and the Go types of their request and response bodies.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// =============================================================================
+// DOCUMENT - The OpenAPI 3.0 object model, as much of it as the API uses
+// =============================================================================
+
+// Version is the OpenAPI version documents declare.
+const Version = "3.0.3"
+
+// Document is an OpenAPI document.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+// Info describes the API.
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem holds the operations on one path, by lower-case method.
+type PathItem map[string]*Operation
+
+// Operation is one method on one path.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is a path or query parameter.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+	Explode     *bool   `json:"explode,omitempty"`
+}
+
+// RequestBody is an operation's JSON body.
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response is one status an operation answers with.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType is the schema of a body in one content type.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the schemas operations refer to by name.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Schema is a JSON Schema as OpenAPI 3.0 restricts it. Objects generated
+// from structs list every property, and Validate rejects others.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+}
+
+// =============================================================================
+// ENDPOINTS - What handlers describe about themselves
+// =============================================================================
+
+// Endpoint describes one operation. Bodies are given as values of their
+// Go types, whose schemas are derived from their fields and JSON tags.
+type Endpoint struct {
+	Method  string
+	Path    string // With {name} path parameters, as in ServeMux patterns
+	Summary string
+	Params  []Param // Query parameters; path parameters come from Path
+
+	Request     any    // The JSON request body; nil for none
+	Status      int    // The success status; 200 if zero
+	Response    any    // The success body; nil for none
+	ContentType string // Of the success body if not JSON, such as text/event-stream
+
+	Errors    []int // Error statuses the operation answers with
+	ErrorBody any   // The body of error responses, as application/problem+json; nil for none
+}
+
+// ProblemContentType is the media type of error bodies (RFC 9457).
+const ProblemContentType = "application/problem+json"
+
+// Param is a query parameter.
+type Param struct {
+	Name        string
+	Type        string // string, integer or boolean
+	Description string
+	Required    bool
+	Repeated    bool     // May be given more than once
+	Enum        []string // Allowed values, if limited
+}
+
+// SuccessStatus returns e.Status, or 200 if it is unset.
+func (e Endpoint) SuccessStatus() int {
+	if e.Status == 0 {
+		return http.StatusOK
+	}
+	return e.Status
+}
+
+// pathParam matches the {name} segments of a path.
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// Build returns the document describing endpoints.
+func Build(info Info, endpoints []Endpoint) *Document {
+	g := &generator{schemas: make(map[string]*Schema), types: make(map[string]reflect.Type)}
+	doc := &Document{
+		OpenAPI:    Version,
+		Info:       info,
+		Paths:      make(map[string]*PathItem),
+		Components: Components{Schemas: g.schemas},
+	}
+	for _, e := range endpoints {
+		item := doc.Paths[e.Path]
+		if item == nil {
+			item = &PathItem{}
+			doc.Paths[e.Path] = item
+		}
+		(*item)[strings.ToLower(e.Method)] = g.operation(e)
+	}
+	return doc
+}
+
+// Operation returns the operation for method on path, or nil.
+func (d *Document) Operation(method, path string) *Operation {
+	item := d.Paths[path]
+	if item == nil {
+		return nil
+	}
+	return (*item)[strings.ToLower(method)]
+}
+
+// Operations returns "METHOD path" for every operation, sorted.
+func (d *Document) Operations() []string {
+	var result []string
+	for path, item := range d.Paths {
+		for method := range *item {
+			result = append(result, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// operation documents one endpoint.
+func (g *generator) operation(e Endpoint) *Operation {
+	op := &Operation{
+		OperationID: operationID(e.Method, e.Path),
+		Summary:     e.Summary,
+		Responses:   make(map[string]*Response),
+	}
+	for _, match := range pathParam.FindAllStringSubmatch(e.Path, -1) {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"},
+		})
+	}
+	for _, p := range e.Params {
+		schema := &Schema{Type: p.Type, Enum: p.Enum}
+		param := Parameter{Name: p.Name, In: "query", Description: p.Description, Required: p.Required, Schema: schema}
+		if p.Repeated {
+			explode := true
+			param.Schema = &Schema{Type: "array", Items: schema}
+			param.Explode = &explode
+		}
+		op.Parameters = append(op.Parameters, param)
+	}
+
+	if e.Request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: g.schema(reflect.TypeOf(e.Request))}},
+		}
+	}
+
+	success := &Response{Description: http.StatusText(e.SuccessStatus())}
+	switch {
+	case e.ContentType != "":
+		success.Content = map[string]MediaType{e.ContentType: {Schema: &Schema{Type: "string"}}}
+	case e.Response != nil:
+		success.Content = map[string]MediaType{"application/json": {Schema: g.schema(reflect.TypeOf(e.Response))}}
+	}
+	op.Responses[fmt.Sprint(e.SuccessStatus())] = success
+	for _, status := range e.Errors {
+		response := &Response{Description: http.StatusText(status)}
+		if e.ErrorBody != nil {
+			response.Content = map[string]MediaType{ProblemContentType: {Schema: g.schema(reflect.TypeOf(e.ErrorBody))}}
+		}
+		op.Responses[fmt.Sprint(status)] = response
+	}
+	return op
+}
+
+// operationID names an operation after its method and path, such as
+// getRulesIdDryRun for GET /v1/rules/{id}/dry-run. A leading version
+// segment is left out.
+func operationID(method, path string) string {
+	id := strings.ToLower(method)
+	for i, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		if i == 0 && len(segment) > 1 && segment[0] == 'v' && strings.Trim(segment[1:], "0123456789") == "" {
+			continue
+		}
+		for _, word := range strings.FieldsFunc(strings.Trim(segment, "{}"), func(r rune) bool { return r == '-' || r == '_' }) {
+			id += strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return id
+}
+
+// Why a generated document:
+//
+// - The schemas come from the very types handlers encode, so a renamed
+//   or retyped field changes the document with it instead of leaving a
+//   hand-written description behind.
+// - Endpoints are described by the handlers that serve them, and routes
+//   are registered from the same descriptions, so a route can't exist
+//   without its documentation.
+// - What reflection can't see, such as which statuses a handler writes,
+//   is checked by a test that calls every operation and validates the
+//   responses against the document.
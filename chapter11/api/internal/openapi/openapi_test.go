@@ -0,0 +1,115 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/openapi"
+)
+
+type base struct {
+	ID string `json:"id"`
+}
+
+type item struct {
+	base
+	Name    string            `json:"name"`
+	Note    string            `json:"note,omitempty"`
+	Due     *time.Time        `json:"due,omitempty"`
+	Created time.Time         `json:"created"`
+	Tags    []string          `json:"tags"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Parent  *item             `json:"parent"`
+	Count   int               `json:"count"`
+}
+
+func document() *openapi.Document {
+	return openapi.Build(openapi.Info{Title: "test", Version: "1"}, []openapi.Endpoint{
+		{
+			Method: "GET", Path: "/v1/items/{id}", Summary: "One item",
+			Params:   []openapi.Param{{Name: "tag", Type: "string", Repeated: true}},
+			Response: item{}, Errors: []int{404},
+		},
+		{Method: "POST", Path: "/v1/items", Request: item{}, Status: 201, Response: item{}},
+		{Method: "DELETE", Path: "/v1/items/{id}", Status: 204},
+	})
+}
+
+func TestBuild(t *testing.T) {
+	doc := document()
+
+	if got, want := doc.Operations(), []string{"DELETE /v1/items/{id}", "GET /v1/items/{id}", "POST /v1/items"}; !slices.Equal(got, want) {
+		t.Errorf("operations = %q, want %q", got, want)
+	}
+	get := doc.Operation("GET", "/v1/items/{id}")
+	if get.OperationID != "getItemsId" {
+		t.Errorf("operationId = %q", get.OperationID)
+	}
+	if len(get.Parameters) != 2 || get.Parameters[0].In != "path" || get.Parameters[1].Schema.Type != "array" {
+		t.Errorf("parameters = %+v, want the path id then the repeated tag", get.Parameters)
+	}
+	if get.Responses["200"] == nil || get.Responses["404"] == nil {
+		t.Errorf("responses = %v, want 200 and 404", get.Responses)
+	}
+	if del := doc.Operation("DELETE", "/v1/items/{id}"); len(del.Responses["204"].Content) != 0 {
+		t.Errorf("204 response has content %v", del.Responses["204"].Content)
+	}
+
+	schema := doc.Components.Schemas["item"]
+	if schema == nil {
+		t.Fatalf("no item schema among %v", doc.Components.Schemas)
+	}
+	if want := []string{"count", "created", "id", "name", "parent", "tags"}; !slices.Equal(schema.Required, want) {
+		t.Errorf("required = %q, want %q", schema.Required, want)
+	}
+	if due := schema.Properties["due"]; due.Format != "date-time" || !due.Nullable {
+		t.Errorf("due = %+v, want a nullable date-time", due)
+	}
+
+	// The document itself is JSON an OpenAPI tool can read
+	data, err := json.Marshal(doc)
+	if err != nil || !strings.Contains(string(data), `"openapi":"3.0.3"`) {
+		t.Errorf("marshal = %s, %v", data, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	doc := document()
+	schema := doc.Operation("GET", "/v1/items/{id}").Responses["200"].Content["application/json"].Schema
+
+	const valid = `{"id":"a","name":"n","created":"2024-08-13T16:00:00Z","tags":[],"parent":null,"count":1`
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"minimal", valid + `}`, ""},
+		{"optional fields", valid + `,"due":"2024-08-14T00:00:00+02:00","labels":{"a":"b"}}`, ""},
+		{"nested", valid + `,"parent":` + valid + `}}`, ""},
+		{"missing required", `{"id":"a"}`, `missing required property "count"`},
+		{"undocumented property", valid + `,"Name":"n"}`, `undocumented property "Name"`},
+		{"bad date", strings.Replace(valid, "2024-08-13T16:00:00Z", "13 Aug 2024", 1) + `}`, "not an RFC 3339 date-time"},
+		{"wrong type", strings.Replace(valid, `"count":1`, `"count":"1"`, 1) + `}`, "want a number"},
+		{"fraction", strings.Replace(valid, `"count":1`, `"count":1.5`, 1) + `}`, "want an integer"},
+		{"null array", strings.Replace(valid, `"tags":[]`, `"tags":null`, 1) + `}`, "$.tags: null is not allowed"},
+		{"nested error", valid + `,"parent":{"id":"b"}}`, "$.parent: missing required property"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v any
+			if err := json.Unmarshal([]byte(tt.body), &v); err != nil {
+				t.Fatal(err)
+			}
+			err := doc.Validate(schema, v)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Validate = %v, want nil", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("Validate = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
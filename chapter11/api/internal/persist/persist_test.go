@@ -0,0 +1,53 @@
+package persist_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/persist"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+
+	for _, data := range []string{`{"first":true}`, `{}`} {
+		if err := persist.WriteFile(path, []byte(data)); err != nil {
+			t.Fatalf("WriteFile error = %v", err)
+		}
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != data {
+			t.Errorf("file = %q, want %q", got, data)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory holds %d files, want only the state file", len(entries))
+	}
+
+	if err := persist.WriteFile(filepath.Join(dir, "missing", "state.json"), nil); err == nil {
+		t.Error("WriteFile into a missing directory succeeded")
+	}
+}
+
+func TestNewID(t *testing.T) {
+	seen := make(map[string]bool)
+	for range 100 {
+		id := persist.NewID(8)
+		if len(id) != 16 {
+			t.Fatalf("NewID(8) = %q, want 16 hex digits", id)
+		}
+		if seen[id] {
+			t.Fatalf("NewID repeated %q", id)
+		}
+		seen[id] = true
+	}
+}
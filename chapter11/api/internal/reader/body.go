@@ -0,0 +1,120 @@
+package reader
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// =============================================================================
+// RESPONSE BODIES - Bounded reads and safe decompression
+// =============================================================================
+
+// DefaultMaxBodySize is the largest feed body read by default (10 MiB).
+// Real feeds rarely exceed a few hundred kilobytes.
+const DefaultMaxBodySize = 10 << 20
+
+// maxCompressionRatio is the largest decompressed-to-compressed size ratio
+// accepted once the output passes bombCheckAfter bytes. XML compresses
+// around 5-15x; bombs built from repeated bytes reach 1000x.
+const (
+	maxCompressionRatio = 100
+	bombCheckAfter      = 1 << 20
+)
+
+// ErrDecompressionBomb is returned when a compressed body expands far more
+// than any real feed would.
+var ErrDecompressionBomb = errors.New("compressed body expands suspiciously (possible decompression bomb)")
+
+// BodyTooLargeError is returned when a feed body exceeds the size limit.
+type BodyTooLargeError struct {
+	Limit int64
+}
+
+func (e *BodyTooLargeError) Error() string {
+	return fmt.Sprintf("feed body exceeds the %d byte limit", e.Limit)
+}
+
+// WithMaxBodySize sets the largest feed body that will be read, measured
+// after decompression (default DefaultMaxBodySize).
+func WithMaxBodySize(limit int64) Option {
+	return func(r *RSSReader) {
+		r.maxBodySize = limit
+	}
+}
+
+// readBody reads resp's body, decompressing gzip or deflate content
+// itself so both the compressed and decompressed sizes can be bounded.
+// Callers must have sent their own Accept-Encoding header; otherwise the
+// transport decompresses transparently and hides the compressed size.
+func readBody(resp *http.Response, limit int64) ([]byte, error) {
+	if resp.ContentLength > limit {
+		return nil, &BodyTooLargeError{Limit: limit}
+	}
+
+	compressed := &countingReader{r: io.LimitReader(resp.Body, limit+1)}
+
+	var body io.Reader
+	switch encoding := strings.ToLower(resp.Header.Get("Content-Encoding")); encoding {
+	case "", "identity":
+		body = compressed
+	case "gzip", "x-gzip":
+		gz, err := gzip.NewReader(compressed)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip body: %w", err)
+		}
+		defer gz.Close()
+		body = &bombGuard{r: gz, compressed: compressed}
+	case "deflate":
+		zr, err := zlib.NewReader(compressed)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deflate body: %w", err)
+		}
+		defer zr.Close()
+		body = &bombGuard{r: zr, compressed: compressed}
+	default:
+		return nil, fmt.Errorf("unsupported Content-Encoding %q", encoding)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit || compressed.n > limit {
+		return nil, &BodyTooLargeError{Limit: limit}
+	}
+	return data, nil
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// bombGuard fails a decompressing read once its output outgrows the
+// compressed input by more than maxCompressionRatio.
+type bombGuard struct {
+	r          io.Reader
+	compressed *countingReader
+	n          int64
+}
+
+func (g *bombGuard) Read(p []byte) (int, error) {
+	n, err := g.r.Read(p)
+	g.n += int64(n)
+	if g.n > bombCheckAfter && g.n > maxCompressionRatio*max(g.compressed.n, 1) {
+		return n, ErrDecompressionBomb
+	}
+	return n, err
+}
@@ -0,0 +1,205 @@
+package reader_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/reader"
+)
+
+// rssWithTitle builds a one-item feed in the given prolog encoding.
+// title is inserted as raw bytes so tests can use legacy encodings.
+func rssWithTitle(encoding, title string) string {
+	prolog := `<?xml version="1.0"?>`
+	if encoding != "" {
+		prolog = `<?xml version="1.0" encoding="` + encoding + `"?>`
+	}
+	return prolog + `<rss version="2.0"><channel><title>` + title + `</title>` +
+		`<item><title>` + title + `</title><link>https://example.com/1</link></item>` +
+		`</channel></rss>`
+}
+
+func TestFetchFeedCharsets(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantTitle   string
+		wantErr     error
+	}{
+		{
+			name:      "UTF-8",
+			body:      rssWithTitle("UTF-8", "Café “quoted”"),
+			wantTitle: "Café “quoted”",
+		},
+		{
+			name:      "ISO-8859-1 from prolog",
+			body:      rssWithTitle("ISO-8859-1", "Caf\xe9"),
+			wantTitle: "Café",
+		},
+		{
+			name:      "windows-1252 from prolog",
+			body:      rssWithTitle("windows-1252", "\x93quoted\x94 \x80"),
+			wantTitle: "“quoted” €",
+		},
+		{
+			name:        "HTTP charset without prolog encoding",
+			contentType: "application/rss+xml; charset=windows-1252",
+			body:        rssWithTitle("", "\x93quoted\x94"),
+			wantTitle:   "“quoted”",
+		},
+		{
+			name:        "HTTP charset overrides prolog",
+			contentType: "text/xml; charset=windows-1252",
+			body:        rssWithTitle("ISO-8859-1", "\x80 price"),
+			wantTitle:   "€ price",
+		},
+		{
+			name:        "HTTP UTF-8 overrides legacy prolog",
+			contentType: "text/xml; charset=utf-8",
+			body:        rssWithTitle("ISO-8859-1", "Café"),
+			wantTitle:   "Café",
+		},
+		{
+			name:      "ISO-8859-15 from prolog",
+			body:      rssWithTitle("ISO-8859-15", "\xa4 \xbduvre"),
+			wantTitle: "€ œuvre",
+		},
+		{
+			name:      "ISO-8859-2 from prolog",
+			body:      rssWithTitle("ISO-8859-2", "Kraj\xf3w \xa3\xf3d\xbc"),
+			wantTitle: "Krajów Łódź",
+		},
+		{
+			name:        "windows-1251 from HTTP",
+			contentType: "application/rss+xml; charset=windows-1251",
+			body:        rssWithTitle("", "\xcd\xee\xe2\xee\xf1\xf2\xe8"),
+			wantTitle:   "Новости",
+		},
+		{
+			name:      "KOI8-R from prolog",
+			body:      rssWithTitle("KOI8-R", "\xee\xcf\xd7\xcf\xd3\xd4\xc9"),
+			wantTitle: "Новости",
+		},
+		{
+			name:    "unsupported prolog encoding",
+			body:    rssWithTitle("Shift_JIS", "x"),
+			wantErr: reader.ErrUnsupportedCharset,
+		},
+		{
+			name:        "unsupported HTTP charset",
+			contentType: "application/rss+xml; charset=Shift_JIS",
+			body:        rssWithTitle("", "x"),
+			wantErr:     reader.ErrUnsupportedCharset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// Always set a type, or net/http sniffs one with charset=utf-8
+				contentType := tt.contentType
+				if contentType == "" {
+					contentType = "application/rss+xml"
+				}
+				w.Header().Set("Content-Type", contentType)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			fetched, err := newTestReader().FetchFeed(context.Background(), srv.URL)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("FetchFeed error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FetchFeed error = %v", err)
+			}
+			if fetched.Title != tt.wantTitle || fetched.Articles[0].Title != tt.wantTitle {
+				t.Errorf("titles = %q / %q, want %q", fetched.Title, fetched.Articles[0].Title, tt.wantTitle)
+			}
+		})
+	}
+}
+
+func gzipped(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(data)
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestFetchFeedBodyLimits(t *testing.T) {
+	// Padding inside a comment keeps the feed valid at any size
+	padded := func(n int) []byte {
+		return []byte(rssWithTitle("", "Big") + "<!--" + strings.Repeat(" ", n) + "-->")
+	}
+
+	tests := []struct {
+		name     string
+		body     []byte
+		encoding string
+		flush    bool // Stream without Content-Length
+		limit    int64
+		wantErr  error
+	}{
+		{name: "within limit", body: padded(1000), limit: 64 << 10},
+		{name: "Content-Length over limit", body: padded(100 << 10), limit: 64 << 10, wantErr: &reader.BodyTooLargeError{}},
+		{name: "streamed over limit", body: padded(100 << 10), flush: true, limit: 64 << 10, wantErr: &reader.BodyTooLargeError{}},
+		{name: "gzip within limit", body: padded(1000), encoding: "gzip", limit: 64 << 10},
+		{name: "gzip expands over limit", body: padded(100 << 10), encoding: "gzip", limit: 64 << 10, wantErr: &reader.BodyTooLargeError{}},
+		{name: "gzip bomb", body: padded(5 << 20), encoding: "gzip", limit: 100 << 20, wantErr: reader.ErrDecompressionBomb},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := tt.body
+			if tt.encoding == "gzip" {
+				payload = gzipped(t, payload)
+			}
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.encoding != "" {
+					w.Header().Set("Content-Encoding", tt.encoding)
+				}
+				if tt.flush {
+					w.WriteHeader(http.StatusOK)
+					w.(http.Flusher).Flush()
+				}
+				w.Write(payload)
+			}))
+			defer srv.Close()
+
+			r := newTestReader(reader.WithMaxBodySize(tt.limit))
+			_, err := r.FetchFeed(context.Background(), srv.URL)
+
+			switch want := tt.wantErr.(type) {
+			case nil:
+				if err != nil {
+					t.Fatalf("FetchFeed error = %v", err)
+				}
+			case *reader.BodyTooLargeError:
+				var tooLarge *reader.BodyTooLargeError
+				if !errors.As(err, &tooLarge) || tooLarge.Limit != tt.limit {
+					t.Fatalf("FetchFeed error = %v, want %T with limit %d", err, want, tt.limit)
+				}
+			default:
+				if !errors.Is(err, want) {
+					t.Fatalf("FetchFeed error = %v, want %v", err, want)
+				}
+			}
+		})
+	}
+}
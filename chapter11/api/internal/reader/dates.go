@@ -0,0 +1,270 @@
+package reader
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+)
+
+// =============================================================================
+// DATES - Tolerant parsing of the publication dates feeds actually use
+// =============================================================================
+
+// monthNames maps lower-cased month names and abbreviations in English,
+// French, German, Spanish, Italian, Portuguese and Dutch to the English
+// abbreviations time.Parse understands.
+var monthNames = map[string]string{}
+
+// weekdayNames are lower-cased day names and abbreviations in the same
+// languages. A leading weekday carries no information and is dropped.
+var weekdayNames = map[string]bool{}
+
+func init() {
+	months := [12][]string{
+		{"jan", "january", "janvier", "janv", "januar", "jänner", "enero", "ene", "gennaio", "gen", "janeiro", "januari"},
+		{"feb", "february", "février", "fevrier", "févr", "fevr", "fév", "fev", "februar", "febrero", "febbraio", "fevereiro", "februari"},
+		{"mar", "march", "mars", "märz", "maerz", "mär", "mrz", "marzo", "março", "marco", "maart", "mrt"},
+		{"apr", "april", "avril", "avr", "abril", "abr", "aprile"},
+		{"may", "mai", "mayo", "maggio", "mag", "maio", "mei"},
+		{"jun", "june", "juin", "juni", "junio", "giugno", "giu", "junho"},
+		{"jul", "july", "juillet", "juil", "juli", "julio", "luglio", "lug", "julho"},
+		{"aug", "august", "août", "aout", "agosto", "ago", "augustus"},
+		{"sep", "sept", "september", "septembre", "septiembre", "setiembre", "settembre", "set", "setembro"},
+		{"oct", "october", "octobre", "oktober", "okt", "octubre", "ottobre", "ott", "outubro", "out"},
+		{"nov", "november", "novembre", "noviembre", "novembro"},
+		{"dec", "december", "décembre", "decembre", "déc", "dezember", "dez", "diciembre", "dic", "dicembre", "dezembro"},
+	}
+	for i, names := range months {
+		for _, name := range names {
+			monthNames[name] = time.Month(i + 1).String()[:3]
+		}
+	}
+
+	for _, name := range strings.Fields(`
+		mon tue tues wed thu thur thurs fri sat sun
+		monday tuesday wednesday thursday friday saturday sunday
+		lun mar mer jeu ven sam dim lundi mardi mercredi jeudi vendredi samedi dimanche
+		mo di mi do fr sa so montag dienstag mittwoch donnerstag freitag samstag sonnabend sonntag
+		mié mie jue vie sáb sab dom lunes martes miércoles miercoles jueves viernes sábado sabado domingo
+		gio lunedì lunedi martedì martedi mercoledì mercoledi giovedì giovedi venerdì venerdi sabato domenica
+		seg ter qua qui sex segunda terça terca quarta quinta sexta segunda-feira terça-feira terca-feira quarta-feira quinta-feira sexta-feira
+		ma wo vr za zo maandag dinsdag woensdag donderdag vrijdag zaterdag zondag`) {
+		weekdayNames[name] = true
+	}
+}
+
+// zoneOffsets maps the zone abbreviations seen in feeds to their UTC
+// offsets. time.Parse only knows the local zone's abbreviations and
+// silently treats the rest as UTC.
+var zoneOffsets = map[string]string{
+	"UT": "+0000", "UTC": "+0000", "GMT": "+0000", "Z": "+0000", "WET": "+0000",
+	"EST": "-0500", "EDT": "-0400", "CST": "-0600", "CDT": "-0500",
+	"MST": "-0700", "MDT": "-0600", "PST": "-0800", "PDT": "-0700",
+	"AKST": "-0900", "AKDT": "-0800", "HST": "-1000",
+	"BST": "+0100", "IST": "+0530", "WEST": "+0100", "CET": "+0100", "CEST": "+0200",
+	"MEZ": "+0100", "MESZ": "+0200", "EET": "+0200", "EEST": "+0300", "MSK": "+0300",
+	"JST": "+0900", "KST": "+0900", "AWST": "+0800", "ACST": "+0930", "AEST": "+1000",
+	"AEDT": "+1100", "NZST": "+1200", "NZDT": "+1300",
+}
+
+// fillerWords appear between date parts in some locales, as in
+// "5 de marzo de 2024" or "March 5, 2024 at 10:00".
+var fillerWords = map[string]bool{"de": true, "del": true, "at": true, "à": true, "um": true}
+
+// dateLayouts are tried in order against a normalised date: commas and
+// weekdays removed, months as English abbreviations, zones as numeric
+// offsets.
+var dateLayouts = buildDateLayouts()
+
+func buildDateLayouts() []string {
+	layouts := []string{
+		time.RFC3339, // Also accepts fractional seconds
+		"2006-01-02T15:04:05Z0700",
+		"2006-01-02T15:04Z07:00",
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04",
+		"2006-01-02 15:04:05Z07:00",
+		"2006-01-02 15:04:05 -0700",
+		"2006-01-02 15:04:05 Z07:00",
+		"2006-01-02 15:04 -0700",
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04",
+		"2006-01-02",
+		"Jan 2 15:04:05 -0700 2006", // ctime and Unix date(1)
+		"Jan 2 15:04:05 2006",
+	}
+	for _, date := range []string{"2 Jan 2006", "2 Jan 06", "Jan 2 2006", "Jan 2 06"} {
+		for _, clock := range []string{"15:04:05", "15:04", "3:04:05 PM", "3:04 PM"} {
+			layouts = append(layouts, date+" "+clock+" -0700", date+" "+clock)
+		}
+		layouts = append(layouts, date)
+	}
+	return layouts
+}
+
+// ParseDate parses a publication date as written by real feeds: RFC 822
+// and RFC 1123 with or without seconds, two-digit years and named zones,
+// RFC 3339 and other ISO 8601 forms, and dates with localised day and
+// month names. Dates without a zone are taken as UTC.
+func ParseDate(value string) (time.Time, error) {
+	normalised := normaliseDate(value)
+	if normalised == "" {
+		return time.Time{}, fmt.Errorf("empty date")
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, normalised); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised date %q", value)
+}
+
+// normaliseDate rewrites a date into the vocabulary of dateLayouts.
+func normaliseDate(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 10 && value[4] == '-' && value[7] == '-' {
+		// ISO 8601: only letter case and a trailing named zone need fixing
+		fields := strings.Fields(strings.ToUpper(value))
+		if last := len(fields) - 1; last > 0 && zoneOffsets[fields[last]] != "" {
+			fields[last] = zoneOffsets[fields[last]]
+		}
+		return strings.Join(fields, " ")
+	}
+
+	words := strings.Fields(strings.ReplaceAll(value, ",", " , "))
+	out := make([]string, 0, len(words))
+	for i, word := range words {
+		if word == "," {
+			continue
+		}
+		lower := strings.ToLower(strings.TrimSuffix(word, "."))
+		if lower == "" {
+			continue
+		}
+		upper := strings.ToUpper(lower)
+		followedByComma := i+1 < len(words) && words[i+1] == ","
+
+		switch {
+		case len(out) == 0 && weekdayNames[lower] && (followedByComma || monthNames[lower] == ""):
+			// "Mar," is a French or Spanish Tuesday; "Mar 5" is March
+		case monthNames[lower] != "":
+			out = append(out, monthNames[lower])
+		case fillerWords[lower]:
+		case zoneOffsets[upper] != "":
+			out = append(out, zoneOffsets[upper])
+		case strings.HasPrefix(upper, "GMT") || strings.HasPrefix(upper, "UTC"):
+			out = append(out, numericOffset(upper[3:]))
+		case upper[0] == '+' || upper[0] == '-':
+			out = append(out, numericOffset(upper))
+		case isMeridiem(lower):
+			if clock := lower[:len(lower)-2]; clock != "" {
+				out = append(out, clock)
+			}
+			out = append(out, upper[len(upper)-2:])
+		default:
+			out = append(out, trimOrdinal(lower))
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// isMeridiem reports whether word is "am" or "pm", alone or after a
+// clock time as in "3:04pm".
+func isMeridiem(word string) bool {
+	clock, ok := strings.CutSuffix(word, "am")
+	if !ok {
+		clock, ok = strings.CutSuffix(word, "pm")
+	}
+	return ok && (clock == "" || unicode.IsDigit(rune(clock[0])))
+}
+
+// numericOffset rewrites +h, +hh, +hh:mm and +hhmm as +hhmm. Anything
+// else is returned unchanged, to fail parsing.
+func numericOffset(offset string) string {
+	if offset == "" {
+		return "+0000"
+	}
+	sign, digits := offset[:1], strings.ReplaceAll(offset[1:], ":", "")
+	if sign != "+" && sign != "-" || strings.IndexFunc(digits, func(r rune) bool { return !unicode.IsDigit(r) }) >= 0 {
+		return offset
+	}
+	switch len(digits) {
+	case 1:
+		return sign + "0" + digits + "00"
+	case 2:
+		return sign + digits + "00"
+	case 3:
+		return sign + "0" + digits
+	default:
+		return sign + digits
+	}
+}
+
+// trimOrdinal turns "1st", "2nd", "3rd" and "4th" into plain numbers.
+func trimOrdinal(word string) string {
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		if number, ok := strings.CutSuffix(word, suffix); ok && number != "" && strings.Trim(number, "0123456789") == "" {
+			return number
+		}
+	}
+	return word
+}
+
+// setPublished sets article.Published from the first of dates that is
+// present. If it can't be parsed, the raw value is kept in RawDate so the
+// article can be reported, and the store falls back to when it was first
+// seen.
+func setPublished(article *feed.Article, dates ...string) {
+	for _, raw := range dates {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		if t, err := ParseDate(raw); err == nil {
+			article.Published = &t
+			article.RawDate = ""
+			return
+		}
+		if article.RawDate == "" {
+			article.RawDate = raw
+		}
+	}
+}
+
+// recordUnparsedDates notes how many of a feed's articles had dates that
+// couldn't be parsed, with an example, so the feed shows up in its status.
+func (r *RSSReader) recordUnparsedDates(url string, articles []*feed.Article) {
+	count, example := 0, ""
+	for _, article := range articles {
+		if article.RawDate != "" {
+			count++
+			example = article.RawDate
+		}
+	}
+	if count > 0 {
+		slog.Warn("unparseable article dates", "url", url, "articles", count, "example", example)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if status, ok := r.statuses[url]; ok {
+		status.UnparsedDates = count
+		status.UnparsedDateExample = example
+	}
+}
+
+// Why dates are normalised before parsing:
+//
+// - Feeds mix languages, zones and separators freely. Rewriting each date
+//   into one vocabulary keeps the layout list short instead of multiplying
+//   it by every language and zone spelling.
+// - time.Parse accepts any zone abbreviation but only knows the offset of
+//   the local zone's; "EST" on a UTC server would silently become +0000.
+//   Abbreviations are therefore mapped to numeric offsets first.
+// - An unparseable date is kept rather than dropped, so the feed can be
+//   reported, and the article is dated when it was first seen instead of
+//   sinking below every dated one.
@@ -0,0 +1,99 @@
+package reader_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/reader"
+)
+
+// TestParseDateCorpus runs ParseDate over testdata/dates.txt, a corpus of
+// dates as real feeds write them. Add a line there for every format a
+// feed is found sending.
+func TestParseDateCorpus(t *testing.T) {
+	file, err := os.Open("testdata/dates.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	cases := 0
+	for line := 1; scanner.Scan(); line++ {
+		input, want, ok := strings.Cut(scanner.Text(), "|")
+		if !ok || strings.HasPrefix(strings.TrimSpace(input), "#") {
+			continue
+		}
+		input, want = strings.TrimSpace(input), strings.TrimSpace(want)
+		cases++
+
+		t.Run(input, func(t *testing.T) {
+			got, err := reader.ParseDate(input)
+			if want == "error" {
+				if err == nil {
+					t.Errorf("line %d: ParseDate = %s, want an error", line, got.Format(time.RFC3339Nano))
+				}
+				return
+			}
+			wantTime, parseErr := time.Parse(time.RFC3339Nano, want)
+			if parseErr != nil {
+				t.Fatalf("line %d: bad expectation %q: %v", line, want, parseErr)
+			}
+			if err != nil {
+				t.Fatalf("line %d: ParseDate error = %v", line, err)
+			}
+			_, gotOffset := got.Zone()
+			_, wantOffset := wantTime.Zone()
+			if !got.Equal(wantTime) || gotOffset != wantOffset {
+				t.Errorf("line %d: ParseDate = %s, want %s", line, got.Format(time.RFC3339Nano), want)
+			}
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if cases == 0 {
+		t.Fatal("no cases in the corpus")
+	}
+}
+
+func TestUnparsedDatesFallBackToFirstSeen(t *testing.T) {
+	const body = `<rss version="2.0"><channel><title>T</title>
+<item><title>Dated</title><link>https://example.com/1</link><pubDate>Tue, 05 Mar 2024 09:30:00 EST</pubDate></item>
+<item><title>Odd</title><link>https://example.com/2</link><pubDate>sometime last week</pubDate></item>
+<item><title>Undated</title><link>https://example.com/3</link></item>
+</channel></rss>`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	r := newTestReader()
+	before := time.Now()
+	fetched, err := r.FetchFeed(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("FetchFeed error = %v", err)
+	}
+
+	dated, odd, undated := fetched.Articles[0], fetched.Articles[1], fetched.Articles[2]
+	if dated.PublishedEstimated || dated.Published.Hour() != 9 || dated.RawDate != "" {
+		t.Errorf("dated article = %v estimated %v raw %q, want 09:30 EST from the feed", dated.Published, dated.PublishedEstimated, dated.RawDate)
+	}
+	if !odd.PublishedEstimated || odd.RawDate != "sometime last week" || odd.Published.Before(before) {
+		t.Errorf("unparseable article = %v estimated %v raw %q, want first seen with the raw date kept", odd.Published, odd.PublishedEstimated, odd.RawDate)
+	}
+	if !undated.PublishedEstimated || undated.RawDate != "" {
+		t.Errorf("undated article estimated %v raw %q, want first seen", undated.PublishedEstimated, undated.RawDate)
+	}
+
+	statuses := r.FeedStatuses()
+	if len(statuses) != 1 || statuses[0].UnparsedDates != 1 || statuses[0].UnparsedDateExample != "sometime last week" {
+		t.Errorf("FeedStatuses = %+v, want one unparsed date reported", statuses)
+	}
+}
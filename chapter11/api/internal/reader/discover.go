@@ -0,0 +1,232 @@
+package reader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+)
+
+// =============================================================================
+// AUTODISCOVERY - Finding the feed behind a website URL
+// =============================================================================
+
+// ErrNoFeedFound is returned when a page neither is nor links to a feed.
+var ErrNoFeedFound = errors.New("no feed found at this URL")
+
+// ErrInvalidURL is returned when the URL to discover from can't be used.
+var ErrInvalidURL = errors.New("invalid URL")
+
+// Where a discovery candidate came from.
+const (
+	SourceDirect = "direct" // The URL itself is a feed
+	SourceLink   = "link"   // A <link rel="alternate"> tag on the page
+	SourceProbe  = "probe"  // A common feed path that answered with a feed
+)
+
+// feedMediaTypes are the <link> types that announce a feed.
+var feedMediaTypes = map[string]bool{
+	"application/rss+xml":   true,
+	"application/atom+xml":  true,
+	"application/feed+json": true,
+}
+
+// formatMediaTypes names the media type of each detected format.
+var formatMediaTypes = map[string]string{
+	formatRSS:  "application/rss+xml",
+	formatAtom: "application/atom+xml",
+	formatJSON: "application/feed+json",
+}
+
+// probePaths are tried, in order, when a page has no feed <link> tags.
+var probePaths = []string{"/feed", "/rss.xml", "/index.xml", "/atom.xml", "/feed.xml", "/feed.json"}
+
+// Discover finds feeds for a URL a user pasted. If the URL is itself a
+// feed it is the only candidate. If it is an HTML page, the feeds it
+// advertises with <link rel="alternate"> come first, in page order; only
+// if there are none are common feed paths on the same site probed.
+// A URL without a scheme is assumed to be https. settings apply to every
+// request, for sites that need credentials.
+func (r *RSSReader) Discover(ctx context.Context, rawURL string, settings feed.FetchSettings) ([]feed.Candidate, error) {
+	pageURL, err := normalizeInputURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := r.get(ctx, pageURL, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	if candidate, err := feedCandidate(doc, SourceDirect); err == nil {
+		return []feed.Candidate{candidate}, nil
+	} else if !errors.Is(err, ErrNotFeed) {
+		return nil, err
+	}
+
+	candidates := linkCandidates(doc)
+	if len(candidates) == 0 {
+		candidates = r.probe(ctx, doc.url, settings)
+	}
+	if len(candidates) == 0 {
+		return nil, ErrNoFeedFound
+	}
+	return candidates, nil
+}
+
+// normalizeInputURL turns user input into an absolute http(s) URL.
+func normalizeInputURL(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return "", fmt.Errorf("%w: URL is required", ErrInvalidURL)
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("%w: %q must use http or https", ErrInvalidURL, rawURL)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("%w: %q has no host", ErrInvalidURL, rawURL)
+	}
+	return u.String(), nil
+}
+
+// feedCandidate parses doc and describes it as a candidate if it is a feed.
+func feedCandidate(doc *document, source string) (feed.Candidate, error) {
+	parsed, err := parseFeed(doc.body, doc.contentType)
+	if err != nil {
+		return feed.Candidate{}, err
+	}
+	format, _ := detectFormat(doc.body, "", httpCharset(doc.contentType)) // Parsed, so decodable
+	return feed.Candidate{
+		URL:    doc.url,
+		Title:  parsed.Title,
+		Type:   formatMediaTypes[format],
+		Source: source,
+	}, nil
+}
+
+// probe tries probePaths on the page's site and returns those that serve
+// a parseable feed.
+func (r *RSSReader) probe(ctx context.Context, pageURL string, settings feed.FetchSettings) []feed.Candidate {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil
+	}
+
+	var candidates []feed.Candidate
+	seen := make(map[string]bool)
+	for _, path := range probePaths {
+		if ctx.Err() != nil {
+			break
+		}
+
+		probeURL := base.ResolveReference(&url.URL{Path: path}).String()
+		doc, err := r.get(ctx, probeURL, settings)
+		if err != nil || seen[doc.url] {
+			continue
+		}
+		if candidate, err := feedCandidate(doc, SourceProbe); err == nil {
+			seen[doc.url] = true
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates
+}
+
+// =============================================================================
+// HTML SCANNING
+// =============================================================================
+
+// A full HTML parser would be overkill: feed links live in <head> as
+// plain <link> tags, and these patterns handle the attribute quoting
+// styles seen in the wild.
+var (
+	linkTagPattern = regexp.MustCompile(`(?is)<link\b[^>]*>`)
+	baseTagPattern = regexp.MustCompile(`(?is)<base\b[^>]*>`)
+	attrPattern    = regexp.MustCompile(`(?s)([a-zA-Z][a-zA-Z0-9_:-]*)\s*=\s*("[^"]*"|'[^']*'|[^\s"'>]+)`)
+)
+
+// tagAttrs returns the lower-cased attribute names of an HTML tag and
+// their unescaped values.
+func tagAttrs(tag string) map[string]string {
+	attrs := make(map[string]string)
+	for _, match := range attrPattern.FindAllStringSubmatch(tag, -1) {
+		value := strings.Trim(match[2], `"'`)
+		attrs[strings.ToLower(match[1])] = html.UnescapeString(value)
+	}
+	return attrs
+}
+
+// linkCandidates returns the feeds announced by <link rel="alternate">
+// tags, resolved against the page URL or its <base href>.
+func linkCandidates(doc *document) []feed.Candidate {
+	base, err := url.Parse(doc.url)
+	if err != nil {
+		return nil
+	}
+	if tag := baseTagPattern.FindString(string(doc.body)); tag != "" {
+		if href, err := url.Parse(tagAttrs(tag)["href"]); err == nil {
+			base = base.ResolveReference(href)
+		}
+	}
+
+	var candidates []feed.Candidate
+	seen := make(map[string]bool)
+	for _, tag := range linkTagPattern.FindAllString(string(doc.body), -1) {
+		attrs := tagAttrs(tag)
+		mediaType := strings.ToLower(strings.TrimSpace(attrs["type"]))
+		if !hasToken(attrs["rel"], "alternate") || !feedMediaTypes[mediaType] || attrs["href"] == "" {
+			continue
+		}
+
+		href, err := url.Parse(strings.TrimSpace(attrs["href"]))
+		if err != nil {
+			continue
+		}
+		feedURL := base.ResolveReference(href).String()
+		if seen[feedURL] {
+			continue
+		}
+		seen[feedURL] = true
+
+		candidates = append(candidates, feed.Candidate{
+			URL:    feedURL,
+			Title:  attrs["title"],
+			Type:   mediaType,
+			Source: SourceLink,
+		})
+	}
+	return candidates
+}
+
+// hasToken reports whether a space-separated attribute like rel contains
+// token, ignoring case.
+func hasToken(list, token string) bool {
+	for _, field := range strings.Fields(list) {
+		if strings.EqualFold(field, token) {
+			return true
+		}
+	}
+	return false
+}
+
+// Why link tags before probing:
+//
+// - <link rel="alternate"> is the site telling us where its feeds are,
+//   so it's both more accurate and free: we already have the page.
+// - Probing costs a request per path and can find stale or unrelated
+//   feeds, so it's only a fallback for sites without link tags.
+// - Candidates keep page order because sites list their main feed first,
+//   which is what automatic selection picks.
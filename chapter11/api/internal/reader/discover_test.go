@@ -0,0 +1,197 @@
+package reader_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/reader"
+)
+
+const testAtom = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Atom News</title>
+  <link href="https://example.com/"/>
+  <entry>
+    <title>Atom story</title>
+    <link rel="alternate" href="https://example.com/atom/1"/>
+    <summary>An Atom summary</summary>
+    <updated>2024-01-02T10:00:00Z</updated>
+  </entry>
+</feed>`
+
+const testJSONFeed = `{
+  "version": "https://jsonfeed.org/version/1.1",
+  "title": "JSON News",
+  "items": [
+    {"id": "1", "url": "https://example.com/json/1", "title": "JSON story",
+     "content_text": "A JSON summary", "date_published": "2024-01-02T10:00:00Z"}
+  ]
+}`
+
+// site serves pages at fixed paths with the given content types.
+func site(t *testing.T, pages map[string][2]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", page[0])
+		w.Write([]byte(page[1]))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetchFeedFormats(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantFeed    string
+		wantTitle   string
+		wantLink    string
+		wantDate    bool
+	}{
+		{"RSS", "application/rss+xml", testRSS, "Test", "One", "https://example.com/1", false},
+		{"Atom", "application/atom+xml", testAtom, "Atom News", "Atom story", "https://example.com/atom/1", true},
+		{"Atom as text/xml", "text/xml", testAtom, "Atom News", "Atom story", "https://example.com/atom/1", true},
+		{"JSON Feed", "application/feed+json", testJSONFeed, "JSON News", "JSON story", "https://example.com/json/1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := site(t, map[string][2]string{"/": {tt.contentType, tt.body}})
+
+			fetched, err := newTestReader().FetchFeed(context.Background(), srv.URL)
+			if err != nil {
+				t.Fatalf("FetchFeed error = %v", err)
+			}
+			if fetched.Title != tt.wantFeed {
+				t.Errorf("feed title = %q, want %q", fetched.Title, tt.wantFeed)
+			}
+			if len(fetched.Articles) == 0 {
+				t.Fatal("no articles parsed")
+			}
+			article := fetched.Articles[0]
+			if article.Title != tt.wantTitle || article.Link != tt.wantLink {
+				t.Errorf("article = %q %q, want %q %q", article.Title, article.Link, tt.wantTitle, tt.wantLink)
+			}
+			if tt.wantDate && article.Published == nil {
+				t.Error("published date not parsed")
+			}
+		})
+	}
+}
+
+func TestFetchFeedHTMLPage(t *testing.T) {
+	srv := site(t, map[string][2]string{"/": {"text/html", "<!DOCTYPE html><html><head></head></html>"}})
+
+	_, err := newTestReader().FetchFeed(context.Background(), srv.URL)
+	if !errors.Is(err, reader.ErrNotFeed) {
+		t.Fatalf("FetchFeed error = %v, want ErrNotFeed", err)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	const page = `<!DOCTYPE html>
+<html><head>
+  <base href="/blog/">
+  <link rel="stylesheet" href="/style.css">
+  <link rel="alternate" type="application/rss+xml" title="Main feed" href="rss.xml">
+  <LINK REL='alternate home' TYPE='application/atom+xml' HREF='/atom.xml' title='Atom &amp; more'>
+  <link rel="alternate" type="application/feed+json" href="https://feeds.example/site.json">
+  <link rel="alternate" type="application/rss+xml" href="rss.xml">
+  <link rel="alternate" hreflang="de" href="/de/">
+</head><body></body></html>`
+
+	tests := []struct {
+		name      string
+		pages     map[string][2]string
+		path      string
+		wantURLs  []string // Relative paths are resolved against the server
+		wantTitle string   // Of the first candidate
+		wantSrc   string   // Of the first candidate
+		wantErr   error
+	}{
+		{
+			name:      "link tags in page order",
+			pages:     map[string][2]string{"/": {"text/html; charset=utf-8", page}},
+			wantURLs:  []string{"/blog/rss.xml", "/atom.xml", "https://feeds.example/site.json"},
+			wantTitle: "Main feed",
+			wantSrc:   reader.SourceLink,
+		},
+		{
+			name: "probes common paths without link tags",
+			pages: map[string][2]string{
+				"/about":     {"text/html", "<html><head><title>No feeds</title></head></html>"},
+				"/index.xml": {"application/rss+xml", testRSS},
+				"/feed.json": {"application/feed+json", testJSONFeed},
+				"/rss.xml":   {"text/html", "<html>not a feed</html>"},
+			},
+			path:      "/about",
+			wantURLs:  []string{"/index.xml", "/feed.json"},
+			wantTitle: "Test",
+			wantSrc:   reader.SourceProbe,
+		},
+		{
+			name:      "URL is already a feed",
+			pages:     map[string][2]string{"/atom": {"application/atom+xml", testAtom}},
+			path:      "/atom",
+			wantURLs:  []string{"/atom"},
+			wantTitle: "Atom News",
+			wantSrc:   reader.SourceDirect,
+		},
+		{
+			name:    "no feed anywhere",
+			pages:   map[string][2]string{"/": {"text/html", "<html></html>"}},
+			wantErr: reader.ErrNoFeedFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := site(t, tt.pages)
+
+			candidates, err := newTestReader().Discover(context.Background(), srv.URL+tt.path, feed.FetchSettings{})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Discover error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Discover error = %v", err)
+			}
+
+			if len(candidates) != len(tt.wantURLs) {
+				t.Fatalf("got %d candidates %+v, want %d", len(candidates), candidates, len(tt.wantURLs))
+			}
+			for i, want := range tt.wantURLs {
+				if want[0] == '/' {
+					want = srv.URL + want
+				}
+				if candidates[i].URL != want {
+					t.Errorf("candidate %d = %q, want %q", i, candidates[i].URL, want)
+				}
+			}
+			if candidates[0].Title != tt.wantTitle || candidates[0].Source != tt.wantSrc {
+				t.Errorf("first candidate = %+v, want title %q source %q", candidates[0], tt.wantTitle, tt.wantSrc)
+			}
+		})
+	}
+}
+
+func TestDiscoverInvalidURL(t *testing.T) {
+	for _, input := range []string{"", "ftp://example.com/feed", "http://"} {
+		_, err := newTestReader().Discover(context.Background(), input, feed.FetchSettings{})
+		if !errors.Is(err, reader.ErrInvalidURL) {
+			t.Errorf("Discover(%q) error = %v, want ErrInvalidURL", input, err)
+		}
+	}
+}
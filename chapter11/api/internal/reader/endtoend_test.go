@@ -0,0 +1,118 @@
+package reader_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/feedtest"
+	"github.com/YOUR_USERNAME/go-news/api/internal/ingest"
+	"github.com/YOUR_USERNAME/go-news/api/internal/reader"
+	"github.com/YOUR_USERNAME/go-news/api/internal/store"
+)
+
+// TestFetchFeedEndToEnd runs the reader against generated feeds over real
+// HTTP, covering formats, compression, broken documents and the retry
+// and redirect paths.
+func TestFetchFeedEndToEnd(t *testing.T) {
+	tests := []struct {
+		name         string
+		route        feedtest.Route
+		opts         []reader.Option
+		wantErr      string // Substring of the error; empty for success
+		wantArticles int
+		wantRequests int
+	}{
+		{"RSS", feedtest.Route{Items: 3}, nil, "", 3, 1},
+		{"Atom", feedtest.Route{Format: feedtest.Atom, Items: 2}, nil, "", 2, 1},
+		{"JSON Feed", feedtest.Route{Format: feedtest.JSON, Items: 4}, nil, "", 4, 1},
+		{"gzip", feedtest.Route{Format: feedtest.Atom, Items: 2, Gzip: true}, nil, "", 2, 1},
+		{"empty feed", feedtest.Route{}, nil, "", 0, 1},
+		{"many items", feedtest.Route{Items: 500}, nil, "", 500, 1},
+		{"malformed XML is not retried", feedtest.Route{Items: 3, Malformed: true}, nil, "parse", 0, 1},
+		{"malformed JSON", feedtest.Route{Format: feedtest.JSON, Items: 3, Malformed: true}, nil, "JSON", 0, 1},
+		{"recovers from 503s", feedtest.Route{Items: 1, Statuses: []int{503, 503}}, nil, "", 1, 3},
+		{"gives up on 500s", feedtest.Route{Items: 1, Statuses: []int{500, 500, 500}}, nil, "500", 0, 3},
+		{"404 is final", feedtest.Route{Statuses: []int{404}}, nil, "404", 0, 1},
+		{"slow server times out", feedtest.Route{Items: 1, Delay: time.Second}, []reader.Option{reader.WithTimeout(50 * time.Millisecond)}, "Timeout", 0, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := feedtest.NewServer(t, map[string]feedtest.Route{"/feed": tt.route})
+
+			got, err := newTestReader(tt.opts...).FetchFeed(context.Background(), srv.FeedURL("/feed"))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("FetchFeed error = %v, want one mentioning %q", err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("FetchFeed error = %v", err)
+				}
+				if len(got.Articles) != tt.wantArticles {
+					t.Errorf("articles = %d, want %d", len(got.Articles), tt.wantArticles)
+				}
+				if tt.wantArticles > 0 {
+					newest := got.Articles[0]
+					wantPublished := feedtest.Epoch.Add(time.Duration(tt.wantArticles-1) * time.Hour)
+					if newest.Published == nil || !newest.Published.Equal(wantPublished) || newest.PublishedEstimated {
+						t.Errorf("newest article published %v, want %s from the feed", newest.Published, wantPublished)
+					}
+				}
+			}
+			if requests := srv.Requests("/feed"); requests != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestFetchFeedFollowsTemporaryRedirect(t *testing.T) {
+	srv := feedtest.NewServer(t, map[string]feedtest.Route{
+		"/old": {RedirectTo: "/new"},
+		"/new": {Format: feedtest.Atom, Items: 2, Gzip: true},
+	})
+	watcher := &recordingWatcher{}
+	r := newTestReader()
+	r.Watch(watcher)
+
+	got, err := r.FetchFeed(context.Background(), srv.FeedURL("/old"))
+	if err != nil {
+		t.Fatalf("FetchFeed error = %v", err)
+	}
+	if len(got.Articles) != 2 || len(watcher.moved) != 0 {
+		t.Errorf("articles = %d, moves %v; want 2 and no move for a 302", len(got.Articles), watcher.moved)
+	}
+	if enc := srv.LastRequest("/new").Header.Get("Accept-Encoding"); !strings.Contains(enc, "gzip") {
+		t.Errorf("Accept-Encoding = %q, want gzip offered", enc)
+	}
+}
+
+func TestFetchFeedRunsProcessor(t *testing.T) {
+	srv := feedtest.NewServer(t, map[string]feedtest.Route{"/feed": {Items: 4}})
+	articles := store.NewArticleStore()
+	pipeline := ingest.New(ingest.ProcessorFunc{ProcessorName: "odd", Fn: func(ctx context.Context, article *feed.Article) (bool, error) {
+		if article.Title == "Item 3" {
+			return true, errors.New("can't process")
+		}
+		article.Tags = append(article.Tags, "seen")
+		return article.Title != "Item 0" && article.Title != "Item 2", nil
+	}})
+	r := reader.NewRSSReader(articles, reader.WithProcessor(pipeline))
+
+	got, err := r.FetchFeed(context.Background(), srv.FeedURL("/feed"))
+	if err != nil {
+		t.Fatalf("FetchFeed error = %v, want processing errors logged only", err)
+	}
+	stored := articles.GetRecent(10)
+	if len(got.Articles) != 2 || len(stored) != 2 {
+		t.Fatalf("returned %d and stored %d articles, want the 2 kept", len(got.Articles), len(stored))
+	}
+	if stored[0].Title != "Item 3" || len(stored[0].Tags) != 0 || stored[1].Title != "Item 1" || stored[1].Tags[0] != "seen" {
+		t.Errorf("stored %+v, %+v; want Item 3 as parsed and Item 1 tagged", stored[0], stored[1])
+	}
+}
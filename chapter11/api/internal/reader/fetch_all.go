@@ -0,0 +1,185 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+)
+
+// =============================================================================
+// FETCH ALL - Concurrent, bounded fetching of many feeds
+// =============================================================================
+
+// Limits bounds how hard FetchAll works the network.
+type Limits struct {
+	Workers int // Feeds fetched at once overall (default 8)
+	PerHost int // Feeds fetched at once from one host (default 2)
+}
+
+// withDefaults fills in zero limits.
+func (l Limits) withDefaults() Limits {
+	if l.Workers <= 0 {
+		l.Workers = 8
+	}
+	if l.PerHost <= 0 {
+		l.PerHost = 2
+	}
+	return l
+}
+
+// FetchResult is the outcome of fetching one feed.
+type FetchResult struct {
+	URL      string
+	Title    string // Feed title; empty on failure
+	Articles int    // Articles in the feed, including ones already stored
+	Duration time.Duration
+	Err      error // Nil on success
+}
+
+// Report summarizes a FetchAll run. Results are in the order the URLs
+// were given.
+type Report struct {
+	Results  []FetchResult
+	Duration time.Duration
+}
+
+// Failed returns the results that have an error.
+func (r Report) Failed() []FetchResult {
+	var failed []FetchResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Succeeded returns how many feeds were fetched successfully.
+func (r Report) Succeeded() int {
+	return len(r.Results) - len(r.Failed())
+}
+
+// FetchAll fetches every URL with fetcher through a pool of limits.Workers
+// goroutines, never running more than limits.PerHost fetches against the
+// same host. Put the overall deadline on ctx: feeds that haven't started
+// when it expires are reported with the context's error and not attempted.
+func FetchAll(ctx context.Context, fetcher feed.Fetcher, urls []string, limits Limits) Report {
+	start := time.Now()
+	limits = limits.withDefaults()
+
+	// One semaphore per host, created up front so workers never race on the map
+	hostSlots := make(map[string]chan struct{})
+	for _, feedURL := range urls {
+		host := hostOf(feedURL)
+		if _, ok := hostSlots[host]; !ok {
+			hostSlots[host] = make(chan struct{}, limits.PerHost)
+		}
+	}
+
+	results := make([]FetchResult, len(urls))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(limits.Workers, len(urls)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = fetchOne(ctx, fetcher, urls[i], hostSlots[hostOf(urls[i])])
+			}
+		}()
+	}
+
+	for _, i := range interleaveByHost(urls) {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return Report{Results: results, Duration: time.Since(start)}
+}
+
+// FetchAll fetches every URL with this reader. See the package-level FetchAll.
+func (r *RSSReader) FetchAll(ctx context.Context, urls []string, limits Limits) Report {
+	return FetchAll(ctx, r, urls, limits)
+}
+
+// fetchOne waits for a slot on the feed's host, then fetches it.
+func fetchOne(ctx context.Context, fetcher feed.Fetcher, feedURL string, slots chan struct{}) FetchResult {
+	result := FetchResult{URL: feedURL}
+
+	select {
+	case slots <- struct{}{}:
+		defer func() { <-slots }()
+	case <-ctx.Done():
+		result.Err = fmt.Errorf("not attempted: %w", ctx.Err())
+		return result
+	}
+	if err := ctx.Err(); err != nil {
+		result.Err = fmt.Errorf("not attempted: %w", err)
+		return result
+	}
+
+	start := time.Now()
+	fetched, err := fetcher.FetchFeed(ctx, feedURL)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.Title = fetched.Title
+	result.Articles = len(fetched.Articles)
+	return result
+}
+
+// interleaveByHost returns the indexes of urls ordered round-robin across
+// hosts. Workers block while their host is at its limit, so spreading one
+// host's feeds out keeps a busy host from tying up the whole pool.
+func interleaveByHost(urls []string) []int {
+	var hosts []string
+	byHost := make(map[string][]int)
+	for i, feedURL := range urls {
+		host := hostOf(feedURL)
+		if _, ok := byHost[host]; !ok {
+			hosts = append(hosts, host)
+		}
+		byHost[host] = append(byHost[host], i)
+	}
+
+	order := make([]int, 0, len(urls))
+	for len(order) < len(urls) {
+		for _, host := range hosts {
+			if queue := byHost[host]; len(queue) > 0 {
+				order = append(order, queue[0])
+				byHost[host] = queue[1:]
+			}
+		}
+	}
+	return order
+}
+
+// hostOf returns the lower-cased host (with port) of a feed URL, or the
+// raw string if it doesn't parse, so bad URLs still get a slot and fail
+// in the fetcher with a proper error.
+func hostOf(feedURL string) string {
+	u, err := url.Parse(feedURL)
+	if err != nil || u.Host == "" {
+		return feedURL
+	}
+	return strings.ToLower(u.Host)
+}
+
+// Why a pool with per-host limits:
+//
+// - Fetching sequentially makes startup as slow as the sum of every feed's
+//   latency; one unresponsive publisher holds up all the others.
+// - Unbounded goroutines would open a connection per feed at once; the
+//   worker pool caps sockets and memory however many feeds are configured.
+// - Many feeds often share a host (reddit.com/r/...). The per-host limit
+//   keeps us polite to publishers even when the pool is large.
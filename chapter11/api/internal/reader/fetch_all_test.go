@@ -0,0 +1,138 @@
+package reader_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/reader"
+)
+
+// slowFetcher sleeps for delay per fetch and tracks peak concurrency,
+// overall and per host. URLs containing "fail" return an error.
+type slowFetcher struct {
+	delay time.Duration
+
+	mu         sync.Mutex
+	active     int
+	peak       int
+	hostActive map[string]int
+	hostPeak   map[string]int
+}
+
+func newSlowFetcher(delay time.Duration) *slowFetcher {
+	return &slowFetcher{
+		delay:      delay,
+		hostActive: make(map[string]int),
+		hostPeak:   make(map[string]int),
+	}
+}
+
+func (f *slowFetcher) FetchFeed(ctx context.Context, rawURL string) (*feed.Feed, error) {
+	u, _ := url.Parse(rawURL)
+
+	f.mu.Lock()
+	f.active++
+	f.hostActive[u.Host]++
+	f.peak = max(f.peak, f.active)
+	f.hostPeak[u.Host] = max(f.hostPeak[u.Host], f.hostActive[u.Host])
+	f.mu.Unlock()
+
+	defer func() {
+		f.mu.Lock()
+		f.active--
+		f.hostActive[u.Host]--
+		f.mu.Unlock()
+	}()
+
+	select {
+	case <-time.After(f.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if u.Path == "/fail" {
+		return nil, errors.New("boom")
+	}
+	return &feed.Feed{Title: rawURL, Articles: []*feed.Article{{Title: "a"}, {Title: "b"}}}, nil
+}
+
+func TestFetchAllLimits(t *testing.T) {
+	var urls []string
+	for i := range 6 {
+		urls = append(urls, fmt.Sprintf("https://busy.example/feed%d", i))
+	}
+	for i := range 4 {
+		urls = append(urls, fmt.Sprintf("https://host%d.example/feed", i))
+	}
+
+	fetcher := newSlowFetcher(20 * time.Millisecond)
+	report := reader.FetchAll(context.Background(), fetcher, urls, reader.Limits{Workers: 4, PerHost: 2})
+
+	if fetcher.peak > 4 {
+		t.Errorf("peak concurrency = %d, want at most 4 workers", fetcher.peak)
+	}
+	if got := fetcher.hostPeak["busy.example"]; got > 2 {
+		t.Errorf("peak concurrency for busy.example = %d, want at most 2", got)
+	}
+	if fetcher.peak < 2 {
+		t.Errorf("peak concurrency = %d, fetches don't appear to run in parallel", fetcher.peak)
+	}
+
+	if len(report.Results) != len(urls) {
+		t.Fatalf("got %d results, want %d", len(report.Results), len(urls))
+	}
+	for i, result := range report.Results {
+		if result.URL != urls[i] {
+			t.Errorf("result %d is for %s, want input order (%s)", i, result.URL, urls[i])
+		}
+		if result.Err != nil || result.Articles != 2 {
+			t.Errorf("result %d = %+v, want success with 2 articles", i, result)
+		}
+	}
+	if report.Succeeded() != len(urls) {
+		t.Errorf("Succeeded() = %d, want %d", report.Succeeded(), len(urls))
+	}
+}
+
+func TestFetchAllReportsFailures(t *testing.T) {
+	urls := []string{"https://a.example/ok", "https://b.example/fail", "https://c.example/ok"}
+
+	report := reader.FetchAll(context.Background(), newSlowFetcher(0), urls, reader.Limits{})
+
+	failed := report.Failed()
+	if len(failed) != 1 || failed[0].URL != "https://b.example/fail" {
+		t.Fatalf("Failed() = %+v, want only b.example", failed)
+	}
+	if report.Succeeded() != 2 {
+		t.Errorf("Succeeded() = %d, want 2", report.Succeeded())
+	}
+}
+
+func TestFetchAllDeadline(t *testing.T) {
+	urls := []string{
+		"https://slow.example/1",
+		"https://slow.example/2",
+		"https://slow.example/3",
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	report := reader.FetchAll(ctx, newSlowFetcher(time.Minute), urls, reader.Limits{Workers: 1})
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("FetchAll took %s, want it to stop at the deadline", elapsed)
+	}
+	for _, result := range report.Results {
+		if !errors.Is(result.Err, context.DeadlineExceeded) {
+			t.Errorf("%s: error = %v, want deadline exceeded", result.URL, result.Err)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package reader
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+)
+
+// =============================================================================
+// FEED FORMATS - Detecting and parsing RSS, Atom and JSON Feed
+// =============================================================================
+
+// ErrNotFeed is returned when a URL serves a web page rather than a feed.
+// Discover can usually find the feed the page links to.
+var ErrNotFeed = errors.New("URL is an HTML page, not a feed")
+
+// Feed formats recognised by detectFormat.
+const (
+	formatUnknown = ""
+	formatRSS     = "rss"
+	formatAtom    = "atom"
+	formatJSON    = "json"
+	formatHTML    = "html"
+)
+
+// parseFeed detects the format of body and converts it to a domain Feed.
+// contentType is the HTTP Content-Type header, used for its charset and
+// as a hint when the body alone is ambiguous.
+func parseFeed(body []byte, contentType string) (*feed.Feed, error) {
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	charset := params["charset"]
+
+	format, err := detectFormat(body, mediaType, charset)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case formatRSS:
+		rssFeed, err := parseRSS(body, charset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse RSS: %w", err)
+		}
+		return rssFeed.toDomain(), nil
+	case formatAtom:
+		atom, err := parseAtom(body, charset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Atom: %w", err)
+		}
+		return atom.toDomain(), nil
+	case formatJSON:
+		jf, err := parseJSONFeed(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JSON Feed: %w", err)
+		}
+		return jf.toDomain(), nil
+	case formatHTML:
+		return nil, ErrNotFeed
+	default:
+		return nil, fmt.Errorf("unrecognised feed format (Content-Type %q)", mediaType)
+	}
+}
+
+// detectFormat looks at the first element of an XML document, or the
+// first byte of a JSON one, to tell the formats apart. Feeds are often
+// served with generic types like text/xml, so the body is trusted over
+// the media type. An XML document in an encoding the reader can't decode
+// is an ErrUnsupportedCharset error.
+func detectFormat(body []byte, mediaType, charset string) (string, error) {
+	trimmed := bytes.TrimLeft(body, " \t\r\n\ufeff")
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		return formatJSON, nil
+	}
+
+	dec, err := newXMLDecoder(body, charset)
+	if err != nil {
+		return formatUnknown, err
+	}
+	dec.Strict = false
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, ErrUnsupportedCharset) {
+			return formatUnknown, err
+		}
+		if err != nil {
+			break
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			switch strings.ToLower(start.Name.Local) {
+			case "rss":
+				return formatRSS, nil
+			case "feed":
+				return formatAtom, nil
+			case "html":
+				return formatHTML, nil
+			}
+			break // Only the root element matters
+		}
+	}
+
+	if mediaType == "text/html" || mediaType == "application/xhtml+xml" {
+		return formatHTML, nil
+	}
+	return formatUnknown, nil
+}
+
+// newXMLDecoder returns a decoder for data that handles legacy charsets.
+// charset is the HTTP Content-Type charset, if any; it takes precedence
+// over the encoding declared in the XML prolog.
+func newXMLDecoder(data []byte, charset string) (*xml.Decoder, error) {
+	readCharset := charsetReader
+	if charset != "" {
+		decode, err := lookupCharset(charset)
+		if err != nil {
+			return nil, err
+		}
+		if decode != nil {
+			data = transcode(data, decode)
+		}
+		readCharset = passthroughCharsetReader
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.CharsetReader = readCharset
+	return dec, nil
+}
+
+// =============================================================================
+// ATOM
+// =============================================================================
+
+// atomFeed represents the parts of an Atom 1.0 feed (RFC 4287) we use.
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type atomEntry struct {
+	Title     string     `xml:"title"`
+	Summary   string     `xml:"summary"`
+	Content   string     `xml:"content"`
+	Links     []atomLink `xml:"link"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+}
+
+func parseAtom(data []byte, charset string) (*atomFeed, error) {
+	dec, err := newXMLDecoder(data, charset)
+	if err != nil {
+		return nil, err
+	}
+
+	var atom atomFeed
+	if err := dec.Decode(&atom); err != nil {
+		return nil, fmt.Errorf("failed to parse Atom XML: %w", err)
+	}
+	return &atom, nil
+}
+
+// alternateLink returns the entry's web page: the link with rel
+// "alternate", which is also what a link without rel means.
+func alternateLink(links []atomLink) string {
+	for _, link := range links {
+		if link.Rel == "" || link.Rel == "alternate" {
+			return link.Href
+		}
+	}
+	return ""
+}
+
+func (a *atomFeed) toDomain() *feed.Feed {
+	title := strings.TrimSpace(a.Title)
+	domainFeed := &feed.Feed{
+		Title:       title,
+		Description: strings.TrimSpace(a.Subtitle),
+		Link:        alternateLink(a.Links),
+		Articles:    make([]*feed.Article, 0, len(a.Entries)),
+		Hub:         relLink(a.Links, "hub"),
+		Self:        relLink(a.Links, "self"),
+	}
+
+	for _, entry := range a.Entries {
+		article := &feed.Article{
+			Title:       strings.TrimSpace(entry.Title),
+			Description: strings.TrimSpace(entry.Summary),
+			Link:        alternateLink(entry.Links),
+			FeedTitle:   title,
+		}
+		if article.Description == "" {
+			article.Description = strings.TrimSpace(entry.Content)
+		}
+
+		// Atom dates should be RFC 3339; updated is required, published optional
+		setPublished(article, entry.Published, entry.Updated)
+
+		domainFeed.Articles = append(domainFeed.Articles, article)
+	}
+
+	return domainFeed
+}
+
+// =============================================================================
+// JSON FEED
+// =============================================================================
+
+// jsonFeed represents a JSON Feed 1.x document (https://jsonfeed.org).
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	Description string         `json:"description"`
+	FeedURL     string         `json:"feed_url"`
+	Hubs        []jsonFeedHub  `json:"hubs"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedHub struct {
+	Type string `json:"type"` // "WebSub" for the hubs we can use
+	URL  string `json:"url"`
+}
+
+type jsonFeedItem struct {
+	URL           string `json:"url"`
+	Title         string `json:"title"`
+	Summary       string `json:"summary"`
+	ContentText   string `json:"content_text"`
+	ContentHTML   string `json:"content_html"`
+	DatePublished string `json:"date_published"`
+	DateModified  string `json:"date_modified"`
+}
+
+func parseJSONFeed(data []byte) (*jsonFeed, error) {
+	var jf jsonFeed
+	if err := json.Unmarshal(data, &jf); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(jf.Version, "https://jsonfeed.org/version/") {
+		return nil, fmt.Errorf("missing or unknown JSON Feed version %q", jf.Version)
+	}
+	return &jf, nil
+}
+
+func (j *jsonFeed) toDomain() *feed.Feed {
+	domainFeed := &feed.Feed{
+		Title:       j.Title,
+		Description: j.Description,
+		Link:        j.HomePageURL,
+		Articles:    make([]*feed.Article, 0, len(j.Items)),
+	}
+	for _, hub := range j.Hubs {
+		if strings.EqualFold(hub.Type, "WebSub") && hub.URL != "" {
+			domainFeed.Hub = hub.URL
+			domainFeed.Self = j.FeedURL
+			break
+		}
+	}
+
+	for _, item := range j.Items {
+		article := &feed.Article{
+			Title:     item.Title,
+			Link:      item.URL,
+			FeedTitle: j.Title,
+		}
+		for _, text := range []string{item.Summary, item.ContentText, item.ContentHTML} {
+			if text != "" {
+				article.Description = text
+				break
+			}
+		}
+		setPublished(article, item.DatePublished, item.DateModified)
+
+		domainFeed.Articles = append(domainFeed.Articles, article)
+	}
+
+	return domainFeed
+}
@@ -0,0 +1,117 @@
+package reader
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+)
+
+// =============================================================================
+// WEBSUB HUBS - Finding push hubs and ingesting pushed content
+// =============================================================================
+
+// WatchHubs registers w to be told, after every successful fetch, which
+// WebSub hub the feed advertises. Like Watch, w is called from the
+// goroutine that called FetchFeed.
+func (r *RSSReader) WatchHubs(w feed.HubWatcher) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.hubWatchers = append(r.hubWatchers, w)
+}
+
+func (r *RSSReader) hubWatcherList() []feed.HubWatcher {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.hubWatchers
+}
+
+// Ingest parses a feed document pushed to us for url, for example by a
+// WebSub hub, and stores its articles as if url had been fetched.
+func (r *RSSReader) Ingest(url, contentType string, body []byte) (*feed.Feed, error) {
+	if int64(len(body)) > r.maxBodySize {
+		return nil, &BodyTooLargeError{Limit: r.maxBodySize}
+	}
+
+	domainFeed, err := parseFeed(body, contentType)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.store(context.Background(), url, domainFeed); err != nil {
+		return nil, err
+	}
+
+	slog.Info("ingested pushed feed", "url", url, "articles", len(domainFeed.Articles))
+	r.recordUnparsedDates(url, domainFeed.Articles)
+	return domainFeed, nil
+}
+
+// applyHubLinks sets the feed's hub and self URLs from the HTTP Link
+// header, which WebSub says takes precedence over links in the document,
+// and resolves relative URLs against the document's URL.
+func applyHubLinks(domainFeed *feed.Feed, doc *document) {
+	if hub := linkHeader(doc.header, "hub"); hub != "" {
+		domainFeed.Hub = hub
+		if self := linkHeader(doc.header, "self"); self != "" {
+			domainFeed.Self = self
+		}
+	}
+	if domainFeed.Hub == "" {
+		domainFeed.Self = ""
+		return
+	}
+
+	base, err := url.Parse(doc.url)
+	if err != nil {
+		return
+	}
+	for _, link := range []*string{&domainFeed.Hub, &domainFeed.Self} {
+		if ref, err := url.Parse(*link); err == nil && *link != "" {
+			*link = base.ResolveReference(ref).String()
+		}
+	}
+}
+
+// linkHeader returns the first URL in the response's Link headers with
+// the given rel, e.g. `<https://hub.example/>; rel="hub"`.
+func linkHeader(header http.Header, rel string) string {
+	for _, value := range header.Values("Link") {
+		for _, link := range strings.Split(value, ",") {
+			target, params, ok := strings.Cut(link, ";")
+			target = strings.TrimSpace(target)
+			if !ok || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if strings.EqualFold(name, "rel") && hasToken(strings.Trim(value, `"`), rel) {
+					return strings.Trim(target, "<>")
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// relLink returns the href of the first Atom link with the given rel.
+func relLink(links []atomLink, rel string) string {
+	for _, link := range links {
+		if hasToken(link.Rel, rel) {
+			return strings.TrimSpace(link.Href)
+		}
+	}
+	return ""
+}
+
+// Why hubs are reported on every fetch:
+//
+// - A feed can start or stop advertising a hub, or switch hubs, at any
+//   time; reporting the current answer lets the subscriber converge
+//   without the reader remembering anything.
+// - Polling carries on for feeds with a hub, so a hub that stops pushing
+//   only slows updates down instead of silently stopping them.
@@ -0,0 +1,124 @@
+package reader_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// hubRecorder is a test double for feed.HubWatcher.
+type hubRecorder struct {
+	mu         sync.Mutex
+	hub, topic string
+	calls      int
+}
+
+func (h *hubRecorder) HubAdvertised(url, hub, topic string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hub, h.topic = hub, topic
+	h.calls++
+}
+
+func TestFetchFeedReportsHubs(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		link        string // Link response header
+		body        string
+		wantHub     string // "/" prefixed paths are relative to the test server
+		wantTopic   string
+		wantLink    string // The feed's web page, which atom:link must not clobber
+	}{
+		{
+			name:        "RSS with atom:link",
+			contentType: "application/rss+xml",
+			body: `<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom"><channel><title>T</title><link>https://example.com/</link>
+<atom:link rel="hub" href="https://hub.example/"/><atom:link rel="self" href="https://example.com/rss"/></channel></rss>`,
+			wantHub:   "https://hub.example/",
+			wantTopic: "https://example.com/rss",
+			wantLink:  "https://example.com/",
+		},
+		{
+			name:        "Atom with a relative hub",
+			contentType: "application/atom+xml",
+			body: `<feed xmlns="http://www.w3.org/2005/Atom"><title>T</title><link href="https://example.com/"/>
+<link rel="hub" href="/hub"/><link rel="self" href="https://example.com/atom"/></feed>`,
+			wantHub:   "/hub",
+			wantTopic: "https://example.com/atom",
+			wantLink:  "https://example.com/",
+		},
+		{
+			name:        "JSON Feed",
+			contentType: "application/feed+json",
+			body:        `{"version":"https://jsonfeed.org/version/1.1","title":"T","home_page_url":"https://example.com/","feed_url":"https://example.com/feed.json","hubs":[{"type":"rssCloud","url":"https://cloud.example/"},{"type":"WebSub","url":"https://hub.example/"}]}`,
+			wantHub:     "https://hub.example/",
+			wantTopic:   "https://example.com/feed.json",
+			wantLink:    "https://example.com/",
+		},
+		{
+			name:        "Link header wins",
+			contentType: "application/atom+xml",
+			link:        `<https://other-hub.example/>; rel="hub", <https://example.com/canonical>; rel="self"`,
+			body:        `<feed xmlns="http://www.w3.org/2005/Atom"><title>T</title><link rel="hub" href="https://hub.example/"/></feed>`,
+			wantHub:     "https://other-hub.example/",
+			wantTopic:   "https://example.com/canonical",
+		},
+		{
+			name:        "no hub",
+			contentType: "application/rss+xml",
+			body:        testRSS,
+			wantLink:    "https://example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				if tt.link != "" {
+					w.Header().Set("Link", tt.link)
+				}
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			r := newTestReader()
+			watcher := &hubRecorder{}
+			r.WatchHubs(watcher)
+
+			got, err := r.FetchFeed(context.Background(), srv.URL)
+			if err != nil {
+				t.Fatalf("FetchFeed error = %v", err)
+			}
+
+			wantHub := tt.wantHub
+			if len(wantHub) > 0 && wantHub[0] == '/' {
+				wantHub = srv.URL + wantHub
+			}
+			if watcher.calls != 1 || watcher.hub != wantHub || watcher.topic != tt.wantTopic {
+				t.Errorf("HubAdvertised = %q, %q (%d calls), want %q, %q", watcher.hub, watcher.topic, watcher.calls, wantHub, tt.wantTopic)
+			}
+			if got.Link != tt.wantLink {
+				t.Errorf("Link = %q, want %q", got.Link, tt.wantLink)
+			}
+		})
+	}
+}
+
+func TestIngest(t *testing.T) {
+	r := newTestReader()
+	got, err := r.Ingest("https://example.com/rss", "application/rss+xml", []byte(testRSS))
+	if err != nil {
+		t.Fatalf("Ingest error = %v", err)
+	}
+	if len(got.Articles) != 1 || got.Articles[0].Title != "One" {
+		t.Errorf("Ingest = %+v, want the pushed article", got.Articles)
+	}
+
+	if _, err := r.Ingest("https://example.com/rss", "text/html", []byte("<html></html>")); err == nil {
+		t.Error("Ingest of an HTML page succeeded")
+	}
+}
@@ -3,9 +3,12 @@ package reader
 import (
 	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
-	"io"
+	"log/slog"
 	"net/http"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
@@ -15,32 +18,200 @@ import (
 // RSS READER - Infrastructure adapter implementing feed.Fetcher
 // =============================================================================
 
-// Compile-time verification that RSSReader implements feed.Fetcher
-var _ feed.Fetcher = (*RSSReader)(nil)
+// Compile-time verification that RSSReader implements feed.Fetcher and feed.Backoff
+var (
+	_ feed.Fetcher = (*RSSReader)(nil)
+	_ feed.Backoff = (*RSSReader)(nil)
+)
 
 // RSSReader fetches and parses RSS feeds using a simplified RSS parser.
 // In production, you'd typically use github.com/mmcdole/gofeed, but this
 // demonstrates the adapter pattern: converting external formats to domain types.
 type RSSReader struct {
-	client  *http.Client
-	storage feed.Storage // Dependency injection of storage interface
+	client    *http.Client
+	storage   feed.Storage          // Dependency injection of storage interface
+	processor feed.ArticleProcessor // Prepares articles for storage; nil stores them as parsed
+
+	retry       RetryPolicy
+	breaker     BreakerPolicy
+	maxBodySize int64
+	politeness  PolitenessPolicy
+	now         func() time.Time
+
+	mu          sync.RWMutex
+	statuses    map[string]*feed.FetchStatus  // Keyed by feed URL
+	settings    map[string]feed.FetchSettings // Keyed by feed URL
+	clients     map[string]*http.Client       // Keyed by proxy and CA bundle
+	watchers    []feed.Watcher
+	hubWatchers []feed.HubWatcher
+	robots      map[string]*robotsEntry // Keyed by origin, proxy and CA bundle
+	nextRequest map[string]time.Time    // Keyed by host
+}
+
+// Option configures optional RSSReader settings.
+type Option func(*RSSReader)
+
+// WithTimeout sets the timeout for a single feed fetch (default 30s).
+func WithTimeout(timeout time.Duration) Option {
+	return func(r *RSSReader) {
+		r.client.Timeout = timeout
+	}
+}
+
+// WithTransport sends every request through rt, for example to replay
+// recorded responses. Per-feed proxies and CA bundles are then ignored.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(r *RSSReader) {
+		r.client.Transport = rt
+	}
+}
+
+// WithProcessor runs every fetched or pushed feed's articles through p
+// before they are stored, for example an ingest pipeline.
+func WithProcessor(p feed.ArticleProcessor) Option {
+	return func(r *RSSReader) {
+		r.processor = p
+	}
 }
 
 // NewRSSReader creates a new RSS reader with the given storage dependency.
 // This is constructor injection - dependencies are explicit and testable.
-func NewRSSReader(storage feed.Storage) *RSSReader {
-	return &RSSReader{
+// Optional settings are supplied as functional options.
+func NewRSSReader(storage feed.Storage, opts ...Option) *RSSReader {
+	r := &RSSReader{
 		client: &http.Client{
-			Timeout: 30 * time.Second,
+			CheckRedirect: checkRedirect,
+			Timeout:       30 * time.Second,
 		},
 		storage: storage,
+		retry: RetryPolicy{
+			MaxAttempts: 3,
+			BaseDelay:   time.Second,
+			MaxDelay:    30 * time.Second,
+		},
+		breaker: BreakerPolicy{
+			Threshold:   3,
+			Cooldown:    5 * time.Minute,
+			MaxCooldown: 6 * time.Hour,
+		},
+		maxBodySize: DefaultMaxBodySize,
+		now:         time.Now,
+		statuses:    make(map[string]*feed.FetchStatus),
+		settings:    make(map[string]feed.FetchSettings),
+		clients:     make(map[string]*http.Client),
+		robots:      make(map[string]*robotsEntry),
+		nextRequest: make(map[string]time.Time),
+	}
+
+	for _, opt := range opts {
+		opt(r)
 	}
+
+	return r
 }
 
 // FetchFeed implements feed.Fetcher by fetching and parsing an RSS feed.
 // This method demonstrates the full flow: fetch → parse → convert → store.
+// Transient failures are retried; while the feed's circuit breaker is open
+// it returns ErrCircuitOpen without making a request.
 func (r *RSSReader) FetchFeed(ctx context.Context, url string) (*feed.Feed, error) {
-	fmt.Printf("Fetching feed: %s\n", url)
+	if retryAt := r.RetryAt(url); r.now().Before(retryAt) {
+		return nil, fmt.Errorf("%w (next attempt %s)", ErrCircuitOpen, retryAt.Format(time.RFC3339))
+	}
+
+	slog.Debug("fetching feed", "url", url)
+
+	domainFeed, movedTo, err := r.fetchWithRetry(ctx, url)
+	if ctx.Err() != nil && err != nil {
+		// Cancelled by the caller (shutdown, feed removed): not the feed's fault
+		return nil, err
+	}
+	r.recordAttempt(url, err)
+	if err == nil {
+		r.recordUnparsedDates(url, domainFeed.Articles)
+	}
+
+	// Watchers run last: they may stop polling url and Forget its status
+	if errors.Is(err, ErrGone) {
+		slog.Warn("feed is gone", "url", url)
+		for _, w := range r.watcherList() {
+			w.Gone(url)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("fetched feed", "url", url, "articles", len(domainFeed.Articles))
+	for _, w := range r.hubWatcherList() {
+		w.HubAdvertised(url, domainFeed.Hub, domainFeed.Self)
+	}
+	if movedTo != "" {
+		slog.Info("feed moved permanently", "url", url, "to", movedTo)
+		for _, w := range r.watcherList() {
+			w.Moved(url, movedTo)
+		}
+	}
+	return domainFeed, nil
+}
+
+// fetch performs a single fetch → parse → convert → store cycle. It also
+// returns the URL the feed has permanently moved to, if any.
+func (r *RSSReader) fetch(ctx context.Context, url string) (*feed.Feed, string, error) {
+	doc, err := r.get(ctx, url, r.settingsFor(url))
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Detect RSS, Atom or JSON Feed and convert to the domain Feed type
+	domainFeed, err := parseFeed(doc.body, doc.contentType)
+	if err != nil {
+		return nil, "", err
+	}
+	applyHubLinks(domainFeed, doc)
+
+	// Store articles using the injected storage dependency
+	if err := r.store(ctx, url, domainFeed); err != nil {
+		return nil, "", err
+	}
+
+	return domainFeed, doc.movedTo, nil
+}
+
+// store runs a feed's articles through the processor, keeping only those
+// it returns, and stores them. Processing errors are per article, so they
+// are logged rather than failing the fetch.
+func (r *RSSReader) store(ctx context.Context, url string, domainFeed *feed.Feed) error {
+	if r.processor != nil {
+		articles, err := r.processor.ProcessArticles(ctx, url, domainFeed.Articles)
+		if err != nil {
+			slog.Warn("article processing failed", "url", url, "error", err)
+		}
+		domainFeed.Articles = articles
+	}
+
+	if err := r.storage.AddArticles(domainFeed.Articles); err != nil {
+		return fmt.Errorf("failed to store articles: %w", err)
+	}
+	return nil
+}
+
+// document is a successfully downloaded response body.
+type document struct {
+	url         string // Final URL, after any redirects
+	movedTo     string // Where the requested URL permanently moved; empty if it didn't
+	contentType string
+	header      http.Header
+	body        []byte
+}
+
+// get downloads url with the given settings, enforcing the status code
+// and body size limits.
+func (r *RSSReader) get(ctx context.Context, url string, settings feed.FetchSettings) (*document, error) {
+	client, err := r.clientFor(settings)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create HTTP request with context for cancellation support
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -48,66 +219,124 @@ func (r *RSSReader) FetchFeed(ctx context.Context, url string) (*feed.Feed, erro
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Set User-Agent to identify our application
-	req.Header.Set("User-Agent", "Go-News-RSS-Reader/1.0")
+	// Identify our application, plus any per-feed headers and credentials
+	req = applySettings(req, settings)
+
+	// Ask for compression ourselves so readBody can bound both sizes
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	// Stay out of what robots.txt forbids, and keep our distance from the host
+	crawlDelay, err := r.checkRobots(ctx, req.URL, req.Header.Get("User-Agent"), settings)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.pace(ctx, req.URL.Host, max(r.politeness.MinInterval, crawlDelay)); err != nil {
+		return nil, err
+	}
 
 	// Fetch the feed
-	resp, err := r.client.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch feed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, &StatusError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), r.now()),
+		}
 	}
+	movedTo := permanentRedirect(resp)
 
-	// Read and parse the response
-	body, err := io.ReadAll(resp.Body)
+	// Read the response
+	body, err := readBody(resp, r.maxBodySize)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	// Parse as RSS 2.0 (simplified - real implementation would detect format)
-	rssFeed, err := parseRSS(body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse RSS: %w", err)
-	}
+	return &document{
+		url:         resp.Request.URL.String(),
+		movedTo:     movedTo,
+		contentType: resp.Header.Get("Content-Type"),
+		header:      resp.Header,
+		body:        body,
+	}, nil
+}
 
-	// Convert from RSS structs to domain Feed type (adapter pattern)
-	domainFeed := &feed.Feed{
-		Title:       rssFeed.Channel.Title,
-		Description: rssFeed.Channel.Description,
-		Link:        rssFeed.Channel.Link,
-		Articles:    make([]*feed.Article, 0, len(rssFeed.Channel.Items)),
+// recordAttempt updates the fetch status for url after an attempt,
+// opening the circuit breaker once failures reach the threshold.
+func (r *RSSReader) recordAttempt(url string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	status, ok := r.statuses[url]
+	if !ok {
+		status = &feed.FetchStatus{URL: url}
+		r.statuses[url] = status
 	}
 
-	// Convert each RSS item to a domain Article
-	for _, item := range rssFeed.Channel.Items {
-		article := &feed.Article{
-			Title:       item.Title,
-			Description: item.Description,
-			Link:        item.Link,
-			FeedTitle:   rssFeed.Channel.Title,
-		}
+	now := r.now()
+	status.LastAttempt = now
+	status.Gone = errors.Is(err, ErrGone)
+	if err != nil {
+		status.LastError = err.Error()
+		status.ConsecutiveFailures++
 
-		// Parse publication date if present
-		if item.PubDate != "" {
-			if pubTime, err := parseRFC822(item.PubDate); err == nil {
-				article.Published = &pubTime
-			}
+		var retryAfter time.Duration
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) {
+			retryAfter = statusErr.RetryAfter
+		}
+		status.RetryAt = r.breaker.openUntil(now, status.ConsecutiveFailures, retryAfter)
+		if !status.RetryAt.IsZero() {
+			slog.Warn("feed backing off", "url", url, "failures", status.ConsecutiveFailures, "until", status.RetryAt)
 		}
+		return
+	}
+	status.LastSuccess = now
+	status.LastError = ""
+	status.ConsecutiveFailures = 0
+	status.RetryAt = time.Time{}
+}
 
-		domainFeed.Articles = append(domainFeed.Articles, article)
+// RetryAt returns when url's circuit breaker next lets a fetch through,
+// or the zero time if it is closed. It implements feed.Backoff.
+func (r *RSSReader) RetryAt(url string) time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if status, ok := r.statuses[url]; ok {
+		return status.RetryAt
 	}
+	return time.Time{}
+}
 
-	// Store articles using the injected storage dependency
-	if err := r.storage.AddArticles(domainFeed.Articles); err != nil {
-		return nil, fmt.Errorf("failed to store articles: %w", err)
+// Forget drops the fetch status and settings of a feed that is no longer
+// subscribed.
+func (r *RSSReader) Forget(url string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.statuses, url)
+	delete(r.settings, url)
+}
+
+// FeedStatuses returns a snapshot of the fetch status of every feed this
+// reader has attempted, sorted by URL.
+func (r *RSSReader) FeedStatuses() []feed.FetchStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]feed.FetchStatus, 0, len(r.statuses))
+	for _, status := range r.statuses {
+		result = append(result, *status)
 	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].URL < result[j].URL
+	})
 
-	fmt.Printf("Fetched %d articles from: %s\n", len(domainFeed.Articles), url)
-	return domainFeed, nil
+	return result
 }
 
 // =============================================================================
@@ -121,10 +350,13 @@ type rss struct {
 }
 
 type channel struct {
-	Title       string `xml:"title"`
-	Description string `xml:"description"`
-	Link        string `xml:"link"`
-	Items       []item `xml:"item"`
+	// atom:link must come before link: an unqualified tag matches
+	// elements in any namespace, so link would swallow atom:link
+	AtomLinks   []atomLink `xml:"http://www.w3.org/2005/Atom link"`
+	Title       string     `xml:"title"`
+	Description string     `xml:"description"`
+	Link        string     `xml:"link"`
+	Items       []item     `xml:"item"`
 }
 
 type item struct {
@@ -134,36 +366,45 @@ type item struct {
 	PubDate     string `xml:"pubDate"`
 }
 
-// parseRSS unmarshals RSS XML into structured data
-func parseRSS(data []byte) (*rss, error) {
+// parseRSS unmarshals RSS XML into structured data. charset is the HTTP
+// Content-Type charset, if any; it takes precedence over the encoding
+// declared in the XML prolog.
+func parseRSS(data []byte, charset string) (*rss, error) {
+	dec, err := newXMLDecoder(data, charset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse RSS XML: %w", err)
+	}
+
 	var rssFeed rss
-	if err := xml.Unmarshal(data, &rssFeed); err != nil {
+	if err := dec.Decode(&rssFeed); err != nil {
 		return nil, fmt.Errorf("failed to parse RSS XML: %w", err)
 	}
 	return &rssFeed, nil
 }
 
-// parseRFC822 attempts to parse common RSS date formats.
-// RSS 2.0 uses RFC 822, but feeds often vary in their date formatting.
-func parseRFC822(dateStr string) (time.Time, error) {
-	// Common RSS date formats to try in order
-	formats := []string{
-		time.RFC1123Z,
-		time.RFC1123,
-		time.RFC822Z,
-		time.RFC822,
-		"Mon, 2 Jan 2006 15:04:05 -0700",
-		"Mon, 2 Jan 2006 15:04:05 MST",
-	}
-
-	var lastErr error
-	for _, format := range formats {
-		if t, err := time.Parse(format, dateStr); err == nil {
-			return t, nil
-		} else {
-			lastErr = err
+// toDomain converts from RSS structs to the domain Feed type (adapter pattern).
+func (r *rss) toDomain() *feed.Feed {
+	domainFeed := &feed.Feed{
+		Title:       r.Channel.Title,
+		Description: r.Channel.Description,
+		Link:        r.Channel.Link,
+		Articles:    make([]*feed.Article, 0, len(r.Channel.Items)),
+		Hub:         relLink(r.Channel.AtomLinks, "hub"),
+		Self:        relLink(r.Channel.AtomLinks, "self"),
+	}
+
+	// Convert each RSS item to a domain Article
+	for _, item := range r.Channel.Items {
+		article := &feed.Article{
+			Title:       item.Title,
+			Description: item.Description,
+			Link:        item.Link,
+			FeedTitle:   r.Channel.Title,
 		}
+		setPublished(article, item.PubDate)
+
+		domainFeed.Articles = append(domainFeed.Articles, article)
 	}
 
-	return time.Time{}, fmt.Errorf("unable to parse date %q: %w", dateStr, lastErr)
+	return domainFeed
 }
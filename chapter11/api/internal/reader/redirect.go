@@ -0,0 +1,60 @@
+package reader
+
+import (
+	"net/http"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+)
+
+// =============================================================================
+// MOVED AND GONE FEEDS - Telling subscriptions about permanent changes
+// =============================================================================
+
+// Watch registers w to be told when a fetched feed has permanently moved
+// (a 301 or 308 redirect) or is gone (410). Watchers are called from the
+// goroutine that called FetchFeed.
+func (r *RSSReader) Watch(w feed.Watcher) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.watchers = append(r.watchers, w)
+}
+
+func (r *RSSReader) watcherList() []feed.Watcher {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.watchers
+}
+
+// permanentRedirect returns the URL the request permanently moved to: the
+// end of the leading run of 301 and 308 redirects. A temporary redirect
+// ends the run, since only the hops before it are permanent.
+func permanentRedirect(resp *http.Response) string {
+	// Each redirected request links to the response that caused it, so
+	// walking back from the final request lists the hops in reverse
+	var hops []*http.Request
+	for req := resp.Request; req.Response != nil; req = req.Response.Request {
+		hops = append(hops, req)
+	}
+
+	movedTo := ""
+	for i := len(hops) - 1; i >= 0; i-- {
+		switch hops[i].Response.StatusCode {
+		case http.StatusMovedPermanently, http.StatusPermanentRedirect:
+			movedTo = hops[i].URL.String()
+		default:
+			return movedTo
+		}
+	}
+	return movedTo
+}
+
+// Why only 301 and 308:
+//
+// - 302, 303 and 307 say "for now"; following them permanently would pin
+//   subscriptions to URLs publishers expect to change back.
+// - A temporary hop anywhere in the chain means the final URL isn't a
+//   permanent address, so only the permanent prefix counts.
+// - The reader reports moves and leaves the decision to watchers, so it
+//   stays free of subscription storage.
@@ -0,0 +1,117 @@
+package reader_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/reader"
+)
+
+// recordingWatcher is a test double for feed.Watcher.
+type recordingWatcher struct {
+	mu    sync.Mutex
+	moved [][2]string
+	gone  []string
+}
+
+func (w *recordingWatcher) Moved(from, to string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.moved = append(w.moved, [2]string{from, to})
+}
+
+func (w *recordingWatcher) Gone(url string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.gone = append(w.gone, url)
+}
+
+func TestFetchFeedRedirects(t *testing.T) {
+	// Each path redirects with its status code to the next; /feed serves
+	redirects := map[string]struct {
+		code int
+		to   string
+	}{
+		"/moved":          {http.StatusMovedPermanently, "/feed"},
+		"/permanent":      {http.StatusPermanentRedirect, "/moved"},
+		"/temporary":      {http.StatusFound, "/feed"},
+		"/moved-then-tmp": {http.StatusMovedPermanently, "/temporary"},
+		"/tmp-then-moved": {http.StatusTemporaryRedirect, "/moved"},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hop, ok := redirects[r.URL.Path]; ok {
+			http.Redirect(w, r, hop.to, hop.code)
+			return
+		}
+		if r.URL.Path == "/feed" {
+			w.Header().Set("Content-Type", "application/rss+xml")
+			w.Write([]byte(testRSS))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		path        string
+		wantMovedTo string // Empty if no move should be reported
+	}{
+		{"/feed", ""},
+		{"/moved", "/feed"},
+		{"/permanent", "/feed"}, // 308 then 301: both permanent
+		{"/temporary", ""},
+		{"/moved-then-tmp", "/temporary"}, // Only the permanent prefix counts
+		{"/tmp-then-moved", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			watcher := &recordingWatcher{}
+			r := newTestReader()
+			r.Watch(watcher)
+
+			if _, err := r.FetchFeed(context.Background(), srv.URL+tt.path); err != nil {
+				t.Fatalf("FetchFeed error = %v", err)
+			}
+
+			if tt.wantMovedTo == "" {
+				if len(watcher.moved) != 0 {
+					t.Errorf("moves = %v, want none", watcher.moved)
+				}
+				return
+			}
+			want := [2]string{srv.URL + tt.path, srv.URL + tt.wantMovedTo}
+			if len(watcher.moved) != 1 || watcher.moved[0] != want {
+				t.Errorf("moves = %v, want [%v]", watcher.moved, want)
+			}
+		})
+	}
+}
+
+func TestFetchFeedGone(t *testing.T) {
+	srv, requests := flakyServer(t, nil, http.StatusGone, http.StatusGone, http.StatusGone)
+	watcher := &recordingWatcher{}
+	r := newTestReader()
+	r.Watch(watcher)
+
+	_, err := r.FetchFeed(context.Background(), srv.URL)
+	if !errors.Is(err, reader.ErrGone) {
+		t.Fatalf("FetchFeed error = %v, want ErrGone", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("requests = %d, want 1 (410 is not retried)", n)
+	}
+	if len(watcher.gone) != 1 || watcher.gone[0] != srv.URL {
+		t.Errorf("gone = %v, want [%s]", watcher.gone, srv.URL)
+	}
+
+	statuses := r.FeedStatuses()
+	if len(statuses) != 1 || !statuses[0].Gone {
+		t.Errorf("statuses = %+v, want the feed marked gone", statuses)
+	}
+}
@@ -0,0 +1,179 @@
+package reader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+)
+
+// =============================================================================
+// RETRIES AND CIRCUIT BREAKING - Riding out flaky publishers
+// =============================================================================
+
+// ErrCircuitOpen is returned without touching the network while a feed's
+// circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit open: feed is backing off after repeated failures")
+
+// ErrGone is returned when a feed answers 410 Gone: its publisher has
+// removed it for good, so it is neither retried nor worth polling again.
+var ErrGone = errors.New("feed is gone")
+
+// StatusError is returned when a feed responds with a non-200 status.
+type StatusError struct {
+	StatusCode int
+	RetryAfter time.Duration // From the Retry-After header; zero if absent
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+}
+
+// Is lets errors.Is(err, ErrGone) match a 410 response.
+func (e *StatusError) Is(target error) bool {
+	return target == ErrGone && e.StatusCode == http.StatusGone
+}
+
+// RetryPolicy controls retries within a single FetchFeed call.
+type RetryPolicy struct {
+	MaxAttempts int           // Total attempts, including the first; 1 disables retries
+	BaseDelay   time.Duration // Delay before the first retry; doubles each time
+	MaxDelay    time.Duration // Cap on a single delay, including Retry-After
+}
+
+// BreakerPolicy controls the per-feed circuit breaker.
+type BreakerPolicy struct {
+	Threshold   int           // Consecutive failed fetches that open the circuit; 0 disables it
+	Cooldown    time.Duration // How long the circuit first stays open; doubles per further failure
+	MaxCooldown time.Duration // Cap on the open period
+}
+
+// WithRetry sets the retry policy for transient failures
+// (default 3 attempts, 1s base delay, 30s max delay).
+func WithRetry(policy RetryPolicy) Option {
+	return func(r *RSSReader) {
+		r.retry = policy
+	}
+}
+
+// WithCircuitBreaker sets the per-feed circuit breaker policy
+// (default: open after 3 failures for 5m, doubling up to 6h).
+func WithCircuitBreaker(policy BreakerPolicy) Option {
+	return func(r *RSSReader) {
+		r.breaker = policy
+	}
+}
+
+// fetchWithRetry calls fetch, retrying transient failures with exponential
+// backoff and jitter. A Retry-After longer than MaxDelay ends the retries
+// and is returned in the StatusError so the breaker can honour it.
+func (r *RSSReader) fetchWithRetry(ctx context.Context, url string) (*feed.Feed, string, error) {
+	for attempt := 1; ; attempt++ {
+		result, movedTo, err := r.fetch(ctx, url)
+		if err == nil || attempt >= r.retry.MaxAttempts || !isTransient(err) {
+			return result, movedTo, err
+		}
+
+		delay := jitter(backoffDelay(r.retry.BaseDelay, r.retry.MaxDelay, attempt))
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+			if statusErr.RetryAfter > r.retry.MaxDelay {
+				return nil, "", err
+			}
+			delay = statusErr.RetryAfter
+		}
+
+		slog.Debug("retrying feed", "url", url, "attempt", attempt, "delay", delay, "error", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, "", err
+		case <-timer.C:
+		}
+	}
+}
+
+// isTransient reports whether a failed fetch is worth retrying:
+// timeouts, 5xx responses and 429 Too Many Requests.
+func isTransient(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// backoffDelay returns base doubled for each attempt after the first,
+// capped at max.
+func backoffDelay(base, max time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	return min(delay, max)
+}
+
+// jitter returns a random duration in [d/2, d), so feeds that failed
+// together don't all retry at the same instant.
+func jitter(d time.Duration) time.Duration {
+	if d <= 1 {
+		return d
+	}
+	half := d / 2
+	return half + rand.N(d-half)
+}
+
+// parseRetryAfter reads a Retry-After header given as seconds or an
+// HTTP date. It returns zero if the header is absent or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// openUntil returns when a feed's open circuit lets the next fetch through
+// after failures consecutive failures, or the zero time if it stays closed.
+// A server's Retry-After is honoured when it asks for longer.
+func (p BreakerPolicy) openUntil(now time.Time, failures int, retryAfter time.Duration) time.Time {
+	var wait time.Duration
+	if p.Threshold > 0 && failures >= p.Threshold {
+		wait = backoffDelay(p.Cooldown, p.MaxCooldown, failures-p.Threshold+1)
+	}
+	wait = max(wait, retryAfter)
+	if wait == 0 {
+		return time.Time{}
+	}
+	return now.Add(wait)
+}
+
+// Retries vs circuit breaking:
+//
+// - Retries handle blips: a timeout or a 503 during a deploy usually
+//   clears within seconds, so trying again right away is cheap and saves
+//   waiting a whole polling interval.
+// - The breaker handles outages: once a feed has failed several polls in
+//   a row, hammering it every interval wastes our time and theirs. It
+//   stays open for a growing cooldown, then lets one fetch through.
+// - Permanent errors (404, bad XML) aren't retried; only the breaker
+//   slows them down.
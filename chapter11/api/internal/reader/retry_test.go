@@ -0,0 +1,133 @@
+package reader_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/reader"
+	"github.com/YOUR_USERNAME/go-news/api/internal/store"
+)
+
+const testRSS = `<?xml version="1.0"?>
+<rss version="2.0"><channel><title>Test</title><link>https://example.com</link>
+<item><title>One</title><link>https://example.com/1</link></item>
+</channel></rss>`
+
+// flakyServer answers with the given status codes in turn, then serves
+// testRSS. It counts every request.
+func flakyServer(t *testing.T, headers http.Header, codes ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(requests.Add(1))
+		if n <= len(codes) {
+			for key, values := range headers {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(codes[n-1])
+			return
+		}
+		w.Write([]byte(testRSS))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func newTestReader(opts ...reader.Option) *reader.RSSReader {
+	opts = append([]reader.Option{
+		reader.WithRetry(reader.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}),
+	}, opts...)
+	return reader.NewRSSReader(store.NewArticleStore(), opts...)
+}
+
+func TestFetchFeedRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		codes        []int
+		headers      http.Header
+		wantErr      bool
+		wantRequests int32
+	}{
+		{"recovers from 5xx", []int{503, 502}, nil, false, 3},
+		{"gives up after max attempts", []int{500, 500, 500}, nil, true, 3},
+		{"404 is not retried", []int{404}, nil, true, 1},
+		{"429 honours short Retry-After", []int{429}, http.Header{"Retry-After": {"0"}}, false, 2},
+		{"429 with long Retry-After is not retried", []int{429}, http.Header{"Retry-After": {"3600"}}, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := flakyServer(t, tt.headers, tt.codes...)
+			r := newTestReader()
+
+			_, err := r.FetchFeed(context.Background(), srv.URL)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FetchFeed error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("server got %d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestFetchFeedRetriesTimeouts(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Write([]byte(testRSS))
+	}))
+	defer srv.Close()
+
+	r := newTestReader(reader.WithTimeout(50 * time.Millisecond))
+	if _, err := r.FetchFeed(context.Background(), srv.URL); err != nil {
+		t.Fatalf("FetchFeed error = %v, want success after retrying the timeout", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("server got %d requests, want 2", got)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	srv, requests := flakyServer(t, nil, 404, 404, 404, 404)
+	r := newTestReader(reader.WithCircuitBreaker(reader.BreakerPolicy{
+		Threshold:   2,
+		Cooldown:    time.Hour,
+		MaxCooldown: time.Hour,
+	}))
+	ctx := context.Background()
+
+	// The first failure leaves the circuit closed
+	r.FetchFeed(ctx, srv.URL)
+	if !r.RetryAt(srv.URL).IsZero() {
+		t.Fatal("circuit opened after one failure, want threshold of 2")
+	}
+
+	// The second opens it for the cooldown
+	r.FetchFeed(ctx, srv.URL)
+	retryAt := r.RetryAt(srv.URL)
+	if until := time.Until(retryAt); until < 59*time.Minute || until > time.Hour {
+		t.Fatalf("RetryAt is %s away, want about 1h", until)
+	}
+
+	// While open, fetches fail fast without a request
+	_, err := r.FetchFeed(ctx, srv.URL)
+	if !errors.Is(err, reader.ErrCircuitOpen) {
+		t.Fatalf("FetchFeed error = %v, want ErrCircuitOpen", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("server got %d requests, want 2", got)
+	}
+
+	statuses := r.FeedStatuses()
+	if len(statuses) != 1 || statuses[0].ConsecutiveFailures != 2 {
+		t.Fatalf("FeedStatuses() = %+v, want 2 consecutive failures", statuses)
+	}
+}
@@ -0,0 +1,365 @@
+package reader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+)
+
+// =============================================================================
+// POLITENESS - robots.txt rules, Crawl-delay and per-host pacing
+// =============================================================================
+
+// ErrDisallowed is returned for URLs a host's robots.txt doesn't let us
+// fetch. It is not retried.
+var ErrDisallowed = errors.New("disallowed by robots.txt")
+
+// DefaultRobotsTTL is how long a robots.txt is cached. RFC 9309 asks
+// crawlers not to rely on a cached copy for longer than a day.
+const DefaultRobotsTTL = 24 * time.Hour
+
+// DefaultMaxCrawlDelay is the longest Crawl-delay honoured unless the
+// policy says otherwise.
+const DefaultMaxCrawlDelay = time.Minute
+
+// robotsRetryTTL is how long an unreachable robots.txt blocks its host
+// before it is fetched again.
+const robotsRetryTTL = 10 * time.Minute
+
+// maxRobotsSize is the most of a robots.txt that is read; RFC 9309 asks
+// for at least 500 KiB.
+const maxRobotsSize = 512 << 10
+
+// PolitenessPolicy controls how considerately the reader treats hosts.
+// The zero value neither reads robots.txt nor paces requests.
+type PolitenessPolicy struct {
+	RespectRobots bool          // Honour robots.txt rules and Crawl-delay for our user agent
+	MinInterval   time.Duration // Minimum time between requests to one host
+	MaxCrawlDelay time.Duration // Longest Crawl-delay honoured, so one host can't stall a feed
+	RobotsTTL     time.Duration // How long a fetched robots.txt is used
+}
+
+// WithPoliteness sets the robots.txt and per-host pacing policy
+// (default: neither). A zero MaxCrawlDelay or RobotsTTL takes the
+// package default.
+func WithPoliteness(policy PolitenessPolicy) Option {
+	return func(r *RSSReader) {
+		if policy.MaxCrawlDelay <= 0 {
+			policy.MaxCrawlDelay = DefaultMaxCrawlDelay
+		}
+		if policy.RobotsTTL <= 0 {
+			policy.RobotsTTL = DefaultRobotsTTL
+		}
+		r.politeness = policy
+	}
+}
+
+// robotsEntry is the cached robots.txt of one origin.
+type robotsEntry struct {
+	ready   chan struct{} // Closed once the fields below are set
+	done    bool          // Guarded by RSSReader.mu
+	rules   *robotsRules  // nil allows everything
+	err     error         // Set if robots.txt was unreachable, which disallows everything
+	expires time.Time
+}
+
+// checkRobots returns ErrDisallowed if robots.txt forbids agent from
+// fetching target, and otherwise the Crawl-delay it asks agent to keep.
+func (r *RSSReader) checkRobots(ctx context.Context, target *url.URL, agent string, settings feed.FetchSettings) (time.Duration, error) {
+	if !r.politeness.RespectRobots || target.Path == "/robots.txt" {
+		return 0, nil
+	}
+
+	// robots.txt is public: it is fetched without the feed's credentials
+	// and headers, only through its proxy and with its CA bundle
+	origin := target.Scheme + "://" + target.Host
+	route := feed.FetchSettings{Proxy: settings.Proxy, CABundle: settings.CABundle}
+	entry := r.robotsFor(ctx, origin, route)
+	if entry.err != nil {
+		return 0, fmt.Errorf("%w: %s/robots.txt is unreachable: %v", ErrDisallowed, origin, entry.err)
+	}
+	if entry.rules == nil {
+		return 0, nil
+	}
+
+	group := entry.rules.group(productToken(agent))
+	if path := requestPath(target); !group.allows(path) {
+		return 0, fmt.Errorf("%w: %s", ErrDisallowed, path)
+	}
+	return min(group.crawlDelay, r.politeness.MaxCrawlDelay), nil
+}
+
+// robotsFor returns origin's robots.txt as reached over route, fetching
+// it if it isn't cached or has expired. Concurrent callers share a single
+// fetch.
+func (r *RSSReader) robotsFor(ctx context.Context, origin string, route feed.FetchSettings) *robotsEntry {
+	key := origin + "\x00" + routeKey(route)
+	r.mu.Lock()
+	entry, ok := r.robots[key]
+	if ok && (!entry.done || r.now().Before(entry.expires)) {
+		r.mu.Unlock()
+		select {
+		case <-entry.ready:
+			return entry
+		case <-ctx.Done():
+			return &robotsEntry{err: ctx.Err()}
+		}
+	}
+
+	entry = &robotsEntry{ready: make(chan struct{})}
+	r.robots[key] = entry
+	r.mu.Unlock()
+
+	rules, ttl, err := r.fetchRobots(ctx, origin, route)
+
+	r.mu.Lock()
+	entry.rules, entry.err, entry.expires, entry.done = rules, err, r.now().Add(ttl), true
+	if ctx.Err() != nil {
+		delete(r.robots, key) // Our caller gave up; that says nothing about the host
+	}
+	r.mu.Unlock()
+	close(entry.ready)
+	return entry
+}
+
+// fetchRobots downloads and parses origin's robots.txt, returning how
+// long the result may be cached. Following RFC 9309, a 4xx response
+// allows everything; a 5xx or network error is an error, which
+// disallows everything until it is retried.
+func (r *RSSReader) fetchRobots(ctx context.Context, origin string, route feed.FetchSettings) (*robotsRules, time.Duration, error) {
+	client, err := r.clientFor(route)
+	if err != nil {
+		return nil, robotsRetryTTL, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, origin+"/robots.txt", nil)
+	if err != nil {
+		return nil, robotsRetryTTL, err
+	}
+	req = applySettings(req, route)
+
+	if err := r.pace(ctx, req.URL.Host, r.politeness.MinInterval); err != nil {
+		return nil, robotsRetryTTL, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, robotsRetryTTL, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxRobotsSize))
+		if err != nil {
+			return nil, robotsRetryTTL, err
+		}
+		return parseRobots(string(body)), r.politeness.RobotsTTL, nil
+	case resp.StatusCode >= 400 && resp.StatusCode <= 499:
+		return nil, r.politeness.RobotsTTL, nil
+	default:
+		return nil, robotsRetryTTL, &StatusError{StatusCode: resp.StatusCode}
+	}
+}
+
+// pace waits until a request to host keeps at least interval since the
+// previous one. Slots are reserved up front, so concurrent callers queue
+// instead of all waking at once.
+func (r *RSSReader) pace(ctx context.Context, host string, interval time.Duration) error {
+	if interval <= 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	now := r.now()
+	at := now
+	if next := r.nextRequest[host]; next.After(now) {
+		at = next
+	}
+	r.nextRequest[host] = at.Add(interval)
+	r.mu.Unlock()
+
+	wait := at.Sub(now)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// =============================================================================
+// ROBOTS.TXT PARSING
+// =============================================================================
+
+// robotsRules are the groups of a parsed robots.txt.
+type robotsRules struct {
+	groups []robotsGroup
+}
+
+// robotsGroup is the rules for one or more user agents.
+type robotsGroup struct {
+	agents     []string // Lower-cased product tokens, or "*"
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+// robotsRule is one allow or disallow line.
+type robotsRule struct {
+	allow   bool
+	pattern string // Path prefix; may contain * and end in $
+}
+
+// parseRobots parses a robots.txt. Unknown lines, and rules before the
+// first user-agent line, are ignored.
+func parseRobots(body string) *robotsRules {
+	rules := &robotsRules{}
+	current := -1    // Index of the group being read
+	inRules := false // A rule has been seen since the last user-agent line
+
+	for _, line := range strings.Split(body, "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		if key == "user-agent" {
+			if current < 0 || inRules {
+				rules.groups = append(rules.groups, robotsGroup{})
+				current, inRules = len(rules.groups)-1, false
+			}
+			rules.groups[current].agents = append(rules.groups[current].agents, strings.ToLower(value))
+			continue
+		}
+		if current < 0 {
+			continue
+		}
+
+		group := &rules.groups[current]
+		switch key {
+		case "allow", "disallow":
+			inRules = true
+			if value != "" { // An empty disallow allows everything
+				group.rules = append(group.rules, robotsRule{allow: key == "allow", pattern: value})
+			}
+		case "crawl-delay":
+			inRules = true
+			if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+				group.crawlDelay = time.Duration(seconds * float64(time.Second))
+			}
+		}
+	}
+	return rules
+}
+
+// group returns the rules for token: every group naming it, or failing
+// that every "*" group, merged.
+func (r *robotsRules) group(token string) robotsGroup {
+	var named, any robotsGroup
+	for _, g := range r.groups {
+		for _, agent := range g.agents {
+			target := &any
+			if agent != "*" {
+				if agent != token {
+					continue
+				}
+				target = &named
+			}
+			target.agents = append(target.agents, agent)
+			target.rules = append(target.rules, g.rules...)
+			target.crawlDelay = max(target.crawlDelay, g.crawlDelay)
+		}
+	}
+	if len(named.agents) > 0 {
+		return named
+	}
+	return any
+}
+
+// allows reports whether path may be fetched. The longest matching rule
+// wins, and allow wins a tie; no matching rule allows.
+func (g robotsGroup) allows(path string) bool {
+	allowed, longest := true, -1
+	for _, rule := range g.rules {
+		if !robotsMatch(rule.pattern, path) {
+			continue
+		}
+		if n := len(rule.pattern); n > longest || (n == longest && rule.allow) {
+			allowed, longest = rule.allow, n
+		}
+	}
+	return allowed
+}
+
+// robotsMatch reports whether path matches a robots.txt pattern: a
+// prefix in which * matches any run of characters and a trailing $
+// anchors the end.
+func robotsMatch(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	for i, part := range parts[1:] {
+		if anchored && i == len(parts)-2 {
+			return strings.HasSuffix(rest, part)
+		}
+		at := strings.Index(rest, part)
+		if at < 0 {
+			return false
+		}
+		rest = rest[at+len(part):]
+	}
+	return !anchored || rest == ""
+}
+
+// productToken returns the lower-cased product name from a User-Agent,
+// e.g. "go-news-rss-reader" from "Go-News-RSS-Reader/1.0".
+func productToken(agent string) string {
+	token, _, _ := strings.Cut(strings.TrimSpace(agent), "/")
+	token, _, _ = strings.Cut(token, " ")
+	return strings.ToLower(token)
+}
+
+// requestPath returns the part of target robots.txt rules match against.
+func requestPath(target *url.URL) string {
+	path := target.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if target.RawQuery != "" {
+		path += "?" + target.RawQuery
+	}
+	return path
+}
+
+// Why robots.txt and pacing live in get:
+//
+// - Every request the reader makes, whether a feed poll, a retry, a
+//   discovery probe or a page fetch, goes through get, so none of them
+//   can forget to be polite.
+// - Pacing is per host rather than per feed: ten feeds on one site are
+//   ten requests to the same server.
+// - An unreachable robots.txt blocks the host, as RFC 9309 requires, but
+//   only for a few minutes before it is fetched again.
+// - robots.txt is cached per origin and route, and fetched without any
+//   feed's credentials or headers: one feed's token or proxy must not be
+//   used on behalf of the other feeds on its host, and a host reached
+//   through a proxy may answer differently than it does directly.
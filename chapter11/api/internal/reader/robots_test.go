@@ -0,0 +1,228 @@
+package reader_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/reader"
+)
+
+// robotsServer serves robots.txt with the given status and body, and
+// testRSS everywhere else. It counts robots.txt requests.
+func robotsServer(t *testing.T, status int, robots string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			fetches.Add(1)
+			w.WriteHeader(status)
+			w.Write([]byte(robots))
+			return
+		}
+		w.Write([]byte(testRSS))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &fetches
+}
+
+func TestRobotsRules(t *testing.T) {
+	const rules = `# Comments and unknown lines are ignored
+Sitemap: https://example.com/sitemap.xml
+
+User-agent: *
+Disallow: /private/
+Disallow: /*.json$
+Allow: /private/feeds/
+
+User-agent: Go-News-RSS-Reader
+User-agent: other-bot
+Disallow: /news/drafts
+Allow: /news/drafts/public
+Disallow: /*?session=
+`
+	tests := []struct {
+		name  string
+		path  string
+		agent string // User-Agent header; empty for the default
+		want  bool
+	}{
+		{"our group allows what * forbids", "/private/x", "", true},
+		{"our group disallow", "/news/drafts/1", "", false},
+		{"longer allow wins", "/news/drafts/public/rss", "", true},
+		{"wildcard in the query", "/rss?session=abc", "", false},
+		{"unmatched path", "/rss", "", true},
+		{"other agent uses *", "/private/x", "Changelog/2.0", false},
+		{"other agent longer allow", "/private/feeds/rss", "Changelog/2.0", true},
+		{"$ anchors the end", "/feed.json", "Changelog/2.0", false},
+		{"$ doesn't match a longer path", "/feed.json/rss", "Changelog/2.0", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := robotsServer(t, http.StatusOK, rules)
+			r := newTestReader(reader.WithPoliteness(reader.PolitenessPolicy{RespectRobots: true}))
+			if tt.agent != "" {
+				if err := r.Configure(srv.URL+tt.path, feed.FetchSettings{Headers: map[string]string{"User-Agent": tt.agent}}); err != nil {
+					t.Fatalf("Configure error = %v", err)
+				}
+			}
+
+			_, err := r.FetchFeed(context.Background(), srv.URL+tt.path)
+			if tt.want && err != nil {
+				t.Errorf("FetchFeed error = %v, want allowed", err)
+			}
+			if !tt.want && !errors.Is(err, reader.ErrDisallowed) {
+				t.Errorf("FetchFeed error = %v, want ErrDisallowed", err)
+			}
+		})
+	}
+}
+
+func TestRobotsStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		wantAllowed bool
+	}{
+		{"missing robots.txt allows everything", http.StatusNotFound, true},
+		{"forbidden robots.txt allows everything", http.StatusForbidden, true},
+		{"server error disallows everything", http.StatusServiceUnavailable, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, fetches := robotsServer(t, tt.status, "")
+			r := newTestReader(reader.WithPoliteness(reader.PolitenessPolicy{RespectRobots: true}))
+
+			for i := 0; i < 2; i++ {
+				_, err := r.FetchFeed(context.Background(), srv.URL+"/rss")
+				if allowed := err == nil; allowed != tt.wantAllowed {
+					t.Fatalf("fetch %d error = %v, want allowed %v", i, err, tt.wantAllowed)
+				}
+			}
+			if got := fetches.Load(); got != 1 {
+				t.Errorf("robots.txt fetched %d times, want once", got)
+			}
+		})
+	}
+}
+
+func TestRobotsIgnoresFeedSettings(t *testing.T) {
+	var robotsHeaders atomic.Pointer[http.Header]
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			robotsHeaders.Store(&r.Header)
+			w.Write([]byte("User-agent: *\nAllow: /\n"))
+			return
+		}
+		w.Write([]byte(testRSS))
+	}))
+	defer srv.Close()
+
+	// The proxy has its own idea of the host's robots.txt
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			w.Write([]byte("User-agent: *\nDisallow: /\n"))
+			return
+		}
+		w.Write([]byte(testRSS))
+	}))
+	defer proxy.Close()
+
+	r := newTestReader(reader.WithPoliteness(reader.PolitenessPolicy{RespectRobots: true}))
+	settings := map[string]feed.FetchSettings{
+		srv.URL + "/private": {
+			Auth:    &feed.Auth{Token: "secret-token"},
+			Headers: map[string]string{"X-Api-Key": "secret-key"},
+		},
+		srv.URL + "/proxied": {Proxy: proxy.URL},
+	}
+	for url, s := range settings {
+		if err := r.Configure(url, s); err != nil {
+			t.Fatalf("Configure error = %v", err)
+		}
+	}
+
+	if _, err := r.FetchFeed(context.Background(), srv.URL+"/private"); err != nil {
+		t.Fatalf("FetchFeed error = %v", err)
+	}
+	headers := robotsHeaders.Load()
+	if headers == nil {
+		t.Fatal("robots.txt was not fetched")
+	}
+	if got := headers.Get("Authorization") + headers.Get("X-Api-Key"); got != "" {
+		t.Errorf("robots.txt request carried the feed's credentials: %q", got)
+	}
+
+	if _, err := r.FetchFeed(context.Background(), srv.URL+"/proxied"); !errors.Is(err, reader.ErrDisallowed) {
+		t.Errorf("FetchFeed through the proxy error = %v, want the proxy's robots.txt to disallow it", err)
+	}
+	if _, err := r.FetchFeed(context.Background(), srv.URL+"/public"); err != nil {
+		t.Errorf("FetchFeed without the proxy error = %v, want the host's own robots.txt", err)
+	}
+}
+
+func TestPolitenessDisabledIgnoresRobots(t *testing.T) {
+	srv, fetches := robotsServer(t, http.StatusOK, "User-agent: *\nDisallow: /\n")
+	if _, err := newTestReader().FetchFeed(context.Background(), srv.URL+"/rss"); err != nil {
+		t.Fatalf("FetchFeed error = %v", err)
+	}
+	if fetches.Load() != 0 {
+		t.Error("robots.txt was fetched with RespectRobots off")
+	}
+}
+
+func TestPolitenessPacesHost(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  reader.PolitenessPolicy
+		robots  string
+		wantGap time.Duration
+	}{
+		{"minimum interval", reader.PolitenessPolicy{MinInterval: 150 * time.Millisecond}, "", 150 * time.Millisecond},
+		{"Crawl-delay beats a shorter interval", reader.PolitenessPolicy{RespectRobots: true, MinInterval: 10 * time.Millisecond}, "User-agent: *\nCrawl-delay: 0.2\n", 200 * time.Millisecond},
+		{"Crawl-delay is capped", reader.PolitenessPolicy{RespectRobots: true, MaxCrawlDelay: 100 * time.Millisecond}, "User-agent: *\nCrawl-delay: 3600\n", 100 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := robotsServer(t, http.StatusOK, tt.robots)
+			r := newTestReader(reader.WithPoliteness(tt.policy))
+
+			// Warm the robots.txt cache so only feed requests are timed
+			if _, err := r.FetchFeed(context.Background(), srv.URL+"/a"); err != nil {
+				t.Fatalf("FetchFeed error = %v", err)
+			}
+			start := time.Now()
+			if _, err := r.FetchFeed(context.Background(), srv.URL+"/b"); err != nil {
+				t.Fatalf("FetchFeed error = %v", err)
+			}
+			if _, err := r.FetchFeed(context.Background(), srv.URL+"/c"); err != nil {
+				t.Fatalf("FetchFeed error = %v", err)
+			}
+			if elapsed := time.Since(start); elapsed < tt.wantGap || elapsed > 20*tt.wantGap {
+				t.Errorf("two paced fetches took %s, want at least %s", elapsed, tt.wantGap)
+			}
+		})
+	}
+}
+
+func TestPolitenessWaitHonoursContext(t *testing.T) {
+	srv, _ := robotsServer(t, http.StatusNotFound, "")
+	r := newTestReader(reader.WithPoliteness(reader.PolitenessPolicy{MinInterval: time.Hour}))
+	if _, err := r.FetchFeed(context.Background(), srv.URL+"/a"); err != nil {
+		t.Fatalf("FetchFeed error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := r.FetchFeed(ctx, srv.URL+"/b"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("FetchFeed error = %v, want the context's deadline", err)
+	}
+}
@@ -0,0 +1,161 @@
+package reader_test
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/reader"
+)
+
+func TestConfigureHeaders(t *testing.T) {
+	tests := []struct {
+		name      string
+		settings  feed.FetchSettings
+		wantAuth  string
+		wantAgent string
+		wantExtra string
+	}{
+		{"defaults", feed.FetchSettings{}, "", reader.DefaultUserAgent, ""},
+		{"basic auth", feed.FetchSettings{Auth: &feed.Auth{Username: "ci", Password: "hunter2"}}, "Basic Y2k6aHVudGVyMg==", reader.DefaultUserAgent, ""},
+		{"bearer token", feed.FetchSettings{Auth: &feed.Auth{Token: "t0k3n"}}, "Bearer t0k3n", reader.DefaultUserAgent, ""},
+		{"custom agent and header", feed.FetchSettings{Headers: map[string]string{"user-agent": "Changelog/2.0", "X-Team": "news"}}, "", "Changelog/2.0", "news"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got http.Header
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Clone()
+				w.Write([]byte(testRSS))
+			}))
+			defer srv.Close()
+
+			r := newTestReader()
+			if err := r.Configure(srv.URL, tt.settings); err != nil {
+				t.Fatalf("Configure error = %v", err)
+			}
+			if _, err := r.FetchFeed(context.Background(), srv.URL); err != nil {
+				t.Fatalf("FetchFeed error = %v", err)
+			}
+
+			if got.Get("Authorization") != tt.wantAuth {
+				t.Errorf("Authorization = %q, want %q", got.Get("Authorization"), tt.wantAuth)
+			}
+			if got.Get("User-Agent") != tt.wantAgent {
+				t.Errorf("User-Agent = %q, want %q", got.Get("User-Agent"), tt.wantAgent)
+			}
+			if got.Get("X-Team") != tt.wantExtra {
+				t.Errorf("X-Team = %q, want %q", got.Get("X-Team"), tt.wantExtra)
+			}
+		})
+	}
+}
+
+func TestConfiguredHeadersStayOnTheFeedHost(t *testing.T) {
+	var got http.Header
+	elsewhere := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(testRSS))
+	}))
+	defer elsewhere.Close()
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		http.Redirect(w, r, elsewhere.URL+"/feed.xml", http.StatusFound)
+	}))
+	defer origin.Close()
+
+	r := newTestReader()
+	settings := feed.FetchSettings{
+		Headers: map[string]string{"X-Api-Key": "k3y", "private-token": "t0k3n", "User-Agent": "Changelog/2.0"},
+		Auth:    &feed.Auth{Token: "b3arer"},
+	}
+	if err := r.Configure(origin.URL, settings); err != nil {
+		t.Fatalf("Configure error = %v", err)
+	}
+	if _, err := r.FetchFeed(context.Background(), origin.URL); err != nil {
+		t.Fatalf("FetchFeed error = %v", err)
+	}
+
+	for _, name := range []string{"X-Api-Key", "Private-Token", "Authorization"} {
+		if got.Get(name) != "" {
+			t.Errorf("%s = %q sent to the redirect's host", name, got.Get(name))
+		}
+	}
+	if got.Get("User-Agent") != "Changelog/2.0" {
+		t.Errorf("User-Agent = %q, want the configured one", got.Get("User-Agent"))
+	}
+}
+
+func TestConfigureInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings feed.FetchSettings
+	}{
+		{"token and password", feed.FetchSettings{Auth: &feed.Auth{Username: "ci", Token: "t"}}},
+		{"empty auth", feed.FetchSettings{Auth: &feed.Auth{}}},
+		{"bad header name", feed.FetchSettings{Headers: map[string]string{"X Team": "news"}}},
+		{"reserved header", feed.FetchSettings{Headers: map[string]string{"accept-encoding": "br"}}},
+		{"header injection", feed.FetchSettings{Headers: map[string]string{"X-Team": "news\r\nX-Admin: 1"}}},
+		{"proxy scheme", feed.FetchSettings{Proxy: "ftp://proxy.example:21"}},
+		{"proxy without host", feed.FetchSettings{Proxy: "http://"}},
+		{"CA bundle without certificates", feed.FetchSettings{CABundle: "not a certificate"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReader()
+			if err := r.Configure("https://example.com/rss", tt.settings); !errors.Is(err, reader.ErrInvalidSettings) {
+				t.Errorf("Configure error = %v, want ErrInvalidSettings", err)
+			}
+		})
+	}
+}
+
+func TestConfigureCABundle(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testRSS))
+	}))
+	defer srv.Close()
+
+	r := newTestReader()
+	if _, err := r.FetchFeed(context.Background(), srv.URL); err == nil {
+		t.Fatal("FetchFeed of a self-signed server succeeded without its CA")
+	}
+
+	bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := r.Configure(srv.URL, feed.FetchSettings{CABundle: string(bundle)}); err != nil {
+		t.Fatalf("Configure error = %v", err)
+	}
+	if _, err := r.FetchFeed(context.Background(), srv.URL); err != nil {
+		t.Errorf("FetchFeed with the CA bundle error = %v", err)
+	}
+}
+
+func TestConfigureProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String() // Proxies receive the absolute URL
+		w.Write([]byte(testRSS))
+	}))
+	defer proxy.Close()
+
+	const feedURL = "http://feeds.internal.example/changelog.xml"
+	r := newTestReader()
+	if err := r.Configure(feedURL, feed.FetchSettings{Proxy: proxy.URL}); err != nil {
+		t.Fatalf("Configure error = %v", err)
+	}
+	if _, err := r.FetchFeed(context.Background(), feedURL); err != nil {
+		t.Fatalf("FetchFeed error = %v", err)
+	}
+	if proxied != feedURL {
+		t.Errorf("proxy received %q, want %q", proxied, feedURL)
+	}
+}
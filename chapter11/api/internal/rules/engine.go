@@ -0,0 +1,271 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/ingest"
+	"github.com/YOUR_USERNAME/go-news/api/internal/persist"
+)
+
+// =============================================================================
+// ENGINE - Rule storage and evaluation on ingest
+// =============================================================================
+
+// Compile-time verification that Engine is an ingest processor
+var _ ingest.Processor = (*Engine)(nil)
+
+// Engine owns the rules and applies them to incoming articles as a step
+// of the ingest pipeline. Rules run in the order they were created.
+type Engine struct {
+	stateFile string // Persists rules; empty keeps them in memory
+	now       func() time.Time
+
+	mu    sync.RWMutex
+	rules []*Rule // Oldest first
+}
+
+// NewEngine creates an engine, restoring rules from stateFile if it exists.
+func NewEngine(stateFile string) (*Engine, error) {
+	e := &Engine{stateFile: stateFile, now: time.Now}
+	if err := e.load(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// =============================================================================
+// RULE REGISTRY
+// =============================================================================
+
+// AddRule validates and stores a rule, assigning its ID.
+func (e *Engine) AddRule(rule Rule) (Rule, error) {
+	if err := rule.compile(); err != nil {
+		return Rule{}, err
+	}
+	rule.ID = persist.NewID(8)
+	rule.CreatedAt = e.now().UTC()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.rules = append(e.rules, &rule)
+	if err := e.persistLocked(); err != nil {
+		e.rules = e.rules[:len(e.rules)-1]
+		return Rule{}, err
+	}
+	return rule, nil
+}
+
+// Rules returns every rule, oldest first.
+func (e *Engine) Rules() []Rule {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	result := make([]Rule, len(e.rules))
+	for i, rule := range e.rules {
+		result[i] = *rule
+	}
+	return result
+}
+
+// Rule returns the rule with the given ID.
+func (e *Engine) Rule(id string) (Rule, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if i := e.indexLocked(id); i >= 0 {
+		return *e.rules[i], nil
+	}
+	return Rule{}, ErrNotFound
+}
+
+// UpdateRule replaces the match, actions, name and state of a rule,
+// keeping its ID, creation time and place in the order.
+func (e *Engine) UpdateRule(id string, rule Rule) (Rule, error) {
+	if err := rule.compile(); err != nil {
+		return Rule{}, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	i := e.indexLocked(id)
+	if i < 0 {
+		return Rule{}, ErrNotFound
+	}
+	previous := e.rules[i]
+	rule.ID, rule.CreatedAt = previous.ID, previous.CreatedAt
+	e.rules[i] = &rule
+	if err := e.persistLocked(); err != nil {
+		e.rules[i] = previous
+		return Rule{}, err
+	}
+	return rule, nil
+}
+
+// RemoveRule deletes a rule.
+func (e *Engine) RemoveRule(id string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	i := e.indexLocked(id)
+	if i < 0 {
+		return ErrNotFound
+	}
+	previous := e.rules
+	e.rules = slices.Delete(slices.Clone(e.rules), i, i+1)
+	if err := e.persistLocked(); err != nil {
+		e.rules = previous
+		return err
+	}
+	return nil
+}
+
+func (e *Engine) indexLocked(id string) int {
+	return slices.IndexFunc(e.rules, func(r *Rule) bool { return r.ID == id })
+}
+
+// =============================================================================
+// EVALUATION
+// =============================================================================
+
+// Name implements ingest.Processor.
+func (e *Engine) Name() string { return "rules" }
+
+// Process implements ingest.Processor: every enabled rule matching the
+// article applies its actions, and the article is dropped if any of them
+// says so.
+func (e *Engine) Process(ctx context.Context, article *feed.Article) (bool, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	feedURL := ingest.FeedURL(ctx)
+	keep := true
+	for _, rule := range e.rules {
+		if rule.Disabled || !rule.Matches(article, feedURL) {
+			continue
+		}
+		rule.Actions.apply(article)
+		if rule.Actions.Drop {
+			keep = false
+		}
+	}
+	return keep, nil
+}
+
+// Effect is what a rule would have done to one stored article.
+type Effect struct {
+	Article  *feed.Article `json:"article"`
+	Drop     bool          `json:"drop,omitempty"`
+	AddTags  []string      `json:"add_tags,omitempty"` // Tags the article doesn't already have
+	Star     bool          `json:"star,omitempty"`
+	MarkRead bool          `json:"mark_read,omitempty"`
+}
+
+// DryRunResult lists the articles a rule matches.
+type DryRunResult struct {
+	Scanned  int      `json:"scanned"`
+	Matched  int      `json:"matched"`
+	Articles []Effect `json:"articles"`
+}
+
+// DryRun validates rule and reports which of articles it would have
+// affected, and how, had it existed when they arrived. Nothing is
+// changed. Stored articles no longer know their feed URL, so a Feed
+// criterion matches them by feed title only.
+func DryRun(rule Rule, articles []*feed.Article) (DryRunResult, error) {
+	if err := rule.compile(); err != nil {
+		return DryRunResult{}, err
+	}
+
+	result := DryRunResult{Scanned: len(articles), Articles: []Effect{}}
+	for _, article := range articles {
+		if !rule.Matches(article, "") {
+			continue
+		}
+		after := *article
+		after.Tags = slices.Clone(article.Tags)
+		rule.Actions.apply(&after)
+
+		result.Matched++
+		result.Articles = append(result.Articles, Effect{
+			Article:  article,
+			Drop:     rule.Actions.Drop,
+			AddTags:  after.Tags[len(article.Tags):],
+			Star:     after.Starred && !article.Starred,
+			MarkRead: after.Read && !article.Read,
+		})
+	}
+	return result, nil
+}
+
+// =============================================================================
+// PERSISTENCE
+// =============================================================================
+
+// state is the persisted form of the engine.
+type state struct {
+	Rules []*Rule `json:"rules"`
+}
+
+// load restores rules from the state file, if there is one.
+func (e *Engine) load() error {
+	if e.stateFile == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(e.stateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read rules state: %w", err)
+	}
+
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("failed to parse rules state %s: %w", e.stateFile, err)
+	}
+	for _, rule := range s.Rules {
+		if err := rule.compile(); err != nil {
+			return fmt.Errorf("invalid rule %s in %s: %w", rule.ID, e.stateFile, err)
+		}
+	}
+	e.rules = s.Rules
+	return nil
+}
+
+// persistLocked atomically writes the rules to the state file. e.mu must
+// be held.
+func (e *Engine) persistLocked() error {
+	if e.stateFile == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(state{Rules: e.rules}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode rules state: %w", err)
+	}
+
+	if err := persist.WriteFile(e.stateFile, data); err != nil {
+		return fmt.Errorf("failed to write rules state: %w", err)
+	}
+	return nil
+}
+
+// Why rules run inside the ingest pipeline:
+//
+// - Dropping an article before it is stored means it never reaches the
+//   live stream, webhooks or summaries, which is the point of muting it.
+// - Rules see the same sanitised text as the API serves, so a title
+//   match written against what users read behaves as they expect.
+// - Rules apply to new articles only. The dry run shows what a rule would
+//   have done to stored ones, so it can be tuned before it is saved.
@@ -0,0 +1,149 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+)
+
+// =============================================================================
+// RULES - User-defined matching and actions for incoming articles
+// =============================================================================
+
+// ErrNotFound is returned when a rule ID doesn't exist.
+var ErrNotFound = errors.New("rule not found")
+
+// maxPatternLength bounds the regular expressions rules may use.
+const maxPatternLength = 1024
+
+// Rule applies its actions to every incoming article it matches.
+type Rule struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Match     Match     `json:"match"`
+	Actions   Actions   `json:"actions"`
+	Disabled  bool      `json:"disabled,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	pattern *regexp.Regexp // Compiled Match.Regex; set by compile
+}
+
+// Match selects articles. Each non-empty criterion must match (AND);
+// text comparisons ignore case. At least one criterion is required.
+type Match struct {
+	Title       string `json:"title,omitempty"`       // Substring of the title
+	Description string `json:"description,omitempty"` // Substring of the description
+	Feed        string `json:"feed,omitempty"`        // Feed title, or the URL the feed is fetched from
+	Host        string `json:"host,omitempty"`        // Host of the article link, or a parent domain of it
+	Regex       string `json:"regex,omitempty"`       // Go regular expression over title and description
+}
+
+// Actions are applied to matching articles. Drop discards the article
+// before it is stored, so the other actions only matter without it.
+type Actions struct {
+	Drop     bool     `json:"drop,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Star     bool     `json:"star,omitempty"`
+	MarkRead bool     `json:"mark_read,omitempty"`
+}
+
+// IsZero reports whether m has no criteria.
+func (m Match) IsZero() bool {
+	return m == Match{}
+}
+
+// IsZero reports whether a does nothing.
+func (a Actions) IsZero() bool {
+	return !a.Drop && len(a.Tags) == 0 && !a.Star && !a.MarkRead
+}
+
+// compile validates the rule and prepares it for matching.
+func (r *Rule) compile() error {
+	if r.Match.IsZero() {
+		return errors.New("rule must match on at least one of title, description, feed, host or regex")
+	}
+	if r.Actions.IsZero() {
+		return errors.New("rule must have at least one action: drop, tags, star or mark_read")
+	}
+	for _, tag := range r.Actions.Tags {
+		if strings.TrimSpace(tag) == "" {
+			return errors.New("rule tags must not be empty")
+		}
+	}
+	if host := r.Match.Host; host != "" && (strings.ContainsAny(host, "/:?#") || strings.Contains(host, " ")) {
+		return fmt.Errorf("match host %q must be a bare host name such as example.com", host)
+	}
+
+	r.pattern = nil
+	if r.Match.Regex != "" {
+		if len(r.Match.Regex) > maxPatternLength {
+			return fmt.Errorf("match regex is longer than %d bytes", maxPatternLength)
+		}
+		pattern, err := regexp.Compile(r.Match.Regex)
+		if err != nil {
+			return fmt.Errorf("invalid match regex: %w", err)
+		}
+		r.pattern = pattern
+	}
+	return nil
+}
+
+// Matches reports whether article, from the feed fetched at feedURL,
+// meets every criterion. feedURL may be empty for stored articles, whose
+// feed is then known only by title.
+func (r *Rule) Matches(article *feed.Article, feedURL string) bool {
+	m := r.Match
+	if m.Title != "" && !containsFold(article.Title, m.Title) {
+		return false
+	}
+	if m.Description != "" && !containsFold(article.Description, m.Description) {
+		return false
+	}
+	if m.Feed != "" && !strings.EqualFold(m.Feed, article.FeedTitle) && !strings.EqualFold(m.Feed, feedURL) {
+		return false
+	}
+	if m.Host != "" && !hostMatches(article.Link, m.Host) {
+		return false
+	}
+	if r.pattern != nil && !r.pattern.MatchString(article.Title+"\n"+article.Description) {
+		return false
+	}
+	return true
+}
+
+// apply carries out the actions other than Drop on article.
+func (a Actions) apply(article *feed.Article) {
+	for _, tag := range a.Tags {
+		if !slices.ContainsFunc(article.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			article.Tags = append(article.Tags, tag)
+		}
+	}
+	if a.Star {
+		article.Starred = true
+	}
+	if a.MarkRead {
+		article.Read = true
+	}
+}
+
+// containsFold reports whether substr is within s, ignoring case.
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// hostMatches reports whether link's host is host or a subdomain of it.
+func hostMatches(link, host string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	got := strings.ToLower(u.Hostname())
+	host = strings.ToLower(strings.TrimPrefix(host, "."))
+	return got == host || strings.HasSuffix(got, "."+host)
+}
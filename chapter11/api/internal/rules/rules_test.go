@@ -0,0 +1,182 @@
+package rules_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/ingest"
+	"github.com/YOUR_USERNAME/go-news/api/internal/rules"
+)
+
+func TestRuleMatching(t *testing.T) {
+	article := &feed.Article{
+		Title:       "Weekly Questions Thread - March 04",
+		Description: "Ask anything about Go here",
+		Link:        "https://old.reddit.com/r/golang/comments/1",
+		FeedTitle:   "golang",
+	}
+	tests := []struct {
+		name     string
+		match    rules.Match
+		want     bool
+		liveOnly bool // Needs the feed URL, which dry runs don't have
+	}{
+		{"title substring ignoring case", rules.Match{Title: "weekly questions thread"}, true, false},
+		{"title mismatch", rules.Match{Title: "release"}, false, false},
+		{"description", rules.Match{Description: "ASK ANYTHING"}, true, false},
+		{"feed title", rules.Match{Feed: "GoLang"}, true, false},
+		{"feed URL", rules.Match{Feed: "https://www.reddit.com/r/golang.rss"}, true, true},
+		{"other feed", rules.Match{Feed: "Go Blog"}, false, false},
+		{"parent domain", rules.Match{Host: "reddit.com"}, true, false},
+		{"exact host", rules.Match{Host: "old.reddit.com"}, true, false},
+		{"suffix is not a subdomain", rules.Match{Host: "dit.com"}, false, false},
+		{"regex over title and description", rules.Match{Regex: `(?i)^weekly .* thread`}, true, false},
+		{"regex over description line", rules.Match{Regex: `(?m)^Ask`}, true, false},
+		{"every criterion must match", rules.Match{Title: "weekly", Host: "go.dev"}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := rules.DryRun(rules.Rule{Match: tt.match, Actions: rules.Actions{Star: true}}, []*feed.Article{article})
+			if err != nil {
+				t.Fatal(err)
+			}
+			engine, _ := rules.NewEngine("")
+			if _, err := engine.AddRule(rules.Rule{Match: tt.match, Actions: rules.Actions{Drop: true}}); err != nil {
+				t.Fatal(err)
+			}
+			copied := *article
+			kept, _ := ingest.New(engine).ProcessArticles(context.Background(), "https://www.reddit.com/r/golang.rss", []*feed.Article{&copied})
+
+			if got := len(kept) == 0; got != tt.want {
+				t.Errorf("live match = %v, want %v", got, tt.want)
+			}
+			if got := result.Matched == 1; got != (tt.want && !tt.liveOnly) {
+				t.Errorf("dry-run match = %v, want %v", got, tt.want && !tt.liveOnly)
+			}
+		})
+	}
+}
+
+func TestRuleValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		rule rules.Rule
+	}{
+		{"no criteria", rules.Rule{Actions: rules.Actions{Drop: true}}},
+		{"no actions", rules.Rule{Match: rules.Match{Title: "x"}}},
+		{"empty tag", rules.Rule{Match: rules.Match{Title: "x"}, Actions: rules.Actions{Tags: []string{" "}}}},
+		{"bad regex", rules.Rule{Match: rules.Match{Regex: "("}, Actions: rules.Actions{Drop: true}}},
+		{"host with a path", rules.Rule{Match: rules.Match{Host: "example.com/blog"}, Actions: rules.Actions{Drop: true}}},
+	}
+	engine, _ := rules.NewEngine("")
+	for _, tt := range tests {
+		if _, err := engine.AddRule(tt.rule); err == nil {
+			t.Errorf("%s: AddRule succeeded, want an error", tt.name)
+		}
+	}
+	if got := engine.Rules(); len(got) != 0 {
+		t.Errorf("invalid rules were stored: %v", got)
+	}
+}
+
+func TestEngineProcess(t *testing.T) {
+	engine, _ := rules.NewEngine("")
+	mustAdd := func(rule rules.Rule) rules.Rule {
+		t.Helper()
+		rule, err := engine.AddRule(rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rule
+	}
+	mustAdd(rules.Rule{Name: "mute", Match: rules.Match{Title: "weekly questions thread"}, Actions: rules.Actions{Drop: true}})
+	mustAdd(rules.Rule{Name: "advisories", Match: rules.Match{Regex: `CVE-\d{4}-\d+`}, Actions: rules.Actions{Tags: []string{"security"}, Star: true}})
+	announcements := mustAdd(rules.Rule{Match: rules.Match{Host: "go.dev"}, Actions: rules.Actions{MarkRead: true, Tags: []string{"Security", "go"}}})
+	mustAdd(rules.Rule{Match: rules.Match{Title: "Go"}, Actions: rules.Actions{Drop: true}, Disabled: true})
+
+	articles := []*feed.Article{
+		{Title: "Weekly questions thread", Link: "https://reddit.com/1"},
+		{Title: "Go 1.22.1 fixes CVE-2024-24783", Link: "https://go.dev/blog/go1.22.1"},
+		{Title: "Interesting post", Link: "https://example.com/post"},
+	}
+	kept, err := ingest.New(engine).ProcessArticles(context.Background(), "feed", articles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kept) != 2 || kept[0] != articles[1] || kept[1] != articles[2] {
+		t.Fatalf("kept %d articles, want the muted one dropped", len(kept))
+	}
+	advisory := kept[0]
+	if !advisory.Starred || !advisory.Read || !slices.Equal(advisory.Tags, []string{"security", "go"}) {
+		t.Errorf("advisory = %+v, want starred, read and tagged security and go once", advisory)
+	}
+	if other := kept[1]; other.Starred || other.Read || len(other.Tags) != 0 {
+		t.Errorf("unmatched article was changed: %+v", other)
+	}
+
+	if err := engine.RemoveRule(announcements.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.RemoveRule(announcements.ID); !errors.Is(err, rules.ErrNotFound) {
+		t.Errorf("second RemoveRule error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestDryRunReportsEffects(t *testing.T) {
+	stored := []*feed.Article{
+		{Title: "CVE-2024-1 in net/http", Tags: []string{"security"}},
+		{Title: "CVE-2024-2 in crypto", Starred: true},
+		{Title: "Unrelated"},
+	}
+	rule := rules.Rule{Match: rules.Match{Title: "cve-"}, Actions: rules.Actions{Tags: []string{"security", "advisory"}, Star: true}}
+
+	result, err := rules.DryRun(rule, stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Scanned != 3 || result.Matched != 2 {
+		t.Fatalf("scanned %d, matched %d; want 3 and 2", result.Scanned, result.Matched)
+	}
+	first, second := result.Articles[0], result.Articles[1]
+	if !slices.Equal(first.AddTags, []string{"advisory"}) || !first.Star {
+		t.Errorf("first effect = %+v, want advisory added and starred", first)
+	}
+	if !slices.Equal(second.AddTags, []string{"security", "advisory"}) || second.Star {
+		t.Errorf("second effect = %+v, want both tags and no star for an already starred article", second)
+	}
+	if len(stored[0].Tags) != 1 || stored[1].Tags != nil {
+		t.Error("dry run changed stored articles")
+	}
+}
+
+func TestEnginePersistence(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "rules.json")
+	engine, err := rules.NewEngine(stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, _ := engine.AddRule(rules.Rule{Name: "first", Match: rules.Match{Regex: "^Ask"}, Actions: rules.Actions{Drop: true}})
+	second, _ := engine.AddRule(rules.Rule{Name: "second", Match: rules.Match{Feed: "golang"}, Actions: rules.Actions{MarkRead: true}})
+	if _, err := engine.UpdateRule(first.ID, rules.Rule{Name: "renamed", Match: rules.Match{Regex: "^Tell"}, Actions: rules.Actions{Drop: true}}); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := rules.NewEngine(stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := restored.Rules()
+	if len(got) != 2 || got[0].ID != first.ID || got[0].Name != "renamed" || got[1].ID != second.ID {
+		t.Fatalf("restored rules = %+v, want renamed first then second", got)
+	}
+
+	// Restored regexes are compiled again
+	kept, _ := ingest.New(restored).ProcessArticles(context.Background(), "feed", []*feed.Article{{Title: "Tell me"}, {Title: "Ask me"}})
+	if len(kept) != 1 || kept[0].Title != "Ask me" {
+		t.Errorf("restored rules kept %v, want only Ask me", kept)
+	}
+}
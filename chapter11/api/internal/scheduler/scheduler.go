@@ -0,0 +1,216 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+)
+
+// =============================================================================
+// SCHEDULER - Background polling of subscribed feeds
+// =============================================================================
+
+// Scheduler polls each feed on its own goroutine at a shared interval.
+// The set of feeds and the interval can change while it runs, which is
+// what makes configuration reloads possible without a restart.
+type Scheduler struct {
+	fetcher feed.Fetcher
+
+	mu       sync.Mutex
+	ctx      context.Context               // Parent of every poller; set by Start
+	interval time.Duration                 // Time between fetches of one feed
+	pollers  map[string]context.CancelFunc // Keyed by feed URL
+	next     map[string]time.Time          // Next scheduled fetch, keyed by feed URL
+	wake     chan struct{}                 // Closed when the interval changes
+	wg       sync.WaitGroup
+}
+
+// New creates a scheduler that fetches feeds with fetcher every interval.
+func New(fetcher feed.Fetcher, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		fetcher:  fetcher,
+		interval: interval,
+		pollers:  make(map[string]context.CancelFunc),
+		next:     make(map[string]time.Time),
+		wake:     make(chan struct{}),
+	}
+}
+
+// Start begins polling urls. The first poll of each feed happens after one
+// interval, since callers fetch the initial set themselves at startup.
+// Polling stops when ctx is cancelled or Stop is called.
+func (s *Scheduler) Start(ctx context.Context, urls []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ctx = ctx
+	for _, url := range urls {
+		s.startLocked(url, false)
+	}
+}
+
+// Sync makes the polled set match urls. New feeds are fetched immediately
+// and then on schedule; feeds no longer listed stop polling. It returns the
+// URLs that were added and removed, sorted.
+func (s *Scheduler) Sync(urls []string) (added, removed []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wanted := make(map[string]bool, len(urls))
+	for _, url := range urls {
+		wanted[url] = true
+		if _, ok := s.pollers[url]; !ok {
+			s.startLocked(url, true)
+			added = append(added, url)
+		}
+	}
+
+	for url, cancel := range s.pollers {
+		if !wanted[url] {
+			cancel()
+			delete(s.pollers, url)
+			delete(s.next, url)
+			removed = append(removed, url)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// SetInterval changes the polling interval. Running pollers pick up the
+// new interval immediately rather than after their current wait.
+func (s *Scheduler) SetInterval(interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if interval == s.interval {
+		return
+	}
+	s.interval = interval
+	close(s.wake)
+	s.wake = make(chan struct{})
+}
+
+// Feeds returns the URLs currently being polled, sorted.
+func (s *Scheduler) Feeds() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	urls := make([]string, 0, len(s.pollers))
+	for url := range s.pollers {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+	return urls
+}
+
+// NextPoll returns when url will next be fetched, and false if it isn't
+// being polled.
+func (s *Scheduler) NextPoll(url string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next, ok := s.next[url]
+	return next, ok
+}
+
+// Stop cancels every poller and waits for in-flight fetches to finish.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	for url, cancel := range s.pollers {
+		cancel()
+		delete(s.pollers, url)
+		delete(s.next, url)
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// startLocked launches a poller for url. s.mu must be held.
+func (s *Scheduler) startLocked(url string, fetchNow bool) {
+	parent := s.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parent)
+	s.pollers[url] = cancel
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.poll(ctx, url, fetchNow)
+	}()
+}
+
+// poll fetches url repeatedly until ctx is cancelled.
+func (s *Scheduler) poll(ctx context.Context, url string, fetchNow bool) {
+	if fetchNow {
+		s.fetch(ctx, url)
+	}
+
+	for {
+		wait, wake := s.schedule(url)
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-wake:
+			// Interval changed; restart the wait with the new value
+			timer.Stop()
+			continue
+		case <-timer.C:
+			s.fetch(ctx, url)
+		}
+	}
+}
+
+// schedule returns how long to wait before fetching url again, and the
+// interval change notification. The wait is one interval, or longer if the
+// fetcher has asked to back off the feed.
+func (s *Scheduler) schedule(url string) (time.Duration, <-chan struct{}) {
+	wait := s.currentInterval()
+	if backoff, ok := s.fetcher.(feed.Backoff); ok {
+		wait = max(wait, time.Until(backoff.RetryAt(url)))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.pollers[url]; ok {
+		s.next[url] = time.Now().Add(wait)
+	}
+	return wait, s.wake
+}
+
+func (s *Scheduler) currentInterval() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.interval
+}
+
+func (s *Scheduler) fetch(ctx context.Context, url string) {
+	if _, err := s.fetcher.FetchFeed(ctx, url); err != nil && ctx.Err() == nil {
+		slog.Warn("scheduled fetch failed", "url", url, "error", err)
+	}
+}
+
+// Design notes:
+//
+// - One goroutine per feed keeps slow feeds from delaying fast ones and
+//   makes removing a feed as simple as cancelling its context.
+// - The scheduler depends only on feed.Fetcher, so tests drive it with a
+//   fake fetcher and no network.
+// - Changing the interval closes a shared channel, a broadcast that wakes
+//   every poller without tracking them individually.
+// - Fetchers that implement feed.Backoff stretch the wait for a failing
+//   feed, so an open circuit breaker isn't polled every interval.
@@ -0,0 +1,139 @@
+package scheduler_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/feedtest"
+	"github.com/YOUR_USERNAME/go-news/api/internal/reader"
+	"github.com/YOUR_USERNAME/go-news/api/internal/scheduler"
+	"github.com/YOUR_USERNAME/go-news/api/internal/store"
+)
+
+// countingFetcher records how many times each URL was fetched.
+type countingFetcher struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func newCountingFetcher() *countingFetcher {
+	return &countingFetcher{counts: make(map[string]int)}
+}
+
+func (f *countingFetcher) FetchFeed(ctx context.Context, url string) (*feed.Feed, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.counts[url]++
+	return &feed.Feed{}, nil
+}
+
+func (f *countingFetcher) count(url string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.counts[url]
+}
+
+// waitFor polls cond until it holds or the deadline passes.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before deadline")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestSync verifies feeds are added, removed and polled on schedule.
+func TestSync(t *testing.T) {
+	fetcher := newCountingFetcher()
+	s := scheduler.New(fetcher, time.Hour)
+	s.Start(context.Background(), []string{"a", "b"})
+	defer s.Stop()
+
+	added, removed := s.Sync([]string{"b", "c"})
+	if !slices.Equal(added, []string{"c"}) {
+		t.Errorf("expected c added, got %v", added)
+	}
+	if !slices.Equal(removed, []string{"a"}) {
+		t.Errorf("expected a removed, got %v", removed)
+	}
+	if got := s.Feeds(); !slices.Equal(got, []string{"b", "c"}) {
+		t.Errorf("expected feeds [b c], got %v", got)
+	}
+
+	// New feeds are fetched immediately; existing ones wait for the interval
+	waitFor(t, func() bool { return fetcher.count("c") == 1 })
+	if fetcher.count("b") != 0 {
+		t.Errorf("expected b not fetched before interval, got %d", fetcher.count("b"))
+	}
+
+	// Shortening the interval wakes pollers that were waiting on the old one
+	s.SetInterval(10 * time.Millisecond)
+	waitFor(t, func() bool { return fetcher.count("b") >= 2 })
+	if fetcher.count("a") != 0 {
+		t.Errorf("expected removed feed a never fetched, got %d", fetcher.count("a"))
+	}
+}
+
+// backoffFetcher asks the scheduler to hold off one feed for an hour.
+type backoffFetcher struct {
+	*countingFetcher
+	hold string
+}
+
+func (f *backoffFetcher) RetryAt(url string) time.Time {
+	if url == f.hold {
+		return time.Now().Add(time.Hour)
+	}
+	return time.Time{}
+}
+
+// TestBackoff verifies feeds in backoff are polled no sooner than requested.
+func TestBackoff(t *testing.T) {
+	fetcher := &backoffFetcher{countingFetcher: newCountingFetcher(), hold: "down"}
+	s := scheduler.New(fetcher, 10*time.Millisecond)
+	s.Start(context.Background(), []string{"up", "down"})
+	defer s.Stop()
+
+	waitFor(t, func() bool { return fetcher.count("up") >= 3 })
+	if got := fetcher.count("down"); got != 0 {
+		t.Errorf("expected feed in backoff not polled, got %d fetches", got)
+	}
+
+	next, ok := s.NextPoll("down")
+	if !ok || time.Until(next) < 59*time.Minute {
+		t.Errorf("expected next poll of down in about 1h, got %v (ok=%v)", next, ok)
+	}
+	if _, ok := s.NextPoll("missing"); ok {
+		t.Error("expected no next poll for an unknown feed")
+	}
+}
+
+// TestPollsRealFeed runs the scheduler over a real reader and HTTP server:
+// a transient failure is retried, and items published later reach the store.
+func TestPollsRealFeed(t *testing.T) {
+	srv := feedtest.NewServer(t, map[string]feedtest.Route{
+		"/feed": {Items: 1, Statuses: []int{503}},
+	})
+	articles := store.NewArticleStore()
+	rssReader := reader.NewRSSReader(articles,
+		reader.WithRetry(reader.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}))
+
+	s := scheduler.New(rssReader, 10*time.Millisecond)
+	s.Start(context.Background(), []string{srv.FeedURL("/feed")})
+	defer s.Stop()
+
+	waitFor(t, func() bool { return len(articles.GetRecent(10)) == 1 })
+
+	srv.Set("/feed", feedtest.Route{Items: 3})
+	waitFor(t, func() bool { return len(articles.GetRecent(10)) == 3 })
+	if got := articles.GetRecent(1)[0].Title; got != "Item 2" {
+		t.Errorf("newest article = %q, want Item 2", got)
+	}
+}
@@ -1,8 +1,10 @@
 package store
 
 import (
+	"context"
 	"slices"
 	"sync"
+	"time"
 
 	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
 )
@@ -11,8 +13,9 @@ import (
 // ARTICLE STORE - In-memory storage implementing feed.Storage
 // =============================================================================
 
-// Compile-time verification that ArticleStore implements feed.Storage
+// Compile-time verification that ArticleStore implements the domain interfaces
 var _ feed.Storage = (*ArticleStore)(nil)
+var _ feed.ArticleNotifier = (*ArticleStore)(nil)
 
 // ArticleStore provides thread-safe in-memory storage for articles.
 // This demonstrates the Repository pattern - encapsulating data access
@@ -20,23 +23,60 @@ var _ feed.Storage = (*ArticleStore)(nil)
 type ArticleStore struct {
 	mu       sync.RWMutex
 	articles []*feed.Article
+	seen     map[string]bool // Article keys already stored, for deduplication
+
+	subMu       sync.Mutex
+	subscribers map[int]func([]*feed.Article)
+	nextSubID   int
 }
 
 // NewArticleStore creates a new empty article store.
 func NewArticleStore() *ArticleStore {
 	return &ArticleStore{
-		articles: make([]*feed.Article, 0),
+		articles:    make([]*feed.Article, 0),
+		seen:        make(map[string]bool),
+		subscribers: make(map[int]func([]*feed.Article)),
 	}
 }
 
 // AddArticles stores new articles in memory.
-// Uses a write lock to ensure thread-safety during concurrent access.
+// Articles already in the store are skipped, so polling the same feed
+// repeatedly doesn't create duplicates. Subscribers are notified of the
+// articles that were actually new.
 func (s *ArticleStore) AddArticles(articles []*feed.Article) error {
+	added := s.add(articles)
+	if len(added) > 0 {
+		s.notify(added)
+	}
+	return nil
+}
+
+// add stores articles not seen before and returns them.
+// Uses a write lock to ensure thread-safety during concurrent access.
+func (s *ArticleStore) add(articles []*feed.Article) []*feed.Article {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// Append new articles to the store
-	s.articles = append(s.articles, articles...)
+	// Append only articles we haven't seen before
+	var added []*feed.Article
+	for _, article := range articles {
+		key := feed.ArticleKey(article)
+		if s.seen[key] {
+			continue
+		}
+		s.seen[key] = true
+		if article.Published == nil {
+			// Undated, or the date couldn't be parsed: first seen is the best guess
+			firstSeen := time.Now()
+			article.Published = &firstSeen
+			article.PublishedEstimated = true
+		}
+		s.articles = append(s.articles, article)
+		added = append(added, article)
+	}
+	if len(added) == 0 {
+		return nil
+	}
 
 	// Sort by publication date (newest first) for efficient retrieval
 	slices.SortFunc(s.articles, func(a, b *feed.Article) int {
@@ -61,7 +101,40 @@ func (s *ArticleStore) AddArticles(articles []*feed.Article) error {
 		return 0
 	})
 
-	return nil
+	return added
+}
+
+// Subscribe registers fn to be called with newly added articles.
+// fn runs synchronously after the store lock is released, so it must be
+// quick; hand slow work off to a goroutine. Call the returned function
+// to unsubscribe.
+func (s *ArticleStore) Subscribe(fn func(articles []*feed.Article)) (unsubscribe func()) {
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+
+	id := s.nextSubID
+	s.nextSubID++
+	s.subscribers[id] = fn
+
+	return func() {
+		s.subMu.Lock()
+		defer s.subMu.Unlock()
+		delete(s.subscribers, id)
+	}
+}
+
+// notify calls every subscriber with newly added articles.
+func (s *ArticleStore) notify(articles []*feed.Article) {
+	s.subMu.Lock()
+	subscribers := make([]func([]*feed.Article), 0, len(s.subscribers))
+	for _, fn := range s.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	s.subMu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(articles)
+	}
 }
 
 // GetRecent returns the n most recent articles.
@@ -85,6 +158,16 @@ func (s *ArticleStore) GetRecent(n int) []*feed.Article {
 	return result
 }
 
+// Ping reports whether the store is reachable.
+// For in-memory storage this only checks that the lock can be acquired,
+// but a database-backed store would issue a cheap query here.
+func (s *ArticleStore) Ping(ctx context.Context) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return ctx.Err()
+}
+
 // In a production system, you might add methods like:
 // - GetByFeed(feedTitle string) []*feed.Article
 // - GetByDateRange(start, end time.Time) []*feed.Article
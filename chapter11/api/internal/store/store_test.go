@@ -0,0 +1,156 @@
+package store_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/store"
+)
+
+func titles(articles []*feed.Article) []string {
+	var result []string
+	for _, a := range articles {
+		result = append(result, a.Title)
+	}
+	return result
+}
+
+func TestAddArticlesDeduplicates(t *testing.T) {
+	published := time.Date(2024, 8, 13, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		batches [][]*feed.Article
+		want    []string
+	}{
+		{
+			name: "same link",
+			batches: [][]*feed.Article{
+				{{Title: "Go 1.23", Link: "https://go.dev/blog/go1.23", FeedTitle: "Go Blog"}},
+				{{Title: "Go 1.23 is released", Link: "https://go.dev/blog/go1.23", FeedTitle: "Go Blog"}},
+			},
+			want: []string{"Go 1.23"},
+		},
+		{
+			name: "same link from another feed",
+			batches: [][]*feed.Article{
+				{{Title: "Go 1.23", Link: "https://go.dev/blog/go1.23", FeedTitle: "Go Blog"}},
+				{{Title: "Go 1.23", Link: "https://go.dev/blog/go1.23", FeedTitle: "Hacker News"}},
+			},
+			want: []string{"Go 1.23"},
+		},
+		{
+			name: "no link, same feed and title",
+			batches: [][]*feed.Article{
+				{{Title: "Weekly digest", FeedTitle: "Mailing list"}},
+				{{Title: "Weekly digest", FeedTitle: "Mailing list"}},
+			},
+			want: []string{"Weekly digest"},
+		},
+		{
+			name: "no link, same title in another feed",
+			batches: [][]*feed.Article{
+				{{Title: "Weekly digest", FeedTitle: "Mailing list"}},
+				{{Title: "Weekly digest", FeedTitle: "Newsletter"}},
+			},
+			want: []string{"Weekly digest", "Weekly digest"},
+		},
+		{
+			name: "duplicates within a batch",
+			batches: [][]*feed.Article{
+				{{Title: "Once", Link: "https://example.com/1"}, {Title: "Twice", Link: "https://example.com/1"}},
+			},
+			want: []string{"Once"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := store.NewArticleStore()
+			for _, batch := range tt.batches {
+				for _, a := range batch {
+					a.Published = &published
+				}
+				if err := s.AddArticles(batch); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := titles(s.GetRecent(10)); !slices.Equal(got, tt.want) {
+				t.Errorf("stored %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddArticlesEstimatesMissingDates(t *testing.T) {
+	s := store.NewArticleStore()
+	dated := time.Now().Add(-time.Hour)
+	before := time.Now()
+	s.AddArticles([]*feed.Article{
+		{Title: "Dated", Link: "https://example.com/dated", Published: &dated},
+		{Title: "Undated", Link: "https://example.com/undated"},
+	})
+
+	recent := s.GetRecent(2)
+	if got := titles(recent); !slices.Equal(got, []string{"Undated", "Dated"}) {
+		t.Fatalf("order = %q, want the undated article first, as first seen now", got)
+	}
+	undated := recent[0]
+	if !undated.PublishedEstimated || undated.Published == nil || undated.Published.Before(before) {
+		t.Errorf("undated article = %+v, want an estimated date of now", undated)
+	}
+	if recent[1].PublishedEstimated {
+		t.Error("dated article marked estimated")
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	s := store.NewArticleStore()
+	var first, second [][]string
+	unsubscribe := s.Subscribe(func(articles []*feed.Article) {
+		first = append(first, titles(articles))
+	})
+	s.Subscribe(func(articles []*feed.Article) {
+		second = append(second, titles(articles))
+	})
+
+	a := &feed.Article{Title: "A", Link: "https://example.com/a"}
+	b := &feed.Article{Title: "B", Link: "https://example.com/b"}
+	s.AddArticles([]*feed.Article{a})
+	s.AddArticles([]*feed.Article{a}) // Nothing new: no notification
+	s.AddArticles([]*feed.Article{a, b})
+	unsubscribe()
+	s.AddArticles([]*feed.Article{{Title: "C", Link: "https://example.com/c"}})
+
+	if want := [][]string{{"A"}, {"B"}}; !slices.EqualFunc(first, want, slices.Equal) {
+		t.Errorf("first subscriber got %q, want %q", first, want)
+	}
+	if want := [][]string{{"A"}, {"B"}, {"C"}}; !slices.EqualFunc(second, want, slices.Equal) {
+		t.Errorf("second subscriber got %q, want %q", second, want)
+	}
+}
+
+func TestGetRecent(t *testing.T) {
+	s := store.NewArticleStore()
+	now := time.Now()
+	for title, age := range map[string]time.Duration{"oldest": 2 * time.Hour, "newest": 0, "middle": time.Hour} {
+		published := now.Add(-age)
+		s.AddArticles([]*feed.Article{{Title: title, Link: "https://example.com/" + title, Published: &published}})
+	}
+
+	tests := []struct {
+		n    int
+		want []string
+	}{
+		{0, nil},
+		{-1, nil},
+		{2, []string{"newest", "middle"}},
+		{10, []string{"newest", "middle", "oldest"}},
+	}
+	for _, tt := range tests {
+		if got := titles(s.GetRecent(tt.n)); !slices.Equal(got, tt.want) {
+			t.Errorf("GetRecent(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package story
+
+import (
+	"slices"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+)
+
+// =============================================================================
+// CLUSTERS - Grouping near-duplicate articles into stories
+// =============================================================================
+
+// Story is one piece of news and every article covering it.
+type Story struct {
+	ID       string          `json:"id"`
+	Article  *feed.Article   `json:"article"`           // The representative: the first to be published
+	Siblings []*feed.Article `json:"siblings"`          // The other articles, newest first
+	Feeds    []string        `json:"feeds"`             // Titles of the feeds covering the story, sorted
+	Updated  *time.Time      `json:"updated,omitempty"` // When the newest article was published
+}
+
+// Size returns how many articles cover the story.
+func (s Story) Size() int {
+	return 1 + len(s.Siblings)
+}
+
+// Group clusters articles, newest first, into stories. An article joins
+// the first story holding an article within maxDistance bits of it, so
+// a chain of small rewordings stays one story. Stories are ordered by
+// their newest article.
+func Group(articles []*feed.Article, maxDistance int) []Story {
+	type cluster struct {
+		members      []*feed.Article
+		fingerprints []feed.Fingerprint
+	}
+	var clusters []*cluster
+
+	for _, article := range articles {
+		fingerprint := fingerprintOf(article)
+		var home *cluster
+		if fingerprint != 0 {
+			for _, c := range clusters {
+				if slices.ContainsFunc(c.fingerprints, func(f feed.Fingerprint) bool {
+					return f != 0 && f.Distance(fingerprint) <= maxDistance
+				}) {
+					home = c
+					break
+				}
+			}
+		}
+		if home == nil {
+			home = &cluster{}
+			clusters = append(clusters, home)
+		}
+		home.members = append(home.members, article)
+		home.fingerprints = append(home.fingerprints, fingerprint)
+	}
+
+	stories := make([]Story, len(clusters))
+	for i, c := range clusters {
+		stories[i] = newStory(c.members)
+	}
+	return stories
+}
+
+// newStory builds a story from its members, newest first.
+func newStory(members []*feed.Article) Story {
+	representative := 0
+	for i, article := range members {
+		if publishedBefore(article, members[representative]) {
+			representative = i
+		}
+	}
+
+	s := Story{
+		Article:  members[representative],
+		Siblings: make([]*feed.Article, 0, len(members)-1),
+		Updated:  members[0].Published,
+	}
+	for i, article := range members {
+		if i != representative {
+			s.Siblings = append(s.Siblings, article)
+		}
+		if !slices.Contains(s.Feeds, article.FeedTitle) && article.FeedTitle != "" {
+			s.Feeds = append(s.Feeds, article.FeedTitle)
+		}
+	}
+	slices.Sort(s.Feeds)
+
+	s.ID = feed.ArticleID(s.Article)
+	return s
+}
+
+// publishedBefore reports whether a was published before b. Undated
+// articles come last.
+func publishedBefore(a, b *feed.Article) bool {
+	switch {
+	case a.Published == nil:
+		return false
+	case b.Published == nil:
+		return true
+	default:
+		return a.Published.Before(*b.Published)
+	}
+}
+
+// Collapse returns the representative of each story in articles, in
+// story order, so a list shows every piece of news once.
+func Collapse(articles []*feed.Article, maxDistance int) []*feed.Article {
+	stories := Group(articles, maxDistance)
+	result := make([]*feed.Article, len(stories))
+	for i, s := range stories {
+		result[i] = s.Article
+	}
+	return result
+}
+
+// Why SimHash, computed on ingest and grouped on read:
+//
+// - A 64-bit fingerprint per article is cheap to store and to compare, and
+//   unlike exact hashes it tolerates the rewording and extra words feeds
+//   add to the same headline.
+// - Grouping at read time over recent articles keeps no cluster state to
+//   go stale when articles are dropped, deduplicated or aged out; it is
+//   quadratic only in the few hundred articles scanned.
+// - The first-published article represents a story because it is usually
+//   the source the others are reporting on.
@@ -0,0 +1,99 @@
+package story
+
+import (
+	"context"
+	"hash/fnv"
+	"slices"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/ingest"
+	"github.com/YOUR_USERNAME/go-news/api/internal/text"
+)
+
+// =============================================================================
+// STORIES - Near-duplicate detection and clustering across feeds
+// =============================================================================
+
+// DefaultMaxDistance is how many of 64 fingerprint bits two articles may
+// differ in and still be the same story. Unrelated texts differ in about
+// 32; rewordings of one headline in well under 10.
+const DefaultMaxDistance = 10
+
+// Feature weights. Titles are what feeds agree on; descriptions range
+// from a teaser to the full post, so they count less and only their
+// opening is used.
+const (
+	titleWeight         = 4
+	descriptionWeight   = 1
+	maxDescriptionTerms = 40
+)
+
+// Fingerprint returns the SimHash of an article's title and description,
+// over their words and the word pairs of the title, ignoring case,
+// punctuation and stopwords. Text with no words fingerprints as zero.
+func Fingerprint(title, description string) feed.Fingerprint {
+	var weights [64]int
+	features := 0
+	add := func(feature string, weight int) {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		sum := h.Sum64()
+		for bit := range weights {
+			if sum&(1<<bit) != 0 {
+				weights[bit] += weight
+			} else {
+				weights[bit] -= weight
+			}
+		}
+		features++
+	}
+
+	titleTerms := terms(title)
+	for i, term := range titleTerms {
+		add(term, titleWeight)
+		if i > 0 {
+			add(titleTerms[i-1]+" "+term, titleWeight)
+		}
+	}
+	descriptionTerms := terms(description)
+	if len(descriptionTerms) > maxDescriptionTerms {
+		descriptionTerms = descriptionTerms[:maxDescriptionTerms]
+	}
+	for _, term := range descriptionTerms {
+		add(term, descriptionWeight)
+	}
+
+	if features == 0 {
+		return 0
+	}
+	var fingerprint feed.Fingerprint
+	for bit, weight := range weights {
+		if weight > 0 {
+			fingerprint |= 1 << bit
+		}
+	}
+	return fingerprint
+}
+
+// terms returns the words of s that aren't stopwords.
+func terms(s string) []string {
+	return slices.DeleteFunc(text.Words(s), text.IsStopword)
+}
+
+// Fingerprinter returns an ingest processor that sets each article's
+// Fingerprint. It should run after Sanitize, so markup doesn't count.
+func Fingerprinter() ingest.Processor {
+	return ingest.ProcessorFunc{ProcessorName: "fingerprint", Fn: func(ctx context.Context, article *feed.Article) (bool, error) {
+		article.Fingerprint = Fingerprint(article.Title, article.Description)
+		return true, nil
+	}}
+}
+
+// fingerprintOf returns article's fingerprint, computing it for articles
+// stored before fingerprints were.
+func fingerprintOf(article *feed.Article) feed.Fingerprint {
+	if article.Fingerprint != 0 {
+		return article.Fingerprint
+	}
+	return Fingerprint(article.Title, article.Description)
+}
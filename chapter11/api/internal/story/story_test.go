@@ -0,0 +1,143 @@
+package story_test
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/ingest"
+	"github.com/YOUR_USERNAME/go-news/api/internal/story"
+)
+
+const goRelease = "The Go team is happy to announce the release of Go 1.23, with iterators and telemetry."
+
+func TestFingerprintDistance(t *testing.T) {
+	base := story.Fingerprint("Go 1.23 is released", goRelease)
+	tests := []struct {
+		name        string
+		title       string
+		description string
+		wantSame    bool
+	}{
+		{"identical", "Go 1.23 is released", goRelease, true},
+		{"case and punctuation", "GO 1.23 IS RELEASED!", goRelease, true},
+		{"reworded", "Go 1.23 released", "The Go team announced Go 1.23 today, with iterators and telemetry.", true},
+		{"title only", "Go 1.23 is released", "", true},
+		{"another release", "Rust 1.80 released", "The Rust team is happy to announce a new version of Rust.", false},
+		{"unrelated", "Kubernetes 1.31 is out", "New features in Kubernetes.", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := base.Distance(story.Fingerprint(tt.title, tt.description))
+			if same := d <= story.DefaultMaxDistance; same != tt.wantSame {
+				t.Errorf("distance %d, same story = %v, want %v", d, same, tt.wantSame)
+			}
+		})
+	}
+}
+
+func TestFingerprintEmpty(t *testing.T) {
+	if f := story.Fingerprint("", " the, and! "); f != 0 {
+		t.Errorf("Fingerprint of stopwords = %v, want 0", f)
+	}
+}
+
+func TestFingerprinter(t *testing.T) {
+	a := &feed.Article{Title: "Go 1.23 is released", Description: goRelease}
+	kept, err := ingest.New(story.Fingerprinter()).ProcessArticles(context.Background(), "feed", []*feed.Article{a})
+	if err != nil || len(kept) != 1 {
+		t.Fatalf("ProcessArticles = %d articles, %v", len(kept), err)
+	}
+	if want := story.Fingerprint(a.Title, a.Description); a.Fingerprint != want {
+		t.Errorf("Fingerprint = %v, want %v", a.Fingerprint, want)
+	}
+}
+
+func TestFingerprintJSON(t *testing.T) {
+	article := feed.Article{Title: "T", Fingerprint: 0x00ff00000000abcd}
+	data, err := json.Marshal(article)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded feed.Article
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Fingerprint != article.Fingerprint {
+		t.Errorf("round trip gave %v, want %v in %s", decoded.Fingerprint, article.Fingerprint, data)
+	}
+}
+
+// article returns an article published hours after a fixed time.
+func article(feedTitle, title, description string, hours int) *feed.Article {
+	published := time.Date(2024, 8, 13, 0, 0, 0, 0, time.UTC).Add(time.Duration(hours) * time.Hour)
+	return &feed.Article{
+		Title:       title,
+		Description: description,
+		Link:        "https://" + feedTitle + ".example/" + title,
+		FeedTitle:   feedTitle,
+		Published:   &published,
+	}
+}
+
+func TestGroup(t *testing.T) {
+	// Newest first, as the store returns them.
+	articles := []*feed.Article{
+		article("hn", "Go 1.23 released", "The Go team announced Go 1.23 today, with iterators and telemetry.", 5),
+		article("lobsters", "Kubernetes 1.31 is out", "New features in Kubernetes.", 4),
+		article("reddit", "Go 1.23 is released!", "", 3),
+		article("rust", "Rust 1.80 released", "The Rust team is happy to announce a new version of Rust.", 2),
+		article("golang", "Go 1.23 is released", goRelease, 1),
+	}
+	articles[2].Fingerprint = story.Fingerprint(articles[2].Title, articles[2].Description)
+
+	stories := story.Group(articles, story.DefaultMaxDistance)
+	if len(stories) != 3 {
+		t.Fatalf("got %d stories, want 3", len(stories))
+	}
+
+	release := stories[0]
+	if release.Article != articles[4] {
+		t.Errorf("representative = %q from %s, want the first published", release.Article.Title, release.Article.FeedTitle)
+	}
+	if !slices.Equal(release.Siblings, []*feed.Article{articles[0], articles[2]}) {
+		t.Errorf("siblings = %v, want the other two releases newest first", release.Siblings)
+	}
+	if want := []string{"golang", "hn", "reddit"}; !slices.Equal(release.Feeds, want) {
+		t.Errorf("feeds = %v, want %v", release.Feeds, want)
+	}
+	if release.Size() != 3 || !release.Updated.Equal(*articles[0].Published) {
+		t.Errorf("size %d updated %v, want 3 and the newest article", release.Size(), release.Updated)
+	}
+	if release.ID != feed.ID(articles[4].Link) {
+		t.Errorf("ID = %s, want the representative's", release.ID)
+	}
+
+	for i, want := range []*feed.Article{articles[1], articles[3]} {
+		if s := stories[i+1]; s.Article != want || len(s.Siblings) != 0 {
+			t.Errorf("story %d = %q with %d siblings, want %q alone", i+1, s.Article.Title, len(s.Siblings), want.Title)
+		}
+	}
+}
+
+func TestGroupKeepsEmptyTextApart(t *testing.T) {
+	articles := []*feed.Article{{Link: "https://example.com/1"}, {Link: "https://example.com/2"}}
+	if stories := story.Group(articles, story.DefaultMaxDistance); len(stories) != 2 {
+		t.Errorf("got %d stories for two articles without text, want 2", len(stories))
+	}
+}
+
+func TestCollapse(t *testing.T) {
+	articles := []*feed.Article{
+		article("hn", "Go 1.23 released", "", 3),
+		article("rust", "Rust 1.80 released", "", 2),
+		article("golang", "Go 1.23 is released", "", 1),
+	}
+	got := story.Collapse(articles, story.DefaultMaxDistance)
+	if want := []*feed.Article{articles[2], articles[1]}; !slices.Equal(got, want) {
+		t.Errorf("Collapse = %v, want the Go representative then Rust", got)
+	}
+}
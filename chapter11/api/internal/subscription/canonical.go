@@ -0,0 +1,79 @@
+package subscription
+
+import (
+	"net/url"
+	"strings"
+)
+
+// =============================================================================
+// CANONICAL URLS - Recognising the same feed written differently
+// =============================================================================
+
+// trackingParams are query parameters that identify a click or campaign
+// rather than the feed. Parameters starting with "utm_" are dropped too.
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"dclid":   true,
+	"msclkid": true,
+	"yclid":   true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"_hsenc":  true,
+	"_hsmi":   true,
+}
+
+// Canonicalize returns url in the form subscriptions are stored: scheme
+// and host lower-cased, default ports, fragments and tracking parameters
+// removed, and the remaining query sorted. The result fetches the same
+// document as url. Unparseable input is returned unchanged.
+func Canonicalize(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	if port := u.Port(); (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		u.Host = u.Hostname()
+	}
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	query := u.Query()
+	for name := range query {
+		if trackingParams[strings.ToLower(name)] || strings.HasPrefix(strings.ToLower(name), "utm_") {
+			query.Del(name)
+		}
+	}
+	u.RawQuery = query.Encode() // Encode sorts by key
+
+	return u.String()
+}
+
+// sameFeedKey returns the identity used to detect duplicate subscriptions.
+// On top of Canonicalize it ignores the scheme and a trailing slash: sites
+// serve the same feed over http and https and with or without the slash,
+// but these can't be rewritten in the stored URL without risking a 404.
+func sameFeedKey(rawURL string) string {
+	u, err := url.Parse(Canonicalize(rawURL))
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+
+	path := strings.TrimSuffix(u.EscapedPath(), "/")
+	key := u.Host + path
+	if u.RawQuery != "" {
+		key += "?" + u.RawQuery
+	}
+	return key
+}
+
+// Why two levels of canonicalisation:
+//
+// - Canonicalize only makes changes that leave the served document the
+//   same, so the stored URL is always safe to fetch.
+// - sameFeedKey also folds http/https and trailing slashes, which are the
+//   same feed on almost every site; a false match only refuses a
+//   duplicate subscription, it never changes what is fetched.
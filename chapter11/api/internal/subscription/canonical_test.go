@@ -0,0 +1,67 @@
+package subscription_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/subscription"
+)
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"https://example.com/feed.xml", "https://example.com/feed.xml"},
+		{"HTTPS://Example.COM/Feed.xml", "https://example.com/Feed.xml"},
+		{"https://example.com:443/feed", "https://example.com/feed"},
+		{"http://example.com:80/feed", "http://example.com/feed"},
+		{"http://example.com:8080/feed", "http://example.com:8080/feed"},
+		{"https://example.com/feed#latest", "https://example.com/feed"},
+		{"https://example.com/feed?utm_source=x&utm_medium=y&fbclid=z", "https://example.com/feed"},
+		{"https://example.com/feed?page=2&cat=go&gclid=1", "https://example.com/feed?cat=go&page=2"},
+		{"  https://example.com/feed  ", "https://example.com/feed"},
+		{"not a url", "not a url"},
+	}
+
+	for _, tt := range tests {
+		if got := subscription.Canonicalize(tt.in); got != tt.want {
+			t.Errorf("Canonicalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestStoreDuplicates(t *testing.T) {
+	store, err := subscription.NewStore("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.SetConfigured([]string{"https://a.example/feed/"})
+
+	if _, err := store.Add(subscription.Subscription{URL: "https://b.example/rss?utm_source=newsletter"}); err != nil {
+		t.Fatalf("Add error = %v", err)
+	}
+
+	// Each of these is a feed above written differently
+	for _, url := range []string{
+		"http://a.example/feed",
+		"https://A.example/feed/#top",
+		"http://b.example/rss",
+		"https://b.example/rss/?fbclid=abc",
+	} {
+		if _, err := store.Add(subscription.Subscription{URL: url}); !errors.Is(err, subscription.ErrExists) {
+			t.Errorf("Add(%q) error = %v, want ErrExists", url, err)
+		}
+	}
+
+	// The same feed from the config file doesn't add a second entry
+	store.SetConfigured([]string{"https://a.example/feed/", "http://b.example/rss"})
+	if subs := store.List(); len(subs) != 2 {
+		t.Errorf("subscriptions = %+v, want 2", subs)
+	}
+
+	// Different queries are different feeds
+	if _, err := store.Add(subscription.Subscription{URL: "https://b.example/rss?cat=go"}); err != nil {
+		t.Errorf("Add with a different query error = %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package subscription
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+)
+
+// =============================================================================
+// SERVICE - Subscribing by website URL and keeping polling in sync
+// =============================================================================
+
+// Reader finds feeds for a URL and tracks per-feed fetch state and
+// settings.
+type Reader interface {
+	Discover(ctx context.Context, url string, settings feed.FetchSettings) ([]feed.Candidate, error)
+	Configure(url string, settings feed.FetchSettings) error
+	Forget(url string)
+}
+
+// Poller polls exactly the feeds it is given.
+type Poller interface {
+	Sync(urls []string) (added, removed []string)
+}
+
+// Compile-time verification that Service follows moved and gone feeds
+var _ feed.Watcher = (*Service)(nil)
+
+// Service changes subscriptions and keeps the poller in step with them.
+type Service struct {
+	store  *Store
+	reader Reader
+	poller Poller
+
+	// mu serialises changes so concurrent syncs can't apply out of order
+	mu sync.Mutex
+}
+
+// NewService creates a subscription service.
+func NewService(store *Store, reader Reader, poller Poller) *Service {
+	return &Service{
+		store:  store,
+		reader: reader,
+		poller: poller,
+	}
+}
+
+// LoadSettings passes every subscription's fetch settings to the reader.
+// Call it once at startup, before the first fetch; later changes are
+// passed on as they happen.
+func (s *Service) LoadSettings() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.configureLocked()
+}
+
+// List returns every subscription sorted by URL, including dead ones.
+func (s *Service) List() []Subscription {
+	return s.store.List()
+}
+
+// Discover returns the feed candidates for a URL without subscribing.
+func (s *Service) Discover(ctx context.Context, url string) ([]feed.Candidate, error) {
+	return s.reader.Discover(ctx, url, feed.FetchSettings{})
+}
+
+// Subscribe discovers the feed behind url, subscribes to the first
+// candidate and starts polling it. settings, if not nil, are used for
+// discovery and every later fetch. All candidates are returned so callers
+// can offer the alternatives.
+func (s *Service) Subscribe(ctx context.Context, url string, settings *feed.FetchSettings) (Subscription, []feed.Candidate, error) {
+	var fetchSettings feed.FetchSettings
+	if settings != nil {
+		fetchSettings = *settings
+	}
+	candidates, err := s.reader.Discover(ctx, url, fetchSettings)
+	if err != nil {
+		return Subscription{}, nil, err
+	}
+
+	chosen := candidates[0]
+	sub := Subscription{URL: chosen.URL, Title: chosen.Title, Settings: settings}
+	if chosen.URL != url {
+		sub.RequestedURL = url
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, err = s.store.Add(sub)
+	if err != nil {
+		return Subscription{}, candidates, err
+	}
+	s.syncLocked()
+	return sub, candidates, nil
+}
+
+// Unsubscribe removes an API subscription and stops polling it.
+func (s *Service) Unsubscribe(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.store.Remove(id); err != nil {
+		return err
+	}
+	s.syncLocked()
+	return nil
+}
+
+// UpdateSettings replaces the fetch settings of an API subscription.
+// Settings the reader rejects aren't stored.
+func (s *Service) UpdateSettings(id string, settings *feed.FetchSettings) (Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, err := s.store.Get(id)
+	if err != nil {
+		return Subscription{}, err
+	}
+	if settings != nil {
+		if err := s.reader.Configure(sub.URL, *settings); err != nil {
+			return Subscription{}, err
+		}
+	}
+
+	sub, err = s.store.SetSettings(id, settings)
+	s.syncLocked() // Applies the stored settings, whether or not they changed
+	return sub, err
+}
+
+// SetConfigured replaces the feeds from the config file, as on a reload,
+// and returns the URLs that started and stopped polling.
+func (s *Service) SetConfigured(urls []string) (added, removed []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.store.SetConfigured(urls)
+	return s.syncLocked()
+}
+
+// Moved implements feed.Watcher: the subscription follows a permanent
+// redirect, and polling switches to the new URL.
+func (s *Service) Moved(from, to string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.store.Move(from, to); err != nil {
+		slog.Warn("failed to record moved feed", "url", from, "to", to, "error", err)
+	}
+	s.syncLocked()
+}
+
+// Gone implements feed.Watcher: the subscription is marked dead and
+// polling stops. It stays listed until it is removed.
+func (s *Service) Gone(url string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.store.MarkDead(url); err != nil {
+		slog.Warn("failed to record dead feed", "url", url, "error", err)
+	}
+	s.syncLocked()
+}
+
+// syncLocked makes the reader and poller match the store and forgets
+// removed feeds, except dead ones whose final status is still worth
+// reporting. s.mu must be held.
+func (s *Service) syncLocked() (added, removed []string) {
+	s.configureLocked()
+	added, removed = s.poller.Sync(s.store.URLs())
+	for _, url := range removed {
+		if !s.store.dead(url) {
+			s.reader.Forget(url)
+		}
+	}
+	return added, removed
+}
+
+// configureLocked passes the stored settings of every subscription to the
+// reader. s.mu must be held.
+func (s *Service) configureLocked() {
+	for _, sub := range s.store.List() {
+		var settings feed.FetchSettings
+		if sub.Settings != nil {
+			settings = *sub.Settings
+		}
+		if err := s.reader.Configure(sub.URL, settings); err != nil {
+			slog.Warn("ignoring invalid fetch settings", "feed", sub.ID, "error", err)
+		}
+	}
+}
+
+// Why config and API subscriptions share one store:
+//
+// - The scheduler polls a single list, so reloading the config must not
+//   drop feeds added through the API, and vice versa.
+// - Only API subscriptions are persisted; the config file already is the
+//   persistent record of its own feeds. Moves are persisted for both, as
+//   the config file can't be rewritten for the user.
+// - The reader only reports moved and gone feeds; deciding what that
+//   means for a subscription belongs here, next to the store.
@@ -0,0 +1,455 @@
+package subscription
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/persist"
+)
+
+// =============================================================================
+// SUBSCRIPTIONS - The feeds we poll, from config and from the API
+// =============================================================================
+
+// Where a subscription came from.
+const (
+	SourceConfig = "config" // Listed in the config file; changed by editing it
+	SourceAPI    = "api"    // Added with POST /feeds; persisted in the state file
+)
+
+// maxMoves bounds how many permanent redirects are followed when resolving
+// a URL, in case publishers have redirected in a loop over time.
+const maxMoves = 10
+
+var (
+	// ErrNotFound is returned when a subscription ID doesn't exist.
+	ErrNotFound = errors.New("subscription not found")
+
+	// ErrExists is returned when subscribing to a feed twice.
+	ErrExists = errors.New("already subscribed to this feed")
+
+	// ErrConfigured is returned when removing a feed the config file lists.
+	ErrConfigured = errors.New("feed is listed in the config file; remove it there")
+)
+
+// Subscription is one polled feed.
+type Subscription struct {
+	ID           string    `json:"id"` // feed.ID of the URL first subscribed to; kept when the feed moves
+	URL          string    `json:"url"`
+	Title        string    `json:"title,omitempty"`
+	Source       string    `json:"source"`
+	RequestedURL string    `json:"requested_url,omitempty"` // What the user entered, if discovery chose a different URL
+	MovedFrom    string    `json:"moved_from,omitempty"`    // The URL subscribed to, if the feed has permanently moved since
+	Dead         bool      `json:"dead,omitempty"`          // Answered 410 Gone; listed but no longer polled
+	CreatedAt    time.Time `json:"created_at"`
+
+	Settings *feed.FetchSettings `json:"settings,omitempty"` // Credentials, headers, CA bundle and proxy
+}
+
+// Redacted returns a copy of the subscription with credentials masked,
+// for API responses and logs.
+func (s Subscription) Redacted() Subscription {
+	if s.Settings != nil {
+		redacted := s.Settings.Redacted()
+		s.Settings = &redacted
+	}
+	return s
+}
+
+// state is the persisted form of the store.
+type state struct {
+	Subscriptions []storedSubscription `json:"subscriptions"`
+	Moved         map[string]string    `json:"moved,omitempty"` // Old URL to new, from permanent redirects
+}
+
+// storedSubscription is a subscription as written to the state file. With
+// a secret key, settings are only written encrypted.
+type storedSubscription struct {
+	Subscription
+	Settings          *feed.FetchSettings `json:"settings,omitempty"` // Plaintext, when there is no key
+	EncryptedSettings string              `json:"encrypted_settings,omitempty"`
+}
+
+// Store holds subscriptions in memory. Subscriptions added through the API
+// are persisted to a state file; configured ones are re-supplied on every
+// start and reload. Permanent moves are persisted for both, so a config
+// file that still lists a moved feed's old URL polls the new one.
+type Store struct {
+	stateFile string
+	sealer    *sealer // nil without a secret key
+
+	mu    sync.Mutex
+	subs  map[string]Subscription // Keyed by URL
+	moved map[string]string       // Old URL to new
+}
+
+// NewStore creates a store, restoring API subscriptions from stateFile if
+// it exists. An empty stateFile keeps them in memory only. secretKey is an
+// AES key that encrypts fetch settings in the state file; without one,
+// settings containing credentials can't be persisted.
+func NewStore(stateFile string, secretKey []byte) (*Store, error) {
+	sealer, err := newSealer(secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Store{
+		stateFile: stateFile,
+		sealer:    sealer,
+		subs:      make(map[string]Subscription),
+		moved:     make(map[string]string),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SetConfigured replaces the configured subscriptions with urls. A URL
+// that is already subscribed through the API, possibly written
+// differently, keeps its API entry. Configured feeds that are still
+// listed stay dead if they were.
+func (s *Store) SetConfigured(urls []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dead := make(map[string]bool)
+	for url, sub := range s.subs {
+		if sub.Source == SourceConfig {
+			dead[url] = sub.Dead
+			delete(s.subs, url)
+		}
+	}
+	for _, url := range urls {
+		resolved := s.resolveLocked(url)
+		if _, ok := s.findLocked(resolved); ok {
+			continue
+		}
+
+		sub := Subscription{
+			ID:     feed.ID(url), // As listed, so it survives a move
+			URL:    resolved,
+			Source: SourceConfig,
+			Dead:   dead[resolved],
+		}
+		if resolved != url {
+			sub.MovedFrom = url
+		}
+		s.subs[resolved] = sub
+	}
+}
+
+// Add stores an API subscription, filling in its ID and creation time.
+// The URL is canonicalised, and ErrExists is returned if the same feed is
+// already subscribed under any spelling or former URL.
+func (s *Store) Add(sub Subscription) (Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub.URL = s.resolveLocked(Canonicalize(sub.URL))
+	if _, ok := s.findLocked(sub.URL); ok {
+		return Subscription{}, ErrExists
+	}
+	if err := s.checkSettings(sub.Settings); err != nil {
+		return Subscription{}, err
+	}
+
+	sub.ID = feed.ID(sub.URL)
+	sub.Source = SourceAPI
+	sub.CreatedAt = time.Now().UTC()
+	s.subs[sub.URL] = sub
+
+	if err := s.persistLocked(); err != nil {
+		delete(s.subs, sub.URL)
+		return Subscription{}, err
+	}
+	return sub, nil
+}
+
+// Remove deletes an API subscription and returns it.
+func (s *Store) Remove(id string) (Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for url, sub := range s.subs {
+		if sub.ID != id {
+			continue
+		}
+		if sub.Source == SourceConfig {
+			return Subscription{}, ErrConfigured
+		}
+
+		delete(s.subs, url)
+		if err := s.persistLocked(); err != nil {
+			s.subs[url] = sub
+			return Subscription{}, err
+		}
+		return sub, nil
+	}
+	return Subscription{}, ErrNotFound
+}
+
+// Get returns the subscription with the given ID.
+func (s *Store) Get(id string) (Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sub := range s.subs {
+		if sub.ID == id {
+			return sub, nil
+		}
+	}
+	return Subscription{}, ErrNotFound
+}
+
+// SetSettings replaces the fetch settings of an API subscription; nil
+// settings restore the defaults.
+func (s *Store) SetSettings(id string, settings *feed.FetchSettings) (Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkSettings(settings); err != nil {
+		return Subscription{}, err
+	}
+	for url, sub := range s.subs {
+		if sub.ID != id {
+			continue
+		}
+		if sub.Source == SourceConfig {
+			return Subscription{}, ErrConfigured
+		}
+
+		previous := sub
+		sub.Settings = settings
+		s.subs[url] = sub
+		if err := s.persistLocked(); err != nil {
+			s.subs[url] = previous
+			return Subscription{}, err
+		}
+		return sub, nil
+	}
+	return Subscription{}, ErrNotFound
+}
+
+// checkSettings refuses credentials that would be written to the state
+// file unencrypted.
+func (s *Store) checkSettings(settings *feed.FetchSettings) error {
+	if settings != nil && settings.HasSecrets() && s.stateFile != "" && s.sealer == nil {
+		return ErrNoSecretKey
+	}
+	return nil
+}
+
+// Move records that the feed at from has permanently moved to to, and
+// updates its subscription, which keeps its ID so clients holding it can
+// still manage it. If to is already subscribed, the old entry is merged
+// into that one: its fetch settings carry over to an API subscription
+// without settings, and are otherwise dropped with a warning. The change
+// applies in memory even if persisting fails.
+func (s *Store) Move(from, to string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, ok := s.subs[from]
+	if !ok {
+		return ErrNotFound
+	}
+
+	to = Canonicalize(to)
+	s.moved[from] = to
+	delete(s.subs, from)
+
+	existing, ok := s.findLocked(to)
+	switch {
+	case !ok:
+		if sub.MovedFrom == "" {
+			sub.MovedFrom = from
+		}
+		sub.URL = to
+		s.subs[to] = sub
+	case sub.Settings == nil:
+		slog.Info("moved feed is already subscribed; merged", "url", from, "to", to, "id", sub.ID, "into", existing.ID)
+	case existing.Source == SourceAPI && existing.Settings == nil:
+		existing.Settings = sub.Settings
+		s.subs[existing.URL] = existing
+		slog.Info("moved feed is already subscribed; merged with its fetch settings", "url", from, "to", to, "id", sub.ID, "into", existing.ID)
+	default:
+		slog.Warn("moved feed is already subscribed; dropped its fetch settings", "url", from, "to", to, "id", sub.ID, "into", existing.ID)
+	}
+	return s.persistLocked()
+}
+
+// MarkDead records that the feed at url is gone, so it is no longer
+// polled. The change applies in memory even if persisting fails.
+func (s *Store) MarkDead(url string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, ok := s.subs[url]
+	if !ok {
+		return ErrNotFound
+	}
+	sub.Dead = true
+	s.subs[url] = sub
+	return s.persistLocked()
+}
+
+// List returns every subscription sorted by URL, including dead ones.
+func (s *Store) List() []Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subs := make([]Subscription, 0, len(s.subs))
+	for _, sub := range s.subs {
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool {
+		return subs[i].URL < subs[j].URL
+	})
+	return subs
+}
+
+// URLs returns the sorted URLs of the subscriptions that should be polled:
+// every one that isn't dead.
+func (s *Store) URLs() []string {
+	var urls []string
+	for _, sub := range s.List() {
+		if !sub.Dead {
+			urls = append(urls, sub.URL)
+		}
+	}
+	return urls
+}
+
+// dead reports whether the subscription for url is dead.
+func (s *Store) dead(url string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.subs[url].Dead
+}
+
+// resolveLocked follows recorded permanent moves from url. s.mu must be held.
+func (s *Store) resolveLocked(url string) string {
+	for range maxMoves {
+		next, ok := s.moved[url]
+		if !ok {
+			break
+		}
+		url = next
+	}
+	return url
+}
+
+// findLocked returns the subscription for the same feed as url, however
+// it is written. s.mu must be held.
+func (s *Store) findLocked(url string) (Subscription, bool) {
+	if sub, ok := s.subs[url]; ok {
+		return sub, true
+	}
+
+	key := sameFeedKey(url)
+	for _, sub := range s.subs {
+		if sameFeedKey(sub.URL) == key {
+			return sub, true
+		}
+	}
+	return Subscription{}, false
+}
+
+// load restores API subscriptions and moves from the state file, if present.
+func (s *Store) load() error {
+	if s.stateFile == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.stateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read subscriptions: %w", err)
+	}
+
+	var saved state
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return fmt.Errorf("failed to parse subscriptions %s: %w", s.stateFile, err)
+	}
+	for _, stored := range saved.Subscriptions {
+		sub := stored.Subscription
+		sub.Source = SourceAPI
+		sub.Settings = stored.Settings
+
+		if stored.EncryptedSettings != "" {
+			if s.sealer == nil {
+				return fmt.Errorf("subscriptions %s: feed %s has encrypted settings but no secret key is set", s.stateFile, sub.ID)
+			}
+			plaintext, err := s.sealer.open(stored.EncryptedSettings, sub.ID)
+			if err != nil {
+				return fmt.Errorf("subscriptions %s: feed %s: %w", s.stateFile, sub.ID, err)
+			}
+			if err := json.Unmarshal(plaintext, &sub.Settings); err != nil {
+				return fmt.Errorf("subscriptions %s: feed %s: invalid settings: %w", s.stateFile, sub.ID, err)
+			}
+		}
+		s.subs[sub.URL] = sub
+	}
+	for from, to := range saved.Moved {
+		s.moved[from] = to
+	}
+	return nil
+}
+
+// persistLocked atomically writes API subscriptions and moves to the
+// state file. s.mu must be held.
+func (s *Store) persistLocked() error {
+	if s.stateFile == "" {
+		return nil
+	}
+
+	saved := state{
+		Subscriptions: make([]storedSubscription, 0, len(s.subs)),
+		Moved:         s.moved,
+	}
+	for _, sub := range s.subs {
+		if sub.Source != SourceAPI {
+			continue
+		}
+
+		stored := storedSubscription{Subscription: sub}
+		switch {
+		case sub.Settings == nil:
+		case s.sealer == nil:
+			stored.Settings = sub.Settings
+		default:
+			plaintext, err := json.Marshal(sub.Settings)
+			if err != nil {
+				return fmt.Errorf("failed to encode settings: %w", err)
+			}
+			if stored.EncryptedSettings, err = s.sealer.seal(plaintext, sub.ID); err != nil {
+				return err
+			}
+		}
+		saved.Subscriptions = append(saved.Subscriptions, stored)
+	}
+	sort.Slice(saved.Subscriptions, func(i, j int) bool {
+		return saved.Subscriptions[i].URL < saved.Subscriptions[j].URL
+	})
+
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode subscriptions: %w", err)
+	}
+
+	if err := persist.WriteFile(s.stateFile, data); err != nil {
+		return fmt.Errorf("failed to write subscriptions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,400 @@
+package subscription_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/subscription"
+)
+
+func TestStore(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "subscriptions.json")
+
+	store, err := subscription.NewStore(stateFile, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.SetConfigured([]string{"https://a.example/rss", "https://b.example/rss"})
+
+	added, err := store.Add(subscription.Subscription{URL: "https://c.example/feed", Title: "C"})
+	if err != nil {
+		t.Fatalf("Add error = %v", err)
+	}
+	if added.ID != feed.ID(added.URL) || added.Source != subscription.SourceAPI || added.CreatedAt.IsZero() {
+		t.Errorf("Add = %+v, want ID, source and creation time filled in", added)
+	}
+
+	if _, err := store.Add(subscription.Subscription{URL: "https://a.example/rss"}); !errors.Is(err, subscription.ErrExists) {
+		t.Errorf("Add configured feed error = %v, want ErrExists", err)
+	}
+	if _, err := store.Remove(feed.ID("https://a.example/rss")); !errors.Is(err, subscription.ErrConfigured) {
+		t.Errorf("Remove configured feed error = %v, want ErrConfigured", err)
+	}
+	if _, err := store.Remove("missing"); !errors.Is(err, subscription.ErrNotFound) {
+		t.Errorf("Remove missing error = %v, want ErrNotFound", err)
+	}
+
+	// A reload drops b, and API subscriptions survive it
+	store.SetConfigured([]string{"https://a.example/rss"})
+	want := []string{"https://a.example/rss", "https://c.example/feed"}
+	if got := store.URLs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("URLs after reload = %v, want %v", got, want)
+	}
+
+	// Only API subscriptions are persisted
+	restored, err := subscription.NewStore(stateFile, nil)
+	if err != nil {
+		t.Fatalf("NewStore from state error = %v", err)
+	}
+	subs := restored.List()
+	if len(subs) != 1 || subs[0] != added {
+		t.Fatalf("restored = %+v, want only %+v", subs, added)
+	}
+
+	if _, err := restored.Remove(added.ID); err != nil {
+		t.Fatalf("Remove error = %v", err)
+	}
+	restored, err = subscription.NewStore(stateFile, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subs := restored.List(); len(subs) != 0 {
+		t.Errorf("after Remove, restored = %+v, want none", subs)
+	}
+}
+
+// errInvalidSettings is what fakeReader returns for the proxy "invalid".
+var errInvalidSettings = errors.New("invalid fetch settings")
+
+// fakeReader is a test double for subscription.Reader.
+type fakeReader struct {
+	candidates map[string][]feed.Candidate
+	forgotten  []string
+	settings   map[string]feed.FetchSettings
+}
+
+func (f *fakeReader) Discover(ctx context.Context, url string, settings feed.FetchSettings) ([]feed.Candidate, error) {
+	candidates, ok := f.candidates[url]
+	if !ok {
+		return nil, errors.New("no feed found")
+	}
+	return candidates, nil
+}
+
+func (f *fakeReader) Configure(url string, settings feed.FetchSettings) error {
+	if settings.Proxy == "invalid" {
+		return errInvalidSettings
+	}
+	if f.settings == nil {
+		f.settings = make(map[string]feed.FetchSettings)
+	}
+	if settings.IsZero() {
+		delete(f.settings, url)
+	} else {
+		f.settings[url] = settings
+	}
+	return nil
+}
+
+func (f *fakeReader) Forget(url string) {
+	f.forgotten = append(f.forgotten, url)
+}
+
+// fakePoller is a test double for subscription.Poller.
+type fakePoller struct {
+	urls map[string]bool
+}
+
+func (f *fakePoller) Sync(urls []string) (added, removed []string) {
+	wanted := make(map[string]bool)
+	for _, url := range urls {
+		wanted[url] = true
+		if !f.urls[url] {
+			added = append(added, url)
+		}
+	}
+	for url := range f.urls {
+		if !wanted[url] {
+			removed = append(removed, url)
+		}
+	}
+	sort.Strings(removed)
+	f.urls = wanted
+	return added, removed
+}
+
+func TestStoreMoveAndDead(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "subscriptions.json")
+	store, err := subscription.NewStore(stateFile, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.SetConfigured([]string{"http://config.example/rss"})
+	api, err := store.Add(subscription.Subscription{URL: "http://api.example/rss"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Move("http://config.example/rss", "https://config.example/feed"); err != nil {
+		t.Fatalf("Move error = %v", err)
+	}
+	if err := store.Move(api.URL, "https://api.example/rss"); err != nil {
+		t.Fatalf("Move error = %v", err)
+	}
+	if err := store.Move("http://unknown.example/rss", "https://unknown.example/rss"); !errors.Is(err, subscription.ErrNotFound) {
+		t.Errorf("Move unknown error = %v, want ErrNotFound", err)
+	}
+
+	want := []string{"https://api.example/rss", "https://config.example/feed"}
+	if got := store.URLs(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("URLs after moves = %v, want %v", got, want)
+	}
+	for _, sub := range store.List() {
+		if sub.MovedFrom == "" || sub.ID != feed.ID(sub.MovedFrom) {
+			t.Errorf("moved subscription = %+v, want its original ID and moved_from", sub)
+		}
+	}
+	if _, err := store.Get(api.ID); err != nil {
+		t.Errorf("Get by the ID from Add after a move: %v", err)
+	}
+
+	// A restart with the old URL still in the config polls the new one
+	restored, err := subscription.NewStore(stateFile, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored.SetConfigured([]string{"http://config.example/rss"})
+	if got := restored.URLs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("URLs after restart = %v, want %v", got, want)
+	}
+	if _, err := restored.Add(subscription.Subscription{URL: "http://api.example/rss"}); !errors.Is(err, subscription.ErrExists) {
+		t.Errorf("re-adding the old URL error = %v, want ErrExists", err)
+	}
+
+	// Dead feeds stay listed, aren't polled, and stay dead across reloads
+	if err := restored.MarkDead("https://config.example/feed"); err != nil {
+		t.Fatalf("MarkDead error = %v", err)
+	}
+	restored.SetConfigured([]string{"http://config.example/rss"})
+	if got := restored.URLs(); !reflect.DeepEqual(got, []string{"https://api.example/rss"}) {
+		t.Errorf("URLs with a dead feed = %v", got)
+	}
+	if subs := restored.List(); len(subs) != 2 {
+		t.Errorf("List = %+v, want the dead feed included", subs)
+	}
+}
+
+func TestStoreMoveOntoSubscribedFeed(t *testing.T) {
+	withSettings := &feed.FetchSettings{Headers: map[string]string{"X-Team": "news"}}
+	tests := []struct {
+		name         string
+		movedSet     *feed.FetchSettings
+		existingSet  *feed.FetchSettings
+		wantSettings *feed.FetchSettings
+	}{
+		{"no settings", nil, nil, nil},
+		{"settings carried over", withSettings, nil, withSettings},
+		{"existing settings kept", withSettings, &feed.FetchSettings{Proxy: "http://proxy.example:3128"}, &feed.FetchSettings{Proxy: "http://proxy.example:3128"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := subscription.NewStore("", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			moved, err := store.Add(subscription.Subscription{URL: "http://old.example/rss", Settings: tt.movedSet})
+			if err != nil {
+				t.Fatal(err)
+			}
+			existing, err := store.Add(subscription.Subscription{URL: "https://new.example/rss", Settings: tt.existingSet})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := store.Move(moved.URL, existing.URL); err != nil {
+				t.Fatalf("Move error = %v", err)
+			}
+			subs := store.List()
+			if len(subs) != 1 || subs[0].ID != existing.ID {
+				t.Fatalf("subscriptions = %+v, want only the existing one", subs)
+			}
+			if !reflect.DeepEqual(subs[0].Settings, tt.wantSettings) {
+				t.Errorf("settings = %+v, want %+v", subs[0].Settings, tt.wantSettings)
+			}
+		})
+	}
+}
+
+func TestServiceSubscribe(t *testing.T) {
+	store, err := subscription.NewStore("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.SetConfigured([]string{"https://a.example/rss"})
+
+	reader := &fakeReader{candidates: map[string][]feed.Candidate{
+		"blog.example": {
+			{URL: "https://blog.example/rss.xml", Title: "Posts"},
+			{URL: "https://blog.example/comments.xml", Title: "Comments"},
+		},
+	}}
+	poller := &fakePoller{}
+	svc := subscription.NewService(store, reader, poller)
+
+	sub, candidates, err := svc.Subscribe(context.Background(), "blog.example", nil)
+	if err != nil {
+		t.Fatalf("Subscribe error = %v", err)
+	}
+	if sub.URL != "https://blog.example/rss.xml" || sub.Title != "Posts" || sub.RequestedURL != "blog.example" {
+		t.Errorf("Subscribe = %+v, want the first candidate", sub)
+	}
+	if len(candidates) != 2 {
+		t.Errorf("candidates = %d, want 2", len(candidates))
+	}
+	if !poller.urls["https://a.example/rss"] || !poller.urls[sub.URL] {
+		t.Errorf("polled = %v, want config feed and new subscription", poller.urls)
+	}
+
+	if _, _, err := svc.Subscribe(context.Background(), "blog.example", nil); !errors.Is(err, subscription.ErrExists) {
+		t.Errorf("second Subscribe error = %v, want ErrExists", err)
+	}
+
+	// A reload that drops the config feed stops polling it but keeps ours
+	svc.SetConfigured(nil)
+	if err := svc.Unsubscribe(sub.ID); err != nil {
+		t.Fatalf("Unsubscribe error = %v", err)
+	}
+	if len(poller.urls) != 0 {
+		t.Errorf("polled = %v, want none", poller.urls)
+	}
+	want := []string{"https://a.example/rss", sub.URL}
+	if !reflect.DeepEqual(reader.forgotten, want) {
+		t.Errorf("forgotten = %v, want %v", reader.forgotten, want)
+	}
+}
+
+func TestServiceWatcher(t *testing.T) {
+	store, err := subscription.NewStore("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.SetConfigured([]string{"http://old.example/rss", "https://gone.example/rss"})
+
+	reader := &fakeReader{}
+	poller := &fakePoller{}
+	svc := subscription.NewService(store, reader, poller)
+	svc.SetConfigured([]string{"http://old.example/rss", "https://gone.example/rss"})
+
+	svc.Moved("http://old.example/rss", "https://new.example/rss")
+	svc.Gone("https://gone.example/rss")
+
+	if want := map[string]bool{"https://new.example/rss": true}; !reflect.DeepEqual(poller.urls, want) {
+		t.Errorf("polled = %v, want %v", poller.urls, want)
+	}
+	// The dead feed's status is kept for reporting; the old URL's isn't
+	if want := []string{"http://old.example/rss"}; !reflect.DeepEqual(reader.forgotten, want) {
+		t.Errorf("forgotten = %v, want %v", reader.forgotten, want)
+	}
+}
+
+func TestStoreEncryptsSettings(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "subscriptions.json")
+	key := bytes.Repeat([]byte{7}, 32)
+	settings := &feed.FetchSettings{
+		Auth:    &feed.Auth{Token: "s3cret-token"},
+		Headers: map[string]string{"User-Agent": "Changelog/2.0"},
+	}
+
+	// Credentials are refused rather than written in plaintext
+	plain, err := subscription.NewStore(stateFile, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := plain.Add(subscription.Subscription{URL: "https://a.example/rss", Settings: settings}); !errors.Is(err, subscription.ErrNoSecretKey) {
+		t.Fatalf("Add with credentials and no key error = %v, want ErrNoSecretKey", err)
+	}
+
+	store, err := subscription.NewStore(stateFile, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	added, err := store.Add(subscription.Subscription{URL: "https://a.example/rss", Settings: settings})
+	if err != nil {
+		t.Fatalf("Add error = %v", err)
+	}
+
+	data, err := os.ReadFile(stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("s3cret-token")) || bytes.Contains(data, []byte("Changelog")) {
+		t.Errorf("state file contains settings in plaintext: %s", data)
+	}
+	if redacted := added.Redacted(); redacted.Settings.Auth.Token == "s3cret-token" || settings.Auth.Token != "s3cret-token" {
+		t.Errorf("Redacted = %+v, want the token masked in a copy", redacted.Settings.Auth)
+	}
+
+	restored, err := subscription.NewStore(stateFile, key)
+	if err != nil {
+		t.Fatalf("NewStore with the key error = %v", err)
+	}
+	if got, _ := restored.Get(added.ID); !reflect.DeepEqual(got.Settings, settings) {
+		t.Errorf("restored settings = %+v, want %+v", got.Settings, settings)
+	}
+
+	if _, err := subscription.NewStore(stateFile, bytes.Repeat([]byte{8}, 32)); err == nil {
+		t.Error("NewStore with the wrong key succeeded")
+	}
+	if _, err := subscription.NewStore(stateFile, nil); err == nil {
+		t.Error("NewStore without a key succeeded despite encrypted settings")
+	}
+}
+
+func TestServiceUpdateSettings(t *testing.T) {
+	store, err := subscription.NewStore("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.SetConfigured([]string{"https://a.example/rss"})
+	reader := &fakeReader{candidates: map[string][]feed.Candidate{
+		"https://b.example/rss": {{URL: "https://b.example/rss"}},
+	}}
+	svc := subscription.NewService(store, reader, &fakePoller{})
+
+	sub, _, err := svc.Subscribe(context.Background(), "https://b.example/rss", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	settings := &feed.FetchSettings{Auth: &feed.Auth{Username: "ci", Password: "pw"}}
+	if _, err := svc.UpdateSettings(sub.ID, settings); err != nil {
+		t.Fatalf("UpdateSettings error = %v", err)
+	}
+	if got := reader.settings[sub.URL]; !reflect.DeepEqual(got, *settings) {
+		t.Errorf("reader settings = %+v, want %+v", got, *settings)
+	}
+
+	if _, err := svc.UpdateSettings(sub.ID, &feed.FetchSettings{Proxy: "invalid"}); !errors.Is(err, errInvalidSettings) {
+		t.Errorf("UpdateSettings with invalid settings error = %v", err)
+	}
+	if got, _ := store.Get(sub.ID); !reflect.DeepEqual(got.Settings, settings) {
+		t.Errorf("stored settings after rejected update = %+v, want unchanged", got.Settings)
+	}
+	if _, err := svc.UpdateSettings(feed.ID("https://a.example/rss"), settings); !errors.Is(err, subscription.ErrConfigured) {
+		t.Errorf("UpdateSettings on a config feed error = %v, want ErrConfigured", err)
+	}
+
+	if _, err := svc.UpdateSettings(sub.ID, nil); err != nil {
+		t.Fatalf("clearing settings error = %v", err)
+	}
+	if _, ok := reader.settings[sub.URL]; ok {
+		t.Error("reader still has settings after they were cleared")
+	}
+}
@@ -0,0 +1,62 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+)
+
+// =============================================================================
+// TERMS - Stemming and n-grams for keyword extraction
+// =============================================================================
+
+// Stem reduces an English word, in lower case, to a stem shared by its
+// inflections: "releases", "released" and "releasing" all become
+// "releas". It strips plural, past and progressive endings only, and
+// leaves short words and words with digits alone. Stems aren't always
+// words, so they are for comparing terms rather than showing them.
+func Stem(word string) string {
+	if len([]rune(word)) <= 3 || strings.ContainsFunc(word, func(r rune) bool { return !unicode.IsLetter(r) }) {
+		return word
+	}
+
+	switch {
+	case strings.HasSuffix(word, "sses"):
+		word = word[:len(word)-2]
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		word = word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"), strings.HasSuffix(word, "is"):
+	case strings.HasSuffix(word, "s"):
+		word = word[:len(word)-1]
+	}
+
+	for _, suffix := range []string{"ing", "ed"} {
+		stem, ok := strings.CutSuffix(word, suffix)
+		if !ok || len(stem) < 3 || !strings.ContainsAny(stem, "aeiouy") {
+			continue
+		}
+		word = stem
+		// "stopped" and "stopping" share "stop"
+		if n := len(word); word[n-1] == word[n-2] && !strings.ContainsRune("aeiouls", rune(word[n-1])) {
+			word = word[:n-1]
+		}
+		break
+	}
+
+	// "release" and "releas(ed)" share "releas"
+	if len(word) > 4 && strings.HasSuffix(word, "e") {
+		word = word[:len(word)-1]
+	}
+	return word
+}
+
+// NGrams returns every run of 1 to n consecutive words, each joined by
+// a space, shortest runs first.
+func NGrams(words []string, n int) []string {
+	var grams []string
+	for size := 1; size <= n; size++ {
+		for i := 0; i+size <= len(words); i++ {
+			grams = append(grams, strings.Join(words[i:i+size], " "))
+		}
+	}
+	return grams
+}
@@ -0,0 +1,77 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+)
+
+// =============================================================================
+// TEXT - Tokenising and stopwords shared by the article analysers
+// =============================================================================
+
+// Words splits s into lower-case words of letters and digits. Everything
+// else separates them, except a dot between two digits, so version
+// numbers such as 1.22 stay one word.
+func Words(s string) []string {
+	runes := []rune(strings.ToLower(s))
+	var words []string
+	start := -1
+	for i, r := range runes {
+		inWord := unicode.IsLetter(r) || unicode.IsDigit(r) ||
+			r == '.' && start >= 0 && i+1 < len(runes) && unicode.IsDigit(runes[i-1]) && unicode.IsDigit(runes[i+1])
+		switch {
+		case inWord && start < 0:
+			start = i
+		case !inWord && start >= 0:
+			words = append(words, string(runes[start:i]))
+			start = -1
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// stopwords are frequent short words of each supported language, by ISO
+// 639-1 code. They carry grammar rather than topic.
+var stopwords = map[string][]string{
+	"en": strings.Fields("the and of to in is that for it with as was on are be this by at from or an have not but which you they we has will its"),
+	"fr": strings.Fields("le la les des et est une un du dans que qui pour pas sur au avec ce il elle sont par plus ne se nous vous aux cette"),
+	"de": strings.Fields("der die das und ist nicht ein eine zu den von mit sich des auf für im dem auch es an werden aus er sie wir wird bei"),
+	"es": strings.Fields("el la los las de que y en un una es por con para se no del al lo como más pero sus le ya o este fue"),
+	"it": strings.Fields("il lo la gli le di che e è un una per non con del della si sono da al nel anche come più questo ma"),
+	"pt": strings.Fields("o a os as de que e do da em um uma para com não por se na no mais dos das como mas foi ao ele ela"),
+	"nl": strings.Fields("de het een en van is dat op te in niet zijn met voor er maar om ook als aan bij dit deze wordt door"),
+}
+
+// stopwordLanguages maps each stopword to the languages it belongs to.
+var stopwordLanguages = func() map[string][]string {
+	index := make(map[string][]string)
+	for lang, list := range stopwords {
+		for _, word := range list {
+			index[word] = append(index[word], lang)
+		}
+	}
+	return index
+}()
+
+// StopwordLanguages returns the languages in which word, in lower case,
+// is a stopword. Words shared between languages belong to each of them.
+func StopwordLanguages(word string) []string {
+	return stopwordLanguages[word]
+}
+
+// IsStopword reports whether word, in lower case, is a stopword in any
+// supported language.
+func IsStopword(word string) bool {
+	return len(stopwordLanguages[word]) > 0
+}
+
+// Why one shared vocabulary:
+//
+// - Language detection, near-duplicate fingerprints and trending terms
+//   all tokenise article text; doing it one way means a word is the same
+//   word to each of them.
+// - The stopword lists double as the language model, so they are kept
+//   short and balanced rather than exhaustive.
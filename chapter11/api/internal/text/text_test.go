@@ -0,0 +1,67 @@
+package text_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/text"
+)
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"Go 1.22 released!", []string{"go", "1.22", "released"}},
+		{"Café, crème-brûlée.", []string{"café", "crème", "brûlée"}},
+		{"v1.2. Done...", []string{"v1.2", "done"}},
+		{"...", nil},
+	}
+	for _, tt := range tests {
+		if got := text.Words(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("Words(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		words []string
+		want  string
+	}{
+		{[]string{"release", "releases", "released", "releasing"}, "releas"},
+		{[]string{"stop", "stops", "stopped", "stopping"}, "stop"},
+		{[]string{"library", "libraries"}, "library"},
+		{[]string{"iterator", "iterators"}, "iterator"},
+		{[]string{"class", "classes"}, "class"},
+		{[]string{"status"}, "status"},
+		{[]string{"go", "k8s", "1.22"}, ""},
+	}
+	for _, tt := range tests {
+		for _, word := range tt.words {
+			want := tt.want
+			if want == "" {
+				want = word
+			}
+			if got := text.Stem(word); got != want {
+				t.Errorf("Stem(%q) = %q, want %q", word, got, want)
+			}
+		}
+	}
+}
+
+func TestNGrams(t *testing.T) {
+	got := text.NGrams([]string{"go", "1.22", "release"}, 2)
+	want := []string{"go", "1.22", "release", "go 1.22", "1.22 release"}
+	if !slices.Equal(got, want) {
+		t.Errorf("NGrams = %q, want %q", got, want)
+	}
+}
+
+func TestIsStopword(t *testing.T) {
+	for word, want := range map[string]bool{"the": true, "und": true, "golang": false, "The": false} {
+		if got := text.IsStopword(word); got != want {
+			t.Errorf("IsStopword(%q) = %v, want %v", word, got, want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package trends
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/text"
+)
+
+// =============================================================================
+// TERMS - Keyword and phrase extraction from articles
+// =============================================================================
+
+// maxPhraseWords is the longest phrase extracted, in words.
+const maxPhraseWords = 3
+
+// Terms returns the keywords and phrases of an article's title and
+// description, keyed by their stemmed form with the text they appeared
+// as. Phrases are runs of up to three words uninterrupted by stopwords
+// or punctuation, so "the release of Go 1.22" yields "release" and
+// "go 1.22" but never "release go".
+func Terms(article *feed.Article) map[string]string {
+	terms := make(map[string]string)
+	for _, run := range runs(article.Title + "\n" + article.Description) {
+		stems := make([]string, len(run))
+		for i, word := range run {
+			stems[i] = text.Stem(word)
+		}
+		keys, surfaces := text.NGrams(stems, maxPhraseWords), text.NGrams(run, maxPhraseWords)
+		for i, key := range keys {
+			if !strings.Contains(key, " ") && !isKeyword(key) {
+				continue
+			}
+			if _, seen := terms[key]; !seen {
+				terms[key] = surfaces[i]
+			}
+		}
+	}
+	return terms
+}
+
+// runs splits s into runs of consecutive non-stopword words. Stopwords
+// and punctuation between clauses end a run.
+func runs(s string) [][]string {
+	var result [][]string
+	for _, clause := range clauses(s) {
+		var run []string
+		for _, word := range text.Words(clause) {
+			if text.IsStopword(word) {
+				result = appendRun(result, run)
+				run = nil
+				continue
+			}
+			run = append(run, word)
+		}
+		result = appendRun(result, run)
+	}
+	return result
+}
+
+func appendRun(runs [][]string, run []string) [][]string {
+	if len(run) == 0 {
+		return runs
+	}
+	return append(runs, run)
+}
+
+// clauses splits s at sentence and clause punctuation. A full stop
+// within a number, such as in "1.22", doesn't split.
+func clauses(s string) []string {
+	runes := []rune(s)
+	var result []string
+	start := 0
+	for i, r := range runes {
+		isBreak := strings.ContainsRune(",;:!?()[]\"|\n—–", r)
+		if r == '.' {
+			isBreak = i == 0 || i+1 == len(runes) || !unicode.IsDigit(runes[i-1]) || !unicode.IsDigit(runes[i+1])
+		}
+		if isBreak {
+			result = append(result, string(runes[start:i]))
+			start = i + 1
+		}
+	}
+	return append(result, string(runes[start:]))
+}
+
+// isKeyword reports whether a single word is worth reporting on its own:
+// numbers and single letters aren't topics.
+func isKeyword(word string) bool {
+	if len([]rune(word)) < 2 {
+		return false
+	}
+	return strings.ContainsFunc(word, unicode.IsLetter)
+}
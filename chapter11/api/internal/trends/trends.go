@@ -0,0 +1,201 @@
+package trends
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+)
+
+// =============================================================================
+// TRENDS - Terms rising above their rolling baseline
+// =============================================================================
+
+const (
+	// BaselineWindows is how many windows before the current one form the
+	// baseline it is compared against.
+	BaselineWindows = 7
+
+	// MinArticles is how many articles in the window must mention a term
+	// before it can trend, so one article's vocabulary doesn't.
+	MinArticles = 2
+
+	// MinBurst is how many times more often than in the baseline a term
+	// must be mentioned to count as rising.
+	MinBurst = 2.0
+
+	// maxSupporting bounds the articles listed for each trend.
+	maxSupporting = 5
+)
+
+// Trend is a term mentioned markedly more in the window than before.
+type Trend struct {
+	Term             string          `json:"term"`              // As most often written, in lower case
+	Articles         int             `json:"articles"`          // Articles in the window mentioning it
+	BaselineArticles int             `json:"baseline_articles"` // Articles in the baseline mentioning it
+	Burst            float64         `json:"burst"`             // How many times its baseline share of articles it has now
+	Score            float64         `json:"score"`             // Rise in TF-IDF weight; trends are ordered by it
+	Supporting       []*feed.Article `json:"supporting"`        // Newest first
+}
+
+// Report is the trending terms of one window.
+type Report struct {
+	Window           string    `json:"window"`
+	From             time.Time `json:"from"`
+	To               time.Time `json:"to"`
+	Articles         int       `json:"articles"`          // Articles published in the window
+	BaselineArticles int       `json:"baseline_articles"` // Articles published in the baseline before it
+	Trends           []Trend   `json:"trends"`
+}
+
+// termStats counts one term's mentions.
+type termStats struct {
+	articles   []*feed.Article // In the window
+	surfaces   map[string]int  // How often each written form appeared in the window
+	baselineDF int
+}
+
+// Detect reports up to limit terms rising in the window ending at now,
+// compared with the BaselineWindows windows before it. Articles without
+// a publication date, or outside both, are ignored.
+//
+// Each term is weighted by TF-IDF, where its term frequency is the share
+// of a period's articles mentioning it and its inverse document
+// frequency is taken over both periods, so words common to every period
+// weigh little. A term trends when it is mentioned by at least
+// MinArticles articles and at least MinBurst times its baseline share,
+// and trends are ordered by how much its weight rose.
+func Detect(articles []*feed.Article, now time.Time, window time.Duration, limit int) Report {
+	from := now.Add(-window)
+	baselineFrom := from.Add(-BaselineWindows * window)
+	report := Report{Window: window.String(), From: from, To: now, Trends: []Trend{}}
+
+	stats := make(map[string]*termStats)
+	for _, article := range articles {
+		if article.Published == nil || !article.Published.After(baselineFrom) {
+			continue
+		}
+		inWindow := article.Published.After(from)
+		if inWindow {
+			report.Articles++
+		} else {
+			report.BaselineArticles++
+		}
+
+		for key, surface := range Terms(article) {
+			s := stats[key]
+			if s == nil {
+				s = &termStats{surfaces: make(map[string]int)}
+				stats[key] = s
+			}
+			if inWindow {
+				s.articles = append(s.articles, article)
+				s.surfaces[surface]++
+			} else {
+				s.baselineDF++
+			}
+		}
+	}
+	if report.Articles == 0 {
+		return report
+	}
+
+	total := float64(report.Articles + report.BaselineArticles)
+	var candidates []Trend
+	for _, s := range stats {
+		df := len(s.articles)
+		if df < MinArticles {
+			continue
+		}
+		// Smoothing credits every term with one mention across both
+		// periods, so terms new to the baseline have a finite burst and,
+		// with no baseline yet, the most mentioned terms trend
+		share := float64(df) / float64(report.Articles)
+		baselineShare := float64(s.baselineDF+1) / total
+		burst := share / baselineShare
+		if burst < MinBurst {
+			continue
+		}
+		idf := math.Log(1 + total/float64(df+s.baselineDF))
+
+		candidates = append(candidates, Trend{
+			Term:             mostCommon(s.surfaces),
+			Articles:         df,
+			BaselineArticles: s.baselineDF,
+			Burst:            round(burst),
+			Score:            round((share - baselineShare) * idf),
+			Supporting:       s.articles,
+		})
+	}
+
+	// Highest score first; of equally scored terms, the shorter phrase
+	slices.SortFunc(candidates, func(a, b Trend) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(strings.Count(a.Term, " "), strings.Count(b.Term, " ")),
+			strings.Compare(a.Term, b.Term),
+		)
+	})
+	for _, candidate := range candidates {
+		if len(report.Trends) == limit {
+			break
+		}
+		if slices.ContainsFunc(report.Trends, func(t Trend) bool { return redundant(t, candidate) }) {
+			continue
+		}
+		report.Trends = append(report.Trends, withSupporting(candidate))
+	}
+	return report
+}
+
+// redundant reports whether a and b are a phrase and a part of it
+// mentioned by the same articles, such as "go 1.22" and "go 1.22
+// released" when every article saying one says the other.
+func redundant(a, b Trend) bool {
+	if a.Articles != b.Articles {
+		return false
+	}
+	padded := func(s string) string { return " " + s + " " }
+	return strings.Contains(padded(a.Term), padded(b.Term)) || strings.Contains(padded(b.Term), padded(a.Term))
+}
+
+// withSupporting keeps the newest of t's supporting articles.
+func withSupporting(t Trend) Trend {
+	supporting := slices.Clone(t.Supporting)
+	slices.SortStableFunc(supporting, func(a, b *feed.Article) int {
+		return b.Published.Compare(*a.Published)
+	})
+	t.Supporting = supporting[:min(len(supporting), maxSupporting)]
+	return t
+}
+
+// mostCommon returns the most frequent surface form, the first
+// alphabetically on ties.
+func mostCommon(surfaces map[string]int) string {
+	best := ""
+	for surface, n := range surfaces {
+		if n > surfaces[best] || n == surfaces[best] && surface < best {
+			best = surface
+		}
+	}
+	return best
+}
+
+// round keeps two decimals, which is all the ranking needs to show.
+func round(f float64) float64 {
+	return math.Round(f*100) / 100
+}
+
+// Why bursts against a rolling baseline:
+//
+// - Frequent terms aren't news: "go" is in most articles of a Go feed
+//   every day. Comparing each term's share of articles with its share
+//   over the preceding week surfaces what changed instead.
+// - Counting articles rather than occurrences stops one long post that
+//   repeats a word from making it trend.
+// - The baseline is recomputed from stored articles on every request, so
+//   there is no model to keep in step with the store; the window scan is
+//   linear in the articles it covers.
@@ -0,0 +1,107 @@
+package trends_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/trends"
+)
+
+var now = time.Date(2024, 8, 14, 12, 0, 0, 0, time.UTC)
+
+// article returns an article published ago before now.
+func article(title string, ago time.Duration) *feed.Article {
+	published := now.Add(-ago)
+	return &feed.Article{Title: title, Link: "https://example.com/" + title, Published: &published}
+}
+
+func TestTerms(t *testing.T) {
+	tests := []struct {
+		title string
+		want  []string // Surface forms
+	}{
+		{"Go 1.23 released", []string{"go", "released", "go 1.23", "1.23 released", "go 1.23 released"}},
+		{"The release of the compiler", []string{"release", "compiler"}},
+		{"Fast builds. Slow tests", []string{"fast", "builds", "fast builds", "slow", "tests", "slow tests"}},
+		{"A 2024 recap", []string{"recap", "2024 recap"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			var got []string
+			for _, surface := range trends.Terms(&feed.Article{Title: tt.title}) {
+				got = append(got, surface)
+			}
+			slices.Sort(got)
+			slices.Sort(tt.want)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Terms = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTermsStemsKeys(t *testing.T) {
+	a := trends.Terms(&feed.Article{Title: "Releasing iterators"})
+	b := trends.Terms(&feed.Article{Title: "Iterator released"})
+	if a["releas"] != "releasing" || b["releas"] != "released" || a["iterator"] == "" || b["iterator"] == "" {
+		t.Errorf("Terms = %v and %v, want shared stems", a, b)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	var articles []*feed.Article
+	// The baseline week: a steady stream of generic Go articles
+	for i := range 20 {
+		articles = append(articles, article(fmt.Sprintf("Go tips part %d", i), time.Duration(30+i*7)*time.Hour))
+	}
+	// Today: the release, covered three times, next to the usual fare
+	articles = append(articles,
+		article("Go 1.23 released with iterators", 1*time.Hour),
+		article("Go 1.23 is out: iterators arrive", 2*time.Hour),
+		article("Go tips part 21", 3*time.Hour),
+		article("Hands-on with Go 1.23 iterators", 4*time.Hour),
+		article("Undated", 0),
+	)
+	articles[len(articles)-1].Published = nil
+
+	report := trends.Detect(articles, now, 24*time.Hour, 10)
+	if report.Articles != 4 || report.BaselineArticles != 20 {
+		t.Fatalf("counted %d window and %d baseline articles, want 4 and 20", report.Articles, report.BaselineArticles)
+	}
+
+	var terms []string
+	for _, trend := range report.Trends {
+		terms = append(terms, trend.Term)
+	}
+	if !slices.Equal(terms, []string{"iterators", "go 1.23"}) {
+		t.Fatalf("trends = %q, want the release and its feature", terms)
+	}
+
+	top := report.Trends[0]
+	if top.Articles != 3 || top.BaselineArticles != 0 || top.Burst < trends.MinBurst || top.Score <= 0 {
+		t.Errorf("top trend = %+v", top)
+	}
+	if len(top.Supporting) != 3 || top.Supporting[0] != articles[20] || top.Supporting[2] != articles[23] {
+		t.Errorf("supporting = %v, want the three release articles newest first", top.Supporting)
+	}
+}
+
+func TestDetectWithoutArticles(t *testing.T) {
+	report := trends.Detect(nil, now, time.Hour, 10)
+	if report.Trends == nil || len(report.Trends) != 0 || report.Window != "1h0m0s" {
+		t.Errorf("report = %+v, want an empty list of trends", report)
+	}
+}
+
+func TestDetectLimit(t *testing.T) {
+	var articles []*feed.Article
+	for _, topic := range []string{"alpha", "beta", "gamma"} {
+		articles = append(articles, article(topic+" one", time.Hour), article(topic+" two", 2*time.Hour))
+	}
+	if report := trends.Detect(articles, now, 24*time.Hour, 2); len(report.Trends) != 2 {
+		t.Errorf("got %d trends, want the limit of 2", len(report.Trends))
+	}
+}
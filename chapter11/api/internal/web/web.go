@@ -0,0 +1,389 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/reader"
+	"github.com/YOUR_USERNAME/go-news/api/internal/subscription"
+	"github.com/YOUR_USERNAME/go-news/newsroom"
+)
+
+// =============================================================================
+// WEB UI - Server-rendered pages for browsing articles and feeds
+// =============================================================================
+
+//go:embed templates static
+var files embed.FS
+
+// Prefix is the path the UI is served under.
+const Prefix = "/ui/"
+
+const (
+	// pageSize is how many articles a list page shows.
+	pageSize = 20
+
+	// articleScan is how many recent articles are searched when
+	// filtering, paginating or looking one up.
+	articleScan = 5000
+
+	// defaultSummaryCount is how many articles a summary covers unless
+	// ?count= says otherwise.
+	defaultSummaryCount = 5
+
+	// maxSummaryCount bounds ?count= on the summary page, which waits
+	// for the summarizer.
+	maxSummaryCount = 50
+)
+
+// ArticleReader provides recent articles, newest first.
+type ArticleReader interface {
+	GetRecent(n int) []*feed.Article
+}
+
+// Summarizer writes a news report about articles.
+type Summarizer interface {
+	Summarize(ctx context.Context, articles []newsroom.Article) (string, error)
+}
+
+// Subscriptions lists and changes the polled feeds.
+type Subscriptions interface {
+	List() []subscription.Subscription
+	Subscribe(ctx context.Context, url string, settings *feed.FetchSettings) (subscription.Subscription, []feed.Candidate, error)
+	Unsubscribe(id string) error
+}
+
+// UI serves the HTML pages.
+type UI struct {
+	articles      ArticleReader
+	summarizer    Summarizer
+	subscriptions Subscriptions
+	pages         map[string]*template.Template
+}
+
+// New creates the UI, parsing its embedded templates.
+func New(articles ArticleReader, summarizer Summarizer, subscriptions Subscriptions) (*UI, error) {
+	funcs := template.FuncMap{
+		"date":      formatDate,
+		"articleID": feed.ArticleID,
+	}
+	pages := make(map[string]*template.Template)
+	for _, page := range []string{"articles", "article", "summary", "feeds", "error"} {
+		t, err := template.New("layout.html").Funcs(funcs).ParseFS(files, "templates/layout.html", "templates/"+page+".html")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s template: %w", page, err)
+		}
+		pages[page] = t
+	}
+	return &UI{articles: articles, summarizer: summarizer, subscriptions: subscriptions, pages: pages}, nil
+}
+
+// RegisterRoutes mounts the UI pages and their stylesheet on mux.
+func (ui *UI) RegisterRoutes(mux *http.ServeMux) {
+	static, _ := fs.Sub(files, "static")
+	mux.Handle("GET "+Prefix+"static/", http.StripPrefix(Prefix+"static/", http.FileServerFS(static)))
+	mux.HandleFunc("GET "+Prefix+"{$}", ui.articlesPage)
+	mux.HandleFunc("GET "+Prefix+"articles/{id}", ui.articlePage)
+	mux.HandleFunc("GET "+Prefix+"summary", ui.summaryPage)
+	mux.HandleFunc("GET "+Prefix+"feeds", ui.feedsPage)
+	mux.HandleFunc("POST "+Prefix+"feeds", ui.subscribe)
+	mux.HandleFunc("POST "+Prefix+"feeds/{id}/delete", ui.unsubscribe)
+	mux.Handle("GET /ui", http.RedirectHandler(Prefix, http.StatusMovedPermanently))
+}
+
+// WithErrorPages answers requests no route of mux matches with the error
+// page, where ServeMux would write plain-text 404 and 405 responses.
+func (ui *UI) WithErrorPages(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, pattern := mux.Handler(r); pattern == "" {
+			w = &unmatchedWriter{ResponseWriter: w, ui: ui, r: r}
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// unmatchedWriter replaces ServeMux's error response with the error page.
+type unmatchedWriter struct {
+	http.ResponseWriter
+	ui      *UI
+	r       *http.Request
+	written bool
+}
+
+func (u *unmatchedWriter) WriteHeader(code int) {
+	if u.written {
+		return
+	}
+	u.written = true
+	if code == http.StatusMethodNotAllowed {
+		u.ui.renderError(u.ResponseWriter, code, "Method not allowed", u.r.Method+" is not allowed on this page.")
+		return
+	}
+	u.ui.renderError(u.ResponseWriter, http.StatusNotFound, "Page not found", "There is nothing at "+u.r.URL.Path+".")
+}
+
+// Write discards ServeMux's plain-text message.
+func (u *unmatchedWriter) Write(p []byte) (int, error) {
+	u.WriteHeader(http.StatusNotFound)
+	return len(p), nil
+}
+
+// =============================================================================
+// ARTICLES
+// =============================================================================
+
+// articlesPage lists recent articles, newest first. Supports ?feed=NAME
+// to show one feed and ?page=N.
+func (ui *UI) articlesPage(w http.ResponseWriter, r *http.Request) {
+	selected := r.URL.Query().Get("feed")
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	recent := ui.articles.GetRecent(articleScan)
+	var feeds []string
+	var matching []*feed.Article
+	for _, article := range recent {
+		if article.FeedTitle != "" && !slices.Contains(feeds, article.FeedTitle) {
+			feeds = append(feeds, article.FeedTitle)
+		}
+		if selected == "" || article.FeedTitle == selected {
+			matching = append(matching, article)
+		}
+	}
+	slices.Sort(feeds)
+
+	start := min((page-1)*pageSize, len(matching))
+	end := min(start+pageSize, len(matching))
+	data := map[string]any{
+		"Title":    "Articles",
+		"Articles": matching[start:end],
+		"Feeds":    feeds,
+		"Selected": selected,
+		"Page":     page,
+	}
+	if page > 1 {
+		data["Prev"] = pageURL(selected, page-1)
+	}
+	if end < len(matching) {
+		data["Next"] = pageURL(selected, page+1)
+	}
+	ui.render(w, http.StatusOK, "articles", data)
+}
+
+// pageURL links to one page of the article list.
+func pageURL(feedTitle string, page int) string {
+	q := url.Values{}
+	if feedTitle != "" {
+		q.Set("feed", feedTitle)
+	}
+	if page > 1 {
+		q.Set("page", strconv.Itoa(page))
+	}
+	if len(q) == 0 {
+		return Prefix
+	}
+	return Prefix + "?" + q.Encode()
+}
+
+// articlePage shows one article. Its text was reduced to plain text on
+// ingest and is escaped again here, so feed markup never reaches the page.
+func (ui *UI) articlePage(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	for _, article := range ui.articles.GetRecent(articleScan) {
+		if feed.ArticleID(article) == id {
+			ui.render(w, http.StatusOK, "article", map[string]any{"Title": article.Title, "Article": article})
+			return
+		}
+	}
+	ui.renderError(w, http.StatusNotFound, "Article not found", "It may have aged out of the store.")
+}
+
+// =============================================================================
+// SUMMARY
+// =============================================================================
+
+// summaryPage shows a news report about the most recent articles.
+// Supports ?count=N.
+func (ui *UI) summaryPage(w http.ResponseWriter, r *http.Request) {
+	n := defaultSummaryCount
+	if count, err := strconv.Atoi(r.URL.Query().Get("count")); err == nil && count > 0 {
+		n = min(count, maxSummaryCount)
+	}
+
+	articles := ui.articles.GetRecent(n)
+	if len(articles) == 0 {
+		ui.renderError(w, http.StatusNotFound, "No articles yet", "The report is written once feeds have been fetched.")
+		return
+	}
+	newsroomArticles := make([]newsroom.Article, len(articles))
+	for i, article := range articles {
+		newsroomArticles[i] = newsroom.Article{
+			Title:       article.Title,
+			Description: article.Description,
+			Link:        article.Link,
+			FeedTitle:   article.FeedTitle,
+		}
+	}
+
+	summary, err := ui.summarizer.Summarize(r.Context(), newsroomArticles)
+	if err != nil {
+		slog.Warn("ui summary failed", "error", err)
+		ui.renderError(w, http.StatusBadGateway, "The summary couldn't be written", "The summarizer failed; try again later.")
+		return
+	}
+	ui.render(w, http.StatusOK, "summary", map[string]any{
+		"Title":    "Summary",
+		"Summary":  summary,
+		"Articles": articles,
+		"Count":    n,
+	})
+}
+
+// =============================================================================
+// FEEDS
+// =============================================================================
+
+// feedsPage lists the subscriptions with forms to add and remove them.
+func (ui *UI) feedsPage(w http.ResponseWriter, r *http.Request) {
+	var notice string
+	switch q := r.URL.Query(); {
+	case q.Has("subscribed"):
+		notice = "Subscribed to " + q.Get("subscribed") + "."
+	case q.Has("removed"):
+		notice = "Feed removed."
+	}
+	ui.renderFeeds(w, http.StatusOK, map[string]any{"Notice": notice})
+}
+
+func (ui *UI) renderFeeds(w http.ResponseWriter, status int, data map[string]any) {
+	data["Title"] = "Feeds"
+	data["Subscriptions"] = ui.subscriptions.List()
+	data["SourceAPI"] = subscription.SourceAPI
+	ui.render(w, status, "feeds", data)
+}
+
+// subscribe adds the feed at, or discovered from, the posted URL and
+// redirects back to the feed list.
+func (ui *UI) subscribe(w http.ResponseWriter, r *http.Request) {
+	if !sameOrigin(r) {
+		ui.renderError(w, http.StatusForbidden, "Request refused", "Forms can only be submitted from this site.")
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, 64<<10)
+	rawURL := strings.TrimSpace(r.PostFormValue("url"))
+
+	sub, _, err := ui.subscriptions.Subscribe(r.Context(), rawURL, nil)
+	if err != nil {
+		ui.renderFeeds(w, subscribeStatus(err), map[string]any{"Error": err.Error(), "URL": rawURL})
+		return
+	}
+	name := sub.Title
+	if name == "" {
+		name = sub.URL
+	}
+	http.Redirect(w, r, Prefix+"feeds?subscribed="+url.QueryEscape(name), http.StatusSeeOther)
+}
+
+// unsubscribe removes a feed added through the API or the UI. Forms
+// can't send DELETE, so this is a POST.
+func (ui *UI) unsubscribe(w http.ResponseWriter, r *http.Request) {
+	if !sameOrigin(r) {
+		ui.renderError(w, http.StatusForbidden, "Request refused", "Forms can only be submitted from this site.")
+		return
+	}
+	err := ui.subscriptions.Unsubscribe(r.PathValue("id"))
+	switch {
+	case errors.Is(err, subscription.ErrNotFound):
+		ui.renderError(w, http.StatusNotFound, "Feed not found", "It may already have been removed.")
+	case err != nil:
+		ui.renderFeeds(w, subscribeStatus(err), map[string]any{"Error": err.Error()})
+	default:
+		http.Redirect(w, r, Prefix+"feeds?removed=1", http.StatusSeeOther)
+	}
+}
+
+// subscribeStatus maps subscription failures to status codes like the
+// JSON API does.
+func subscribeStatus(err error) int {
+	switch {
+	case errors.Is(err, subscription.ErrExists), errors.Is(err, subscription.ErrConfigured):
+		return http.StatusConflict
+	case errors.Is(err, reader.ErrInvalidURL), errors.Is(err, reader.ErrInvalidSettings):
+		return http.StatusBadRequest
+	case errors.Is(err, reader.ErrNoFeedFound), errors.Is(err, reader.ErrDisallowed):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+// sameOrigin reports whether a form was submitted from this site, using
+// the headers browsers add to cross-site requests. Requests without them,
+// such as from curl, are allowed.
+func sameOrigin(r *http.Request) bool {
+	switch r.Header.Get("Sec-Fetch-Site") {
+	case "", "same-origin", "none":
+	default:
+		return false
+	}
+	if origin := r.Header.Get("Origin"); origin != "" {
+		u, err := url.Parse(origin)
+		return err == nil && u.Host == r.Host
+	}
+	return true
+}
+
+// =============================================================================
+// RENDERING
+// =============================================================================
+
+// render executes a page into a buffer first, so a template error
+// becomes a 500 rather than half a page.
+func (ui *UI) render(w http.ResponseWriter, status int, page string, data map[string]any) {
+	var buf bytes.Buffer
+	if err := ui.pages[page].Execute(&buf, data); err != nil {
+		slog.Error("ui template failed", "page", page, "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", "default-src 'self'; script-src 'none'")
+	w.WriteHeader(status)
+	buf.WriteTo(w)
+}
+
+func (ui *UI) renderError(w http.ResponseWriter, status int, title, detail string) {
+	ui.render(w, status, "error", map[string]any{"Title": title, "Detail": detail})
+}
+
+// formatDate shows when an article was published.
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format("2 Jan 2006 15:04 UTC")
+}
+
+// Why server-rendered templates:
+//
+// - Every page is plain HTML with links and forms, so the UI works with
+//   JavaScript disabled and needs no build step or frontend dependencies.
+// - Templates and the stylesheet are embedded, so the binary stays the
+//   only thing to deploy.
+// - html/template escapes titles and descriptions by context and refuses
+//   unsafe link schemes, on top of the sanitising done on ingest.
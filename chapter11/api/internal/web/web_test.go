@@ -0,0 +1,257 @@
+package web_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/subscription"
+	"github.com/YOUR_USERNAME/go-news/api/internal/web"
+	"github.com/YOUR_USERNAME/go-news/newsroom"
+)
+
+type fakeArticles []*feed.Article
+
+func (f fakeArticles) GetRecent(n int) []*feed.Article {
+	return f[:min(n, len(f))]
+}
+
+type fakeSummarizer struct{ err error }
+
+func (s fakeSummarizer) Summarize(ctx context.Context, articles []newsroom.Article) (string, error) {
+	return fmt.Sprintf("Report on %d articles", len(articles)), s.err
+}
+
+type fakeSubscriptions struct {
+	subs []subscription.Subscription
+}
+
+func (f *fakeSubscriptions) List() []subscription.Subscription { return f.subs }
+
+func (f *fakeSubscriptions) Subscribe(ctx context.Context, rawURL string, settings *feed.FetchSettings) (subscription.Subscription, []feed.Candidate, error) {
+	for _, sub := range f.subs {
+		if sub.URL == rawURL {
+			return subscription.Subscription{}, nil, subscription.ErrExists
+		}
+	}
+	sub := subscription.Subscription{ID: feed.ID(rawURL), URL: rawURL, Source: subscription.SourceAPI}
+	f.subs = append(f.subs, sub)
+	return sub, nil, nil
+}
+
+func (f *fakeSubscriptions) Unsubscribe(id string) error {
+	for i, sub := range f.subs {
+		if sub.ID == id {
+			f.subs = append(f.subs[:i], f.subs[i+1:]...)
+			return nil
+		}
+	}
+	return subscription.ErrNotFound
+}
+
+// newUI serves 45 articles alternating between two feeds, the first with
+// markup in its title.
+func newUI(t *testing.T, summarizer web.Summarizer) (http.Handler, *fakeSubscriptions) {
+	t.Helper()
+	var articles fakeArticles
+	for i := range 45 {
+		published := time.Date(2024, 8, 13, 0, 0, 0, 0, time.UTC).Add(-time.Duration(i) * time.Hour)
+		articles = append(articles, &feed.Article{
+			Title:       fmt.Sprintf("Article %d", i),
+			Description: "First paragraph.\nSecond paragraph.",
+			Link:        fmt.Sprintf("https://example.com/%d", i),
+			FeedTitle:   []string{"Go Blog", "golang"}[i%2],
+			Published:   &published,
+		})
+	}
+	articles[0].Title = `<script>alert("x")</script> Go 1.23`
+	articles[1].Link = "javascript:alert(1)"
+
+	subs := &fakeSubscriptions{subs: []subscription.Subscription{
+		{ID: "cfg", URL: "https://go.dev/blog/feed.atom", Source: subscription.SourceConfig},
+	}}
+	ui, err := web.New(articles, summarizer, subs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	ui.RegisterRoutes(mux)
+	return ui.WithErrorPages(mux), subs
+}
+
+func get(mux http.Handler, target string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+func TestArticlesPage(t *testing.T) {
+	mux, _ := newUI(t, fakeSummarizer{})
+	tests := []struct {
+		target   string
+		want     []string
+		dontWant []string
+	}{
+		{"/ui/", []string{"Article 19", `href="/ui/?page=2"`, `<option value="golang">`}, []string{"Article 20", "Newer"}},
+		{"/ui/?page=3", []string{"Article 44", `href="/ui/?page=2"`}, []string{"Article 39", "Older"}},
+		{"/ui/?feed=golang", []string{"Article 39", `<option value="golang" selected>`}, []string{"Article 2<", "Article 41"}},
+		{"/ui/?feed=golang&page=2", []string{"Article 41", `href="/ui/?feed=golang"`}, []string{"Article 39"}},
+		{"/ui/?feed=nobody", []string{"No articles from nobody yet."}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			rec := get(mux, tt.target)
+			body := rec.Body.String()
+			if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/html") {
+				t.Fatalf("status %d, content type %q", rec.Code, rec.Header().Get("Content-Type"))
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("page lacks %q", want)
+				}
+			}
+			for _, dontWant := range tt.dontWant {
+				if strings.Contains(body, dontWant) {
+					t.Errorf("page contains %q", dontWant)
+				}
+			}
+		})
+	}
+}
+
+func TestPagesNeedNoScript(t *testing.T) {
+	mux, _ := newUI(t, fakeSummarizer{})
+	for _, target := range []string{"/ui/", "/ui/summary", "/ui/feeds", "/ui/articles/" + feed.ID("https://example.com/0")} {
+		rec := get(mux, target)
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s: status %d", target, rec.Code)
+		}
+		if strings.Contains(rec.Body.String(), "<script") {
+			t.Errorf("%s: page contains a script element", target)
+		}
+	}
+}
+
+func TestArticlePage(t *testing.T) {
+	mux, _ := newUI(t, fakeSummarizer{})
+
+	rec := get(mux, "/ui/articles/"+feed.ID("https://example.com/0"))
+	body := rec.Body.String()
+	if !strings.Contains(body, "&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; Go 1.23") {
+		t.Errorf("title not escaped:\n%s", body)
+	}
+	if !strings.Contains(body, `href="https://example.com/0"`) || !strings.Contains(body, "First paragraph.\nSecond paragraph.") {
+		t.Errorf("article page lacks link or description:\n%s", body)
+	}
+
+	rec = get(mux, "/ui/articles/"+feed.ID("javascript:alert(1)"))
+	if strings.Contains(rec.Body.String(), "javascript:") {
+		t.Errorf("unsafe link rendered:\n%s", rec.Body)
+	}
+
+	if rec := get(mux, "/ui/articles/unknown"); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown article: status %d, want 404", rec.Code)
+	}
+}
+
+func TestSummaryPage(t *testing.T) {
+	mux, _ := newUI(t, fakeSummarizer{})
+	if rec := get(mux, "/ui/summary?count=3"); !strings.Contains(rec.Body.String(), "Report on 3 articles") {
+		t.Errorf("summary page = %d %s", rec.Code, rec.Body)
+	}
+
+	mux, _ = newUI(t, fakeSummarizer{err: errors.New("ollama down")})
+	if rec := get(mux, "/ui/summary"); rec.Code != http.StatusBadGateway || strings.Contains(rec.Body.String(), "ollama") {
+		t.Errorf("failed summary = %d %s", rec.Code, rec.Body)
+	}
+}
+
+func TestFeedsPage(t *testing.T) {
+	mux, subs := newUI(t, fakeSummarizer{})
+	post := func(target string, form url.Values, origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Config feeds can't be removed from the UI
+	if body := get(mux, "/ui/feeds").Body.String(); strings.Contains(body, "Remove") || !strings.Contains(body, "edit the config file") {
+		t.Errorf("feeds page:\n%s", body)
+	}
+
+	// Subscribe, then see it listed with a remove button
+	rec := post("/ui/feeds", url.Values{"url": {"https://example.com/feed"}}, "http://example.com")
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/ui/feeds?subscribed=https%3A%2F%2Fexample.com%2Ffeed" {
+		t.Fatalf("subscribe = %d %s", rec.Code, rec.Header().Get("Location"))
+	}
+	body := get(mux, rec.Header().Get("Location")).Body.String()
+	if !strings.Contains(body, "Subscribed to https://example.com/feed.") || !strings.Contains(body, `action="/ui/feeds/`+feed.ID("https://example.com/feed")+`/delete"`) {
+		t.Errorf("feeds page after subscribing:\n%s", body)
+	}
+
+	// Subscribing twice shows the error and keeps what was typed
+	rec = post("/ui/feeds", url.Values{"url": {"https://example.com/feed"}}, "")
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), `value="https://example.com/feed"`) {
+		t.Errorf("duplicate subscribe = %d\n%s", rec.Code, rec.Body)
+	}
+
+	// Forms posted from another site are refused
+	rec = post("/ui/feeds/"+feed.ID("https://example.com/feed")+"/delete", nil, "https://evil.example")
+	if rec.Code != http.StatusForbidden || len(subs.subs) != 2 {
+		t.Errorf("cross-site remove = %d, %d subscriptions left", rec.Code, len(subs.subs))
+	}
+
+	rec = post("/ui/feeds/"+feed.ID("https://example.com/feed")+"/delete", nil, "")
+	if rec.Code != http.StatusSeeOther || len(subs.subs) != 1 {
+		t.Errorf("remove = %d, %d subscriptions left", rec.Code, len(subs.subs))
+	}
+	if rec := post("/ui/feeds/unknown/delete", nil, ""); rec.Code != http.StatusNotFound {
+		t.Errorf("remove unknown = %d, want 404", rec.Code)
+	}
+}
+
+func TestStylesheet(t *testing.T) {
+	mux, _ := newUI(t, fakeSummarizer{})
+	rec := get(mux, "/ui/static/style.css")
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/css") {
+		t.Errorf("stylesheet = %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+}
+
+func TestUnmatchedPages(t *testing.T) {
+	mux, _ := newUI(t, fakeSummarizer{})
+	tests := []struct {
+		method, target string
+		wantCode       int
+		wantTitle      string
+	}{
+		{http.MethodGet, "/ui/nowhere", http.StatusNotFound, "Page not found"},
+		{http.MethodGet, "/ui/feeds/cfg", http.StatusNotFound, "Page not found"},
+		{http.MethodDelete, "/ui/feeds", http.StatusMethodNotAllowed, "Method not allowed"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, nil))
+		if rec.Code != tt.wantCode || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/html") || !strings.Contains(rec.Body.String(), tt.wantTitle) {
+			t.Errorf("%s %s = %d %q, want %d with the %q page", tt.method, tt.target, rec.Code, rec.Header().Get("Content-Type"), tt.wantCode, tt.wantTitle)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/ui/feeds", nil))
+	if allow := rec.Header().Get("Allow"); !strings.Contains(allow, "POST") {
+		t.Errorf("Allow = %q, want the page's methods", allow)
+	}
+}
@@ -0,0 +1,484 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/persist"
+)
+
+// =============================================================================
+// DISPATCHER - Persistent delivery queue with exponential backoff
+// =============================================================================
+
+// Options configures a Dispatcher.
+type Options struct {
+	// StateFile persists hooks and pending deliveries across restarts.
+	// Empty keeps everything in memory.
+	StateFile string
+
+	MaxAttempts    int           // Attempts before a delivery is abandoned
+	InitialBackoff time.Duration // Wait after the first failure; doubles each time
+	MaxBackoff     time.Duration // Upper bound on the wait between attempts
+	Timeout        time.Duration // Per-request timeout
+	LogSize        int           // Attempts kept per hook in the delivery log
+}
+
+// DefaultOptions returns production-friendly retry settings.
+func DefaultOptions() Options {
+	return Options{
+		MaxAttempts:    8,
+		InitialBackoff: 30 * time.Second,
+		MaxBackoff:     time.Hour,
+		Timeout:        10 * time.Second,
+		LogSize:        50,
+	}
+}
+
+// Delivery is one payload waiting to be sent to one hook.
+type Delivery struct {
+	ID          string          `json:"id"`
+	HookID      string          `json:"hook_id"`
+	Body        json.RawMessage `json:"body"`
+	Attempts    int             `json:"attempts"`
+	NextAttempt time.Time       `json:"next_attempt"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// Outcomes recorded in the delivery log.
+const (
+	OutcomeDelivered = "delivered"
+	OutcomeRetrying  = "retrying"
+	OutcomeFailed    = "failed" // Gave up after MaxAttempts
+)
+
+// Attempt is one entry in a hook's delivery log.
+type Attempt struct {
+	DeliveryID  string        `json:"delivery_id"`
+	Attempt     int           `json:"attempt"`
+	Time        time.Time     `json:"time"`
+	StatusCode  int           `json:"status_code,omitempty"`
+	Error       string        `json:"error,omitempty"`
+	Duration    time.Duration `json:"duration_ns"`
+	Outcome     string        `json:"outcome"`
+	NextAttempt *time.Time    `json:"next_attempt,omitempty"`
+}
+
+// state is the persisted form of the dispatcher.
+type state struct {
+	Hooks []Hook      `json:"hooks"`
+	Queue []*Delivery `json:"queue"`
+}
+
+// Dispatcher owns registered hooks, matches new articles against them,
+// and delivers signed payloads from a persistent retry queue.
+type Dispatcher struct {
+	opts   Options
+	client *http.Client
+	now    func() time.Time
+
+	mu    sync.Mutex
+	hooks map[string]Hook
+	queue []*Delivery
+	log   map[string][]Attempt // Keyed by hook ID, newest last
+	wake  chan struct{}
+}
+
+// NewDispatcher creates a dispatcher, restoring hooks and pending
+// deliveries from opts.StateFile if it exists.
+func NewDispatcher(opts Options, client *http.Client) (*Dispatcher, error) {
+	if client == nil {
+		client = &http.Client{Timeout: opts.Timeout}
+	}
+
+	d := &Dispatcher{
+		opts:   opts,
+		client: client,
+		now:    time.Now,
+		hooks:  make(map[string]Hook),
+		log:    make(map[string][]Attempt),
+		wake:   make(chan struct{}, 1),
+	}
+
+	if err := d.load(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// =============================================================================
+// HOOK REGISTRY
+// =============================================================================
+
+// AddHook validates and registers a hook, assigning its ID and, if none
+// was supplied, a random signing secret. The returned hook includes the
+// secret so it can be shown to the user once.
+func (d *Dispatcher) AddHook(hook Hook) (Hook, error) {
+	if err := hook.Validate(); err != nil {
+		return Hook{}, err
+	}
+
+	hook.ID = persist.NewID(8)
+	if hook.Secret == "" {
+		hook.Secret = persist.NewID(32)
+	}
+	hook.CreatedAt = d.now().UTC()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.hooks[hook.ID] = hook
+	if err := d.persistLocked(); err != nil {
+		delete(d.hooks, hook.ID)
+		return Hook{}, err
+	}
+	return hook, nil
+}
+
+// Hooks returns all hooks with secrets redacted, oldest first.
+func (d *Dispatcher) Hooks() []Hook {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	hooks := make([]Hook, 0, len(d.hooks))
+	for _, hook := range d.hooks {
+		hooks = append(hooks, hook.Redacted())
+	}
+	sort.Slice(hooks, func(i, j int) bool {
+		return hooks[i].CreatedAt.Before(hooks[j].CreatedAt)
+	})
+	return hooks
+}
+
+// RemoveHook deletes a hook and drops its pending deliveries.
+func (d *Dispatcher) RemoveHook(id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.hooks[id]; !ok {
+		return ErrNotFound
+	}
+	delete(d.hooks, id)
+	delete(d.log, id)
+
+	pending := d.queue[:0]
+	for _, delivery := range d.queue {
+		if delivery.HookID != id {
+			pending = append(pending, delivery)
+		}
+	}
+	d.queue = pending
+
+	return d.persistLocked()
+}
+
+// Deliveries returns the delivery log for a hook, newest first.
+func (d *Dispatcher) Deliveries(id string) ([]Attempt, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.hooks[id]; !ok {
+		return nil, ErrNotFound
+	}
+
+	entries := d.log[id]
+	result := make([]Attempt, len(entries))
+	for i, entry := range entries {
+		result[len(entries)-1-i] = entry
+	}
+	return result, nil
+}
+
+// =============================================================================
+// INGESTION AND DELIVERY
+// =============================================================================
+
+// Notify queues one delivery per hook for the articles it matches.
+// Its signature lets it subscribe directly to feed.ArticleNotifier.
+func (d *Dispatcher) Notify(articles []*feed.Article) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now().UTC()
+	queued := 0
+	for _, hook := range d.hooks {
+		var matched []PayloadArticle
+		for _, article := range articles {
+			if hook.Filter.Matches(article) {
+				matched = append(matched, toPayloadArticle(article))
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		id := persist.NewID(8)
+		body, err := json.Marshal(Payload{
+			DeliveryID: id,
+			Event:      EventArticlesIngested,
+			HookID:     hook.ID,
+			CreatedAt:  now,
+			Articles:   matched,
+		})
+		if err != nil {
+			slog.Error("failed to encode webhook payload", "hook", hook.ID, "error", err)
+			continue
+		}
+
+		d.queue = append(d.queue, &Delivery{
+			ID:          id,
+			HookID:      hook.ID,
+			Body:        body,
+			NextAttempt: now,
+			CreatedAt:   now,
+		})
+		queued++
+	}
+
+	if queued == 0 {
+		return
+	}
+	if err := d.persistLocked(); err != nil {
+		slog.Error("failed to persist webhook queue", "error", err)
+	}
+	d.signal()
+}
+
+// Run delivers queued payloads until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		d.deliverDue(ctx)
+
+		// With an empty queue, wait only for new deliveries
+		timer := time.NewTimer(time.Hour)
+		if wait, ok := d.untilNext(); ok {
+			timer.Reset(wait)
+		}
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-d.wake:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// signal wakes Run without blocking.
+func (d *Dispatcher) signal() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// untilNext returns the wait before the earliest pending delivery.
+func (d *Dispatcher) untilNext() (time.Duration, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.queue) == 0 {
+		return 0, false
+	}
+	next := d.queue[0].NextAttempt
+	for _, delivery := range d.queue[1:] {
+		if delivery.NextAttempt.Before(next) {
+			next = delivery.NextAttempt
+		}
+	}
+	return max(next.Sub(d.now()), 0), true
+}
+
+// deliverDue sends every delivery whose next attempt time has passed.
+func (d *Dispatcher) deliverDue(ctx context.Context) {
+	d.mu.Lock()
+	now := d.now()
+	var due []*Delivery
+	for _, delivery := range d.queue {
+		if !delivery.NextAttempt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+	d.mu.Unlock()
+
+	for _, delivery := range due {
+		if ctx.Err() != nil {
+			return
+		}
+
+		d.mu.Lock()
+		hook, ok := d.hooks[delivery.HookID]
+		d.mu.Unlock()
+		if !ok {
+			continue // Hook removed while we were sending
+		}
+
+		start := d.now()
+		status, err := d.send(ctx, hook, delivery)
+		d.recordResult(delivery, hook.ID, start, status, err)
+	}
+}
+
+// send POSTs one delivery and returns the response status.
+func (d *Dispatcher) send(ctx context.Context, hook Hook, delivery *Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(delivery.Body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Go-News-Webhooks/1.0")
+	req.Header.Set(SignatureHeader, Sign(hook.Secret, delivery.Body))
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	req.Header.Set(EventHeader, EventArticlesIngested)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to deliver: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// recordResult logs an attempt and removes or reschedules the delivery.
+func (d *Dispatcher) recordResult(delivery *Delivery, hookID string, start time.Time, status int, sendErr error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.hooks[hookID]; !ok {
+		return // Removed while the delivery was in flight, log and all
+	}
+
+	now := d.now()
+	delivery.Attempts++
+	entry := Attempt{
+		DeliveryID: delivery.ID,
+		Attempt:    delivery.Attempts,
+		Time:       start.UTC(),
+		StatusCode: status,
+		Duration:   now.Sub(start),
+	}
+
+	switch {
+	case sendErr == nil:
+		entry.Outcome = OutcomeDelivered
+		d.removeLocked(delivery.ID)
+	case delivery.Attempts >= d.opts.MaxAttempts:
+		entry.Outcome = OutcomeFailed
+		entry.Error = sendErr.Error()
+		d.removeLocked(delivery.ID)
+	default:
+		entry.Outcome = OutcomeRetrying
+		entry.Error = sendErr.Error()
+		delivery.NextAttempt = now.Add(d.backoff(delivery.Attempts))
+		next := delivery.NextAttempt.UTC()
+		entry.NextAttempt = &next
+	}
+
+	entries := append(d.log[hookID], entry)
+	if len(entries) > d.opts.LogSize {
+		entries = entries[len(entries)-d.opts.LogSize:]
+	}
+	d.log[hookID] = entries
+
+	if err := d.persistLocked(); err != nil {
+		slog.Error("failed to persist webhook queue", "error", err)
+	}
+}
+
+// backoff returns the wait after the given number of failed attempts:
+// InitialBackoff, doubling each time, capped at MaxBackoff.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.opts.InitialBackoff
+	for i := 1; i < attempts && wait < d.opts.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, d.opts.MaxBackoff)
+}
+
+func (d *Dispatcher) removeLocked(id string) {
+	for i, delivery := range d.queue {
+		if delivery.ID == id {
+			d.queue = append(d.queue[:i], d.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// =============================================================================
+// PERSISTENCE
+// =============================================================================
+
+// load restores state from the state file, if configured and present.
+func (d *Dispatcher) load() error {
+	if d.opts.StateFile == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(d.opts.StateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read webhook state: %w", err)
+	}
+
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("failed to parse webhook state %s: %w", d.opts.StateFile, err)
+	}
+	for _, hook := range s.Hooks {
+		d.hooks[hook.ID] = hook
+	}
+	d.queue = s.Queue
+	return nil
+}
+
+// persistLocked atomically writes hooks and the queue to the state file.
+// d.mu must be held.
+func (d *Dispatcher) persistLocked() error {
+	if d.opts.StateFile == "" {
+		return nil
+	}
+
+	s := state{Hooks: make([]Hook, 0, len(d.hooks)), Queue: d.queue}
+	for _, hook := range d.hooks {
+		s.Hooks = append(s.Hooks, hook)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook state: %w", err)
+	}
+
+	if err := persist.WriteFile(d.opts.StateFile, data); err != nil {
+		return fmt.Errorf("failed to write webhook state: %w", err)
+	}
+	return nil
+}
+
+// Delivery guarantees:
+//
+// - At-least-once: a delivery leaves the queue only after a 2xx response
+//   or MaxAttempts failures, and the queue survives restarts. Receivers
+//   should deduplicate on the X-News-Delivery header.
+// - Signed: the HMAC-SHA256 of the exact body bytes is sent in
+//   X-News-Signature-256, so receivers can reject forged requests.
+// - Non-blocking: Notify only enqueues, so a slow receiver never delays
+//   feed ingestion.
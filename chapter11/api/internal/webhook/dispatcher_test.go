@@ -0,0 +1,286 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/webhook"
+)
+
+// receiver is a test webhook endpoint that fails the first `failures`
+// requests with a 500 and records every request body it accepts.
+type receiver struct {
+	mu       sync.Mutex
+	secret   string
+	failures int
+	requests int
+	bodies   [][]byte
+	badSigs  int
+	received chan struct{}
+}
+
+func newReceiver(secret string, failures int) *receiver {
+	return &receiver{secret: secret, failures: failures, received: make(chan struct{}, 16)}
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.requests++
+	if !webhook.Verify(rc.secret, body, r.Header.Get(webhook.SignatureHeader)) {
+		rc.badSigs++
+	}
+	if rc.requests <= rc.failures {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	rc.bodies = append(rc.bodies, body)
+	w.WriteHeader(http.StatusNoContent)
+	rc.received <- struct{}{}
+}
+
+func (rc *receiver) wait(t *testing.T) {
+	t.Helper()
+	select {
+	case <-rc.received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for webhook delivery")
+	}
+}
+
+// run starts d's delivery loop and stops it when the test ends, before
+// any temp directories are removed.
+func run(t *testing.T, d *webhook.Dispatcher) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		d.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func testOptions(stateFile string) webhook.Options {
+	opts := webhook.DefaultOptions()
+	opts.StateFile = stateFile
+	opts.InitialBackoff = 10 * time.Millisecond
+	opts.MaxBackoff = 50 * time.Millisecond
+	opts.Timeout = time.Second
+	return opts
+}
+
+func TestFilterMatches(t *testing.T) {
+	article := &feed.Article{
+		Title:       "Go 1.23 released",
+		Description: "Iterators land in the standard library",
+		FeedTitle:   "Go Blog",
+		Tags:        []string{"golang", "release"},
+	}
+
+	tests := []struct {
+		name   string
+		filter webhook.Filter
+		want   bool
+	}{
+		{"empty filter", webhook.Filter{}, true},
+		{"feed match is case-insensitive", webhook.Filter{Feeds: []string{"go blog"}}, true},
+		{"other feed", webhook.Filter{Feeds: []string{"Hacker News"}}, false},
+		{"keyword in description", webhook.Filter{Keywords: []string{"ITERATORS"}}, true},
+		{"any keyword may match", webhook.Filter{Keywords: []string{"rust", "released"}}, true},
+		{"no keyword", webhook.Filter{Keywords: []string{"rust"}}, false},
+		{"tag match", webhook.Filter{Tags: []string{"Release"}}, true},
+		{"all criteria must match", webhook.Filter{Feeds: []string{"Go Blog"}, Tags: []string{"security"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(article); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDispatcherRetriesAndSigns(t *testing.T) {
+	rc := newReceiver("s3cret", 1)
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	d, err := webhook.NewDispatcher(testOptions(""), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hook, err := d.AddHook(webhook.Hook{
+		URL:    srv.URL,
+		Secret: "s3cret",
+		Filter: webhook.Filter{Keywords: []string{"go"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run(t, d)
+
+	d.Notify([]*feed.Article{
+		{Title: "Go news", Link: "https://example.com/go"},
+		{Title: "Rust news", Link: "https://example.com/rust"},
+	})
+	rc.wait(t)
+
+	rc.mu.Lock()
+	if rc.badSigs != 0 {
+		t.Errorf("%d requests had an invalid signature", rc.badSigs)
+	}
+	if rc.requests != 2 {
+		t.Errorf("receiver got %d requests, want 2 (one failure, one retry)", rc.requests)
+	}
+	var payload webhook.Payload
+	if err := json.Unmarshal(rc.bodies[0], &payload); err != nil {
+		t.Fatal(err)
+	}
+	rc.mu.Unlock()
+
+	if payload.HookID != hook.ID || payload.Event != webhook.EventArticlesIngested {
+		t.Errorf("payload = %+v", payload)
+	}
+	if len(payload.Articles) != 1 || payload.Articles[0].Title != "Go news" {
+		t.Errorf("payload articles = %+v, want only the matching article", payload.Articles)
+	}
+
+	// The log is written after the response, so poll briefly for it
+	var log []webhook.Attempt
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if log, _ = d.Deliveries(hook.ID); len(log) == 2 {
+			break
+		}
+	}
+	if len(log) != 2 {
+		t.Fatalf("delivery log has %d entries, want 2", len(log))
+	}
+	if log[0].Outcome != webhook.OutcomeDelivered || log[1].Outcome != webhook.OutcomeRetrying {
+		t.Errorf("outcomes = %s, %s; want delivered, retrying", log[0].Outcome, log[1].Outcome)
+	}
+	if log[1].StatusCode != http.StatusInternalServerError || log[1].NextAttempt == nil {
+		t.Errorf("retry entry = %+v", log[1])
+	}
+}
+
+func TestDispatcherPersistsQueue(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "webhooks.json")
+	rc := newReceiver("s3cret", 0)
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	// Queue a delivery without running the sender, as if we crashed
+	first, err := webhook.NewDispatcher(testOptions(stateFile), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hook, err := first.AddHook(webhook.Hook{URL: srv.URL, Secret: "s3cret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.Notify([]*feed.Article{{Title: "Persisted", Link: "https://example.com/p"}})
+
+	second, err := webhook.NewDispatcher(testOptions(stateFile), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hooks := second.Hooks()
+	if len(hooks) != 1 || hooks[0].ID != hook.ID {
+		t.Fatalf("restored hooks = %+v", hooks)
+	}
+	if hooks[0].Secret != "REDACTED" {
+		t.Errorf("Hooks() exposed secret %q", hooks[0].Secret)
+	}
+
+	run(t, second)
+	rc.wait(t)
+
+	if err := second.RemoveHook(hook.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := second.RemoveHook(hook.ID); err != webhook.ErrNotFound {
+		t.Errorf("second RemoveHook error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRemoveHookDuringDelivery(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "webhooks.json")
+	arrived, release := make(chan struct{}), make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(arrived)
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	d, err := webhook.NewDispatcher(testOptions(stateFile), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hook, err := d.AddHook(webhook.Hook{URL: srv.URL, Secret: "s3cret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		d.Run(ctx)
+		close(done)
+	}()
+
+	d.Notify([]*feed.Article{{Title: "In flight", Link: "https://example.com/f"}})
+	select {
+	case <-arrived:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for webhook delivery")
+	}
+	if err := d.RemoveHook(hook.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(stateFile); err != nil {
+		t.Fatal(err)
+	}
+
+	// Run returns only once the in-flight delivery's result is handled
+	close(release)
+	cancel()
+	<-done
+
+	if _, err := os.Stat(stateFile); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("state written after the hook was removed: %v", err)
+	}
+	if _, err := d.Deliveries(hook.ID); err != webhook.ErrNotFound {
+		t.Errorf("Deliveries error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestAddHookRejectsBadURL(t *testing.T) {
+	d, err := webhook.NewDispatcher(testOptions(""), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, raw := range []string{"", "ftp://example.com/hook", "http://"} {
+		if _, err := d.AddHook(webhook.Hook{URL: raw}); err == nil {
+			t.Errorf("AddHook(%q) succeeded, want error", raw)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+)
+
+// =============================================================================
+// WEBHOOKS - Registered receivers and the rules that select articles
+// =============================================================================
+
+// Headers sent with every delivery.
+const (
+	SignatureHeader = "X-News-Signature-256" // "sha256=" + hex HMAC of the body
+	DeliveryHeader  = "X-News-Delivery"      // Delivery ID, stable across retries
+	EventHeader     = "X-News-Event"
+)
+
+// EventArticlesIngested is the only event type sent today.
+const EventArticlesIngested = "articles.ingested"
+
+// ErrNotFound is returned when a webhook ID doesn't exist.
+var ErrNotFound = errors.New("webhook not found")
+
+// Hook is a registered webhook receiver.
+type Hook struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"` // HMAC-SHA256 signing key
+	Filter    Filter    `json:"filter"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Filter selects the articles a hook receives. Each non-empty criterion
+// must match (AND); within a criterion any value may match (OR).
+// An empty filter matches every article.
+type Filter struct {
+	Feeds    []string `json:"feeds,omitempty"`    // Feed titles, case-insensitive
+	Keywords []string `json:"keywords,omitempty"` // Substrings of title or description
+	Tags     []string `json:"tags,omitempty"`     // Article tags
+}
+
+// Matches reports whether article passes the filter.
+func (f Filter) Matches(article *feed.Article) bool {
+	if len(f.Feeds) > 0 && !slices.ContainsFunc(f.Feeds, func(name string) bool {
+		return strings.EqualFold(name, article.FeedTitle)
+	}) {
+		return false
+	}
+
+	if len(f.Keywords) > 0 {
+		text := strings.ToLower(article.Title + " " + article.Description)
+		if !slices.ContainsFunc(f.Keywords, func(keyword string) bool {
+			return strings.Contains(text, strings.ToLower(keyword))
+		}) {
+			return false
+		}
+	}
+
+	if len(f.Tags) > 0 && !slices.ContainsFunc(f.Tags, func(tag string) bool {
+		return slices.ContainsFunc(article.Tags, func(t string) bool {
+			return strings.EqualFold(t, tag)
+		})
+	}) {
+		return false
+	}
+
+	return true
+}
+
+// Validate checks the hook's URL.
+func (h Hook) Validate() error {
+	u, err := url.Parse(h.URL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("webhook URL %q must use http or https", h.URL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("webhook URL %q has no host", h.URL)
+	}
+	return nil
+}
+
+// Redacted returns a copy of the hook with its secret masked.
+func (h Hook) Redacted() Hook {
+	if h.Secret != "" {
+		h.Secret = "REDACTED"
+	}
+	return h
+}
+
+// Sign returns the signature header value for body.
+// Receivers recompute it with their copy of the secret and compare using
+// hmac.Equal to verify the payload came from us and wasn't modified.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature produced by Sign in constant time.
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// Payload is the JSON body POSTed to receivers.
+// Fields use explicit snake_case names so receivers get a stable contract.
+type Payload struct {
+	DeliveryID string           `json:"delivery_id"`
+	Event      string           `json:"event"`
+	HookID     string           `json:"hook_id"`
+	CreatedAt  time.Time        `json:"created_at"`
+	Articles   []PayloadArticle `json:"articles"`
+}
+
+// PayloadArticle is one article in a Payload.
+type PayloadArticle struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Link        string     `json:"link"`
+	Published   *time.Time `json:"published,omitempty"`
+	FeedTitle   string     `json:"feed_title"`
+	Tags        []string   `json:"tags,omitempty"`
+}
+
+func toPayloadArticle(a *feed.Article) PayloadArticle {
+	return PayloadArticle{
+		Title:       a.Title,
+		Description: a.Description,
+		Link:        a.Link,
+		Published:   a.Published,
+		FeedTitle:   a.FeedTitle,
+		Tags:        a.Tags,
+	}
+}
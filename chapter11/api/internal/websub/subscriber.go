@@ -0,0 +1,393 @@
+package websub
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/persist"
+)
+
+// =============================================================================
+// SUBSCRIBER - Subscribing through hubs and receiving their pushes
+// =============================================================================
+
+// Compile-time verification that Subscriber implements feed.HubWatcher
+var _ feed.HubWatcher = (*Subscriber)(nil)
+
+// Ingester parses and stores a pushed feed document.
+type Ingester interface {
+	Ingest(url, contentType string, body []byte) (*feed.Feed, error)
+}
+
+// Subscriber keeps a WebSub subscription for every polled feed that
+// advertises a hub, renews leases before they end, and ingests what the
+// hubs push.
+type Subscriber struct {
+	opts     Options
+	client   *http.Client
+	ingester Ingester
+	now      func() time.Time
+
+	mu      sync.Mutex
+	subs    map[string]*Subscription // Keyed by feed URL
+	leaving map[string]*Subscription // Keyed by ID; being unsubscribed
+	wake    chan struct{}
+}
+
+// NewSubscriber creates a subscriber that stores pushed content through
+// ingester. client may be nil to use one with opts.Timeout.
+func NewSubscriber(opts Options, ingester Ingester, client *http.Client) *Subscriber {
+	if client == nil {
+		client = &http.Client{Timeout: opts.Timeout}
+	}
+	return &Subscriber{
+		opts:     opts,
+		client:   client,
+		ingester: ingester,
+		now:      time.Now,
+		subs:     make(map[string]*Subscription),
+		leaving:  make(map[string]*Subscription),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// HubAdvertised implements feed.HubWatcher. A new or changed hub starts a
+// subscription, replacing any previous one; no hub ends it. An empty
+// topic means the feed's own URL.
+func (s *Subscriber) HubAdvertised(feedURL, hub, topic string) {
+	if topic == "" {
+		topic = feedURL
+	}
+	if hub != "" && !isHTTPURL(hub) {
+		slog.Debug("ignoring WebSub hub that isn't an http URL", "feed", feedURL, "hub", hub)
+		hub = ""
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.subs[feedURL]
+	if ok && current.Hub == hub && current.Topic == topic {
+		return
+	}
+	if ok {
+		s.leaveLocked(current)
+	}
+	if hub != "" {
+		s.subs[feedURL] = &Subscription{
+			ID:      persist.NewID(16),
+			FeedURL: feedURL,
+			Topic:   topic,
+			Hub:     hub,
+			State:   StatePending,
+			secret:  persist.NewID(32),
+		}
+	}
+	s.signal()
+}
+
+// Subscriptions returns every push subscription, sorted by feed URL.
+func (s *Subscriber) Subscriptions() []Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]Subscription, 0, len(s.subs))
+	for _, sub := range s.subs {
+		result = append(result, *sub)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].FeedURL < result[j].FeedURL
+	})
+	return result
+}
+
+// Run sends subscribe, renewal and unsubscribe requests until ctx is
+// cancelled. polled returns the feeds being polled; subscriptions for
+// any others are ended.
+func (s *Subscriber) Run(ctx context.Context, polled func() []string) {
+	for {
+		s.reconcile(ctx, polled())
+
+		timer := time.NewTimer(s.opts.CheckInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.wake:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// signal wakes Run without blocking.
+func (s *Subscriber) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// =============================================================================
+// REQUESTS TO HUBS
+// =============================================================================
+
+// hubRequest is a subscribe or unsubscribe request to send.
+type hubRequest struct {
+	mode string
+	sub  Subscription
+}
+
+// reconcile ends subscriptions for feeds that are no longer polled, then
+// sends every request that is due: new subscriptions, renewals, retries
+// and unsubscribes.
+func (s *Subscriber) reconcile(ctx context.Context, polled []string) {
+	wanted := make(map[string]bool, len(polled))
+	for _, url := range polled {
+		wanted[url] = true
+	}
+
+	s.mu.Lock()
+	now := s.now()
+	var due []hubRequest
+	for url, sub := range s.subs {
+		if !wanted[url] {
+			s.leaveLocked(sub)
+			continue
+		}
+		if sub.State != StateDenied && !sub.nextAttempt.After(now) {
+			// Retried if the hub doesn't verify in time; verification
+			// moves this to the renewal time
+			sub.nextAttempt = now.Add(s.opts.RetryDelay)
+			due = append(due, hubRequest{ModeSubscribe, *sub})
+		}
+	}
+	for id, sub := range s.leaving {
+		switch {
+		case !sub.unsubscribed:
+			sub.unsubscribed = true
+			sub.nextAttempt = now.Add(s.opts.RetryDelay) // Time allowed for the hub to verify
+			due = append(due, hubRequest{ModeUnsubscribe, *sub})
+		case !sub.nextAttempt.After(now):
+			delete(s.leaving, id)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, req := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		err := s.send(ctx, req.mode, req.sub)
+		if req.mode == ModeSubscribe {
+			s.recordSend(req.sub, err)
+		} else if err != nil {
+			slog.Warn("WebSub unsubscribe failed", "feed", req.sub.FeedURL, "hub", req.sub.Hub, "error", err)
+		}
+	}
+}
+
+// send POSTs a subscribe or unsubscribe request to the subscription's hub.
+// The hub answers 202 and verifies the intent separately.
+func (s *Subscriber) send(ctx context.Context, mode string, sub Subscription) error {
+	form := url.Values{
+		"hub.mode":     {mode},
+		"hub.topic":    {sub.Topic},
+		"hub.callback": {s.callbackURL(sub.ID)},
+	}
+	if mode == ModeSubscribe {
+		form.Set("hub.lease_seconds", strconv.Itoa(int(s.opts.Lease.Seconds())))
+		form.Set("hub.secret", sub.secret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Hub, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", "Go-News-WebSub/1.0")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach hub: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("hub returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// recordSend notes a failed subscribe request. A subscription that is
+// still active keeps receiving pushes until its lease ends.
+func (s *Subscriber) recordSend(sent Subscription, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, ok := s.subs[sent.FeedURL]
+	if !ok || sub.ID != sent.ID {
+		return // Replaced or ended while the request was in flight
+	}
+	if err == nil {
+		slog.Debug("WebSub subscription requested", "feed", sub.FeedURL, "hub", sub.Hub)
+		return
+	}
+
+	slog.Warn("WebSub subscribe failed", "feed", sub.FeedURL, "hub", sub.Hub, "error", err)
+	sub.LastError = err.Error()
+	if sub.State != StateActive {
+		sub.State = StateFailed
+	}
+}
+
+// leaveLocked ends sub, unsubscribing from the hub if it may have
+// accepted it. s.mu must be held.
+func (s *Subscriber) leaveLocked(sub *Subscription) {
+	delete(s.subs, sub.FeedURL)
+	if sub.State == StateActive || sub.State == StatePending {
+		leaving := *sub
+		leaving.nextAttempt = time.Time{}
+		s.leaving[sub.ID] = &leaving
+	}
+	s.signal()
+}
+
+// callbackURL returns the URL the hub calls for the subscription id.
+func (s *Subscriber) callbackURL(id string) string {
+	return strings.TrimSuffix(s.opts.CallbackURL, "/") + "/" + id
+}
+
+// =============================================================================
+// CALLBACKS FROM HUBS
+// =============================================================================
+
+// Verify answers a hub's intent verification for the callback id. A nil
+// error means the intent matches a request we made; the handler then
+// echoes the challenge.
+func (s *Subscriber) Verify(id string, intent Intent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if intent.Mode == ModeUnsubscribe {
+		sub, ok := s.leaving[id]
+		if !ok || sub.Topic != intent.Topic || intent.Challenge == "" {
+			return ErrIntentMismatch
+		}
+		delete(s.leaving, id)
+		slog.Info("WebSub unsubscribe verified", "feed", sub.FeedURL, "hub", sub.Hub)
+		return nil
+	}
+
+	sub := s.findLocked(id)
+	if sub == nil || sub.Topic != intent.Topic {
+		return ErrIntentMismatch
+	}
+
+	switch intent.Mode {
+	case ModeSubscribe:
+		if intent.Challenge == "" {
+			return ErrIntentMismatch
+		}
+		lease := s.opts.Lease
+		if intent.LeaseSeconds > 0 {
+			lease = time.Duration(intent.LeaseSeconds) * time.Second
+		}
+		expires := s.now().Add(lease)
+		sub.State = StateActive
+		sub.LeaseExpires = &expires
+		sub.LastError = ""
+		sub.nextAttempt = expires.Add(-lease / 10) // Renew with a tenth of the lease to spare
+		slog.Info("WebSub subscription verified", "feed", sub.FeedURL, "hub", sub.Hub, "lease", lease)
+		return nil
+	case ModeDenied:
+		sub.State = StateDenied
+		sub.LeaseExpires = nil
+		sub.LastError = intent.Reason
+		slog.Warn("WebSub subscription denied", "feed", sub.FeedURL, "hub", sub.Hub, "reason", intent.Reason)
+		return nil
+	default:
+		return ErrIntentMismatch
+	}
+}
+
+// Receive ingests content a hub pushed to the callback id. Content with a
+// missing or wrong signature is dropped with ErrInvalidSignature, and
+// content over MaxBodySize with ErrTooLarge.
+func (s *Subscriber) Receive(id, contentType, signature string, body io.Reader) error {
+	data, err := io.ReadAll(io.LimitReader(body, s.opts.MaxBodySize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read pushed content: %w", err)
+	}
+	if int64(len(data)) > s.opts.MaxBodySize {
+		return fmt.Errorf("%w: over the %d byte limit", ErrTooLarge, s.opts.MaxBodySize)
+	}
+
+	s.mu.Lock()
+	sub := s.findLocked(id)
+	var feedURL, secret string
+	if sub != nil {
+		feedURL, secret = sub.FeedURL, sub.secret
+	}
+	s.mu.Unlock()
+
+	if sub == nil {
+		return ErrNotFound
+	}
+	if !VerifySignature(secret, data, signature) {
+		slog.Warn("dropping WebSub push with a bad signature", "feed", feedURL)
+		return ErrInvalidSignature
+	}
+
+	if _, err := s.ingester.Ingest(feedURL, contentType, data); err != nil {
+		return fmt.Errorf("failed to ingest pushed content: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sub := s.findLocked(id); sub != nil {
+		now := s.now()
+		sub.LastPush = &now
+	}
+	return nil
+}
+
+// findLocked returns the current subscription with the given ID, or nil.
+// s.mu must be held.
+func (s *Subscriber) findLocked(id string) *Subscription {
+	for _, sub := range s.subs {
+		if sub.ID == id {
+			return sub
+		}
+	}
+	return nil
+}
+
+// isHTTPURL reports whether raw is an absolute http or https URL.
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// Why polling continues for pushed feeds, and state stays in memory:
+//
+// - Hubs drop subscriptions, miss pushes and go down. Polling at the
+//   usual interval bounds how stale a feed can get; pushes just make
+//   most updates arrive sooner.
+// - Secrets and leases aren't persisted: after a restart the first fetch
+//   of each feed reports its hub again and we subscribe afresh. Pushes
+//   to old callbacks get 410 Gone, which tells hubs to stop sending.
+// - Pushes with a bad or missing signature get a 2xx as WebSub requires,
+//   so a forger learns nothing, but are never stored. Hubs that ignore
+//   hub.secret therefore only ever feed us through polling.
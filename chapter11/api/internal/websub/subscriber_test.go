@@ -0,0 +1,300 @@
+package websub_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/YOUR_USERNAME/go-news/api/internal/feed"
+	"github.com/YOUR_USERNAME/go-news/api/internal/handlers"
+	"github.com/YOUR_USERNAME/go-news/api/internal/websub"
+)
+
+// localHub is a stand-in WebSub hub. It accepts subscribe and unsubscribe
+// requests, verifies each intent against the callback like a real hub
+// would, and can publish content to verified subscribers.
+type localHub struct {
+	t     *testing.T
+	lease int // lease_seconds granted on verification
+
+	mu        sync.Mutex
+	callbacks map[string]string // Topic to callback
+	secrets   map[string]string // Topic to secret
+	verified  chan url.Values   // Every successfully verified request
+}
+
+func newLocalHub(t *testing.T, lease int) (*localHub, *httptest.Server) {
+	hub := &localHub{
+		t:         t,
+		lease:     lease,
+		callbacks: make(map[string]string),
+		secrets:   make(map[string]string),
+		verified:  make(chan url.Values, 16),
+	}
+	srv := httptest.NewServer(hub)
+	t.Cleanup(srv.Close)
+	return hub, srv
+}
+
+func (h *localHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.Method != http.MethodPost {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	req := r.PostForm
+	w.WriteHeader(http.StatusAccepted)
+
+	// Verify asynchronously, as hubs do
+	go func() {
+		challenge := "challenge-" + req.Get("hub.mode")
+		query := url.Values{
+			"hub.mode":      {req.Get("hub.mode")},
+			"hub.topic":     {req.Get("hub.topic")},
+			"hub.challenge": {challenge},
+		}
+		if req.Get("hub.mode") == websub.ModeSubscribe {
+			query.Set("hub.lease_seconds", strconv.Itoa(h.lease))
+		}
+
+		resp, err := http.Get(req.Get("hub.callback") + "?" + query.Encode())
+		if err != nil {
+			h.t.Errorf("verification request failed: %v", err)
+			return
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || string(body) != challenge {
+			return // The subscriber didn't confirm
+		}
+
+		h.mu.Lock()
+		if req.Get("hub.mode") == websub.ModeSubscribe {
+			h.callbacks[req.Get("hub.topic")] = req.Get("hub.callback")
+			h.secrets[req.Get("hub.topic")] = req.Get("hub.secret")
+		} else {
+			delete(h.callbacks, req.Get("hub.topic"))
+		}
+		h.mu.Unlock()
+		h.verified <- req
+	}()
+}
+
+// waitVerified returns the next verified request.
+func (h *localHub) waitVerified(t *testing.T) url.Values {
+	t.Helper()
+	select {
+	case req := <-h.verified:
+		return req
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the hub to verify a request")
+		return nil
+	}
+}
+
+// publish pushes body to the topic's subscriber, signed with secret
+// (the subscriber's own if empty), and returns the response status.
+func (h *localHub) publish(t *testing.T, topic, body, secret string) int {
+	t.Helper()
+	h.mu.Lock()
+	callback := h.callbacks[topic]
+	if secret == "" {
+		secret = h.secrets[topic]
+	}
+	h.mu.Unlock()
+
+	req, _ := http.NewRequest(http.MethodPost, callback, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/rss+xml")
+	req.Header.Set(websub.SignatureHeader, websub.Sign("sha256", secret, []byte(body)))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+// fakeIngester records pushed documents.
+type fakeIngester struct {
+	mu     sync.Mutex
+	bodies map[string][]string // Feed URL to bodies
+}
+
+func (f *fakeIngester) Ingest(url, contentType string, body []byte) (*feed.Feed, error) {
+	if !strings.Contains(string(body), "<rss") {
+		return nil, errors.New("not a feed")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.bodies[url] = append(f.bodies[url], string(body))
+	return &feed.Feed{}, nil
+}
+
+func (f *fakeIngester) count(url string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.bodies[url])
+}
+
+// polledFeeds is a mutable polled-feed list for Subscriber.Run.
+type polledFeeds struct {
+	mu   sync.Mutex
+	urls []string
+}
+
+func (p *polledFeeds) set(urls ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.urls = urls
+}
+
+func (p *polledFeeds) get() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.urls
+}
+
+// newTestSubscriber starts a subscriber whose callbacks are served by the
+// real handlers.
+func newTestSubscriber(t *testing.T, polled *polledFeeds) (*websub.Subscriber, *fakeIngester, string) {
+	t.Helper()
+	mux := http.NewServeMux()
+	callbacks := httptest.NewServer(mux)
+	t.Cleanup(callbacks.Close)
+
+	opts := websub.DefaultOptions()
+	opts.CallbackURL = callbacks.URL + "/websub/callback"
+	opts.Lease = time.Hour
+	opts.CheckInterval = 20 * time.Millisecond
+	opts.RetryDelay = time.Hour
+	opts.MaxBodySize = 1 << 10
+
+	ingester := &fakeIngester{bodies: make(map[string][]string)}
+	sub := websub.NewSubscriber(opts, ingester, nil)
+	handlers.NewWebSubHandlers(sub).RegisterCallbacks(mux)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go sub.Run(ctx, polled.get)
+	return sub, ingester, callbacks.URL
+}
+
+const pushedRSS = `<rss version="2.0"><channel><title>T</title><item><title>Pushed</title></item></channel></rss>`
+
+func TestSubscriberWithLocalHub(t *testing.T) {
+	hub, hubSrv := newLocalHub(t, 3600)
+	const feedURL = "https://example.com/rss"
+	const topic = "https://example.com/rss?self"
+	polled := &polledFeeds{}
+	polled.set(feedURL)
+	sub, ingester, callbackBase := newTestSubscriber(t, polled)
+
+	sub.HubAdvertised(feedURL, hubSrv.URL, topic)
+	req := hub.waitVerified(t)
+	if req.Get("hub.topic") != topic || req.Get("hub.lease_seconds") != "3600" || len(req.Get("hub.secret")) < 32 {
+		t.Errorf("subscribe request = %v, want topic, lease and a secret", req)
+	}
+
+	subs := sub.Subscriptions()
+	if len(subs) != 1 || subs[0].State != websub.StateActive || subs[0].LeaseExpires == nil {
+		t.Fatalf("Subscriptions = %+v, want one active subscription", subs)
+	}
+	if time.Until(*subs[0].LeaseExpires) < 59*time.Minute {
+		t.Errorf("lease expires %s, want the hour the hub granted", subs[0].LeaseExpires)
+	}
+
+	// Signed content is stored; forged content is acknowledged but dropped
+	if status := hub.publish(t, topic, pushedRSS, ""); status != http.StatusNoContent {
+		t.Errorf("signed push status = %d, want 204", status)
+	}
+	if status := hub.publish(t, topic, pushedRSS, "not-the-secret"); status != http.StatusAccepted {
+		t.Errorf("forged push status = %d, want 202", status)
+	}
+	oversized := strings.Replace(pushedRSS, "Pushed", strings.Repeat("x", 1<<10), 1)
+	if status := hub.publish(t, topic, oversized, ""); status != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized push status = %d, want 413", status)
+	}
+	signature := websub.Sign("sha256", "any", []byte(oversized))
+	if err := sub.Receive(subs[0].ID, "application/rss+xml", signature, strings.NewReader(oversized)); !errors.Is(err, websub.ErrTooLarge) {
+		t.Errorf("Receive oversized error = %v, want ErrTooLarge", err)
+	}
+	if got := ingester.count(feedURL); got != 1 {
+		t.Errorf("ingested %d pushes, want only the signed one", got)
+	}
+
+	// Unknown callbacks are gone; verifications we didn't ask for fail
+	resp, err := http.Post(callbackBase+"/websub/callback/unknown", "application/rss+xml", strings.NewReader(pushedRSS))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusGone {
+		t.Errorf("push to unknown callback = %d, want 410", resp.StatusCode)
+	}
+	if err := sub.Verify(subs[0].ID, websub.Intent{Mode: websub.ModeSubscribe, Topic: "https://evil.example/", Challenge: "x"}); !errors.Is(err, websub.ErrIntentMismatch) {
+		t.Errorf("Verify for another topic error = %v, want ErrIntentMismatch", err)
+	}
+
+	// Unsubscribing the feed unsubscribes from the hub
+	polled.set()
+	if req := hub.waitVerified(t); req.Get("hub.mode") != websub.ModeUnsubscribe || req.Get("hub.topic") != topic {
+		t.Errorf("request after unsubscribing = %v, want an unsubscribe", req)
+	}
+	if subs := sub.Subscriptions(); len(subs) != 0 {
+		t.Errorf("Subscriptions = %+v, want none", subs)
+	}
+}
+
+func TestSubscriberRenewsLease(t *testing.T) {
+	hub, hubSrv := newLocalHub(t, 1) // Renewed 0.9s after verification
+	const feedURL = "https://example.com/atom"
+	polled := &polledFeeds{}
+	polled.set(feedURL)
+	sub, _, _ := newTestSubscriber(t, polled)
+
+	sub.HubAdvertised(feedURL, hubSrv.URL, "")
+	first := hub.waitVerified(t)
+	renewal := hub.waitVerified(t)
+	if renewal.Get("hub.mode") != websub.ModeSubscribe || renewal.Get("hub.callback") != first.Get("hub.callback") {
+		t.Errorf("renewal = %v, want a subscribe to the same callback as %v", renewal, first)
+	}
+	if first.Get("hub.topic") != feedURL {
+		t.Errorf("topic = %q, want the feed URL when none is advertised", first.Get("hub.topic"))
+	}
+
+	// A feed that stops advertising its hub is unsubscribed
+	sub.HubAdvertised(feedURL, "", "")
+	if req := hub.waitVerified(t); req.Get("hub.mode") != websub.ModeUnsubscribe {
+		t.Errorf("request after the hub disappeared = %v, want an unsubscribe", req)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(pushedRSS)
+	tests := []struct {
+		name      string
+		signature string
+		want      bool
+	}{
+		{"sha1", websub.Sign("sha1", "s", body), true},
+		{"sha256", websub.Sign("sha256", "s", body), true},
+		{"sha512 upper case", strings.ToUpper(websub.Sign("sha512", "s", body)), true},
+		{"wrong secret", websub.Sign("sha256", "t", body), false},
+		{"unknown method", "md5=0123", false},
+		{"missing", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := websub.VerifySignature("s", body, tt.signature); got != tt.want {
+				t.Errorf("VerifySignature(%q) = %v, want %v", tt.signature, got, tt.want)
+			}
+		})
+	}
+}
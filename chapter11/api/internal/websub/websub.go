@@ -0,0 +1,141 @@
+package websub
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// WEBSUB - Push subscriptions to feeds that advertise a hub
+// =============================================================================
+
+// SignatureHeader carries the hub's HMAC of each pushed body, as
+// "method=hex", keyed with the secret we sent when subscribing.
+const SignatureHeader = "X-Hub-Signature"
+
+// Modes sent to hubs and received in verification requests.
+const (
+	ModeSubscribe   = "subscribe"
+	ModeUnsubscribe = "unsubscribe"
+	ModeDenied      = "denied" // The hub refused a subscription
+)
+
+// Subscription states.
+const (
+	StatePending = "pending" // Requested; the hub hasn't verified it yet
+	StateActive  = "active"  // Verified; content is pushed until the lease ends
+	StateDenied  = "denied"  // The hub refused; the feed is only polled
+	StateFailed  = "failed"  // The request to the hub failed; retried later
+)
+
+var (
+	// ErrNotFound is returned for a callback ID we don't know, e.g. from
+	// before a restart.
+	ErrNotFound = errors.New("websub subscription not found")
+
+	// ErrIntentMismatch is returned when a hub verifies a request we
+	// didn't make.
+	ErrIntentMismatch = errors.New("verification does not match a pending request")
+
+	// ErrInvalidSignature is returned for pushed content whose
+	// X-Hub-Signature is missing or wrong. Hubs must still get a 2xx.
+	ErrInvalidSignature = errors.New("missing or invalid X-Hub-Signature")
+
+	// ErrTooLarge is returned for pushed content over MaxBodySize, which
+	// is rejected before its signature is checked.
+	ErrTooLarge = errors.New("pushed content is too large")
+)
+
+// Options configures a Subscriber.
+type Options struct {
+	// CallbackURL is the public URL of /websub/callback on this server,
+	// as hubs reach it. Each subscription appends its own ID.
+	CallbackURL string
+
+	Lease         time.Duration // Lease requested from hubs; renewed before it ends
+	RetryDelay    time.Duration // Wait before retrying a failed or unverified request
+	CheckInterval time.Duration // How often leases and polled feeds are checked
+	Timeout       time.Duration // Per-request timeout for hub requests
+	MaxBodySize   int64         // Largest pushed body read
+}
+
+// DefaultOptions returns production-friendly settings without a callback
+// URL.
+func DefaultOptions() Options {
+	return Options{
+		Lease:         24 * time.Hour,
+		RetryDelay:    5 * time.Minute,
+		CheckInterval: time.Minute,
+		Timeout:       10 * time.Second,
+		MaxBodySize:   10 << 20,
+	}
+}
+
+// Subscription is a push subscription for one polled feed.
+type Subscription struct {
+	ID           string     `json:"id"`       // Random; the last segment of the callback URL
+	FeedURL      string     `json:"feed_url"` // The polled feed
+	Topic        string     `json:"topic"`    // The URL the hub knows the feed by
+	Hub          string     `json:"hub"`
+	State        string     `json:"state"`
+	LeaseExpires *time.Time `json:"lease_expires,omitempty"`
+	LastPush     *time.Time `json:"last_push,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+
+	secret       string    // HMAC key the hub signs pushes with; never exposed
+	nextAttempt  time.Time // When the next request to the hub is due
+	unsubscribed bool      // For a leaving subscription: the request was sent
+}
+
+// Intent is a hub's verification request, from the hub.* query parameters.
+type Intent struct {
+	Mode         string
+	Topic        string
+	Challenge    string // Echoed back to confirm subscribe and unsubscribe
+	LeaseSeconds int    // Granted lease; 0 if the hub didn't say
+	Reason       string // Why the hub denied a subscription
+}
+
+// signatureHashes are the X-Hub-Signature methods WebSub allows.
+var signatureHashes = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// Sign returns the X-Hub-Signature value a hub sends for body, using
+// method (sha1, sha256, sha384 or sha512). An unknown method returns "".
+func Sign(method, secret string, body []byte) string {
+	newHash, ok := signatureHashes[method]
+	if !ok {
+		return ""
+	}
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(body)
+	return method + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks an X-Hub-Signature value in constant time.
+func VerifySignature(secret string, body []byte, signature string) bool {
+	method, _, ok := strings.Cut(signature, "=")
+	if !ok || secret == "" {
+		return false
+	}
+	expected := Sign(strings.ToLower(method), secret, body)
+	return expected != "" && hmac.Equal([]byte(expected), []byte(strings.ToLower(signature)))
+}
+
+// Why a random ID per subscription in the callback URL:
+//
+// - A hub can be verifying an unsubscribe for a feed's old hub while the
+//   new hub verifies its subscribe; distinct callbacks keep them apart.
+// - Callback URLs are public. An unguessable ID means nobody can push to
+//   one without also knowing it, on top of the signature check.